@@ -0,0 +1,110 @@
+// Package apperror defines the typed errors resolvers return to clients.
+//
+// Every Error carries a Code that the GraphQL error presenter copies into the
+// error's extensions, so clients can branch on it instead of parsing
+// messages. Errors about invalid input additionally list the offending
+// fields by their path in the operation's arguments, e.g. "input.userId".
+// Conflicts may carry the current state of the entity a write clashed with.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code classifies an error for clients
+type Code string
+
+const (
+	CodeNotFound        Code = "NOT_FOUND"
+	CodeInvalidArgument Code = "INVALID_ARGUMENT"
+	CodeConflict        Code = "CONFLICT"
+	CodeUnauthenticated Code = "UNAUTHENTICATED"
+	CodeForbidden       Code = "FORBIDDEN"
+	CodeInternal        Code = "INTERNAL"
+)
+
+// FieldError describes a problem with a single input field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error with a code and optional field details
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+
+	// Current is the state of the entity a conflicting write clashed with,
+	// shaped like its GraphQL type
+	Current any
+
+	// Err is the underlying cause, if any
+	Err error
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithField attaches the path of an offending input field
+func (e *Error) WithField(field, message string) *Error {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+	return e
+}
+
+// WithCurrent attaches the current state of the entity a write clashed with
+func (e *Error) WithCurrent(current any) *Error {
+	e.Current = current
+	return e
+}
+
+// New creates an error with a formatted message
+func New(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap creates an error with a formatted message caused by err
+func Wrap(code Code, err error, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// NotFound reports a missing entity
+func NotFound(format string, args ...any) *Error {
+	return New(CodeNotFound, format, args...)
+}
+
+// InvalidArgument reports invalid input in field
+func InvalidArgument(field string, format string, args ...any) *Error {
+	message := fmt.Sprintf(format, args...)
+	return New(CodeInvalidArgument, "%s", message).WithField(field, message)
+}
+
+// Conflict reports a write that clashes with the current state
+func Conflict(format string, args ...any) *Error {
+	return New(CodeConflict, format, args...)
+}
+
+// Unauthenticated reports a request without valid credentials
+func Unauthenticated(format string, args ...any) *Error {
+	return New(CodeUnauthenticated, format, args...)
+}
+
+// Forbidden reports an operation the authenticated user isn't allowed to do
+func Forbidden(format string, args ...any) *Error {
+	return New(CodeForbidden, format, args...)
+}
+
+// As finds the first Error in the chain of err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	ok := errors.As(err, &appErr)
+	return appErr, ok
+}
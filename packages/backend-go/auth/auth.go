@@ -0,0 +1,241 @@
+// Package auth logs users in with their password and authenticates requests
+// by the access token of their session.
+//
+// Logging in opens a session with two tokens: a short-lived access token sent
+// with every request as "Authorization: Bearer <token>", and a random refresh
+// token that trades the session's tokens for new ones once the access token
+// expired. Access tokens are JWTs signed by Keys, which other services can
+// verify with the keys published at /.well-known/jwks.json. Only a hash of
+// the refresh token is stored, so a leaked sessions table can't be used to
+// log in. Logging out puts the session on a denylist, which rejects its
+// access tokens until they expire.
+//
+// Middleware puts the user of a valid access token into the request context,
+// where resolvers find it with FromContext, along with their role in the
+// workspace for RequireRole. Requests without a token go through
+// anonymously.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/deniedsession"
+	"backend-go/ent/membership"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AccessTokenTTL is how long an access token can be used
+	AccessTokenTTL = 15 * time.Minute
+
+	// RefreshTokenTTL is how long a session can be refreshed, after which its
+	// user has to log in again
+	RefreshTokenTTL = 30 * 24 * time.Hour
+
+	// SessionCheckInterval is how often long-lived connections check that
+	// their session is still valid, see Watch
+	SessionCheckInterval = time.Minute
+)
+
+var (
+	// ErrNotLoggedIn is returned by operations that need a logged in user
+	ErrNotLoggedIn = apperror.Unauthenticated("not logged in")
+
+	// ErrInvalidCredentials is returned by logins with a wrong email or
+	// password, without telling which one was wrong
+	ErrInvalidCredentials = apperror.Unauthenticated("invalid email or password")
+
+	// ErrInvalidToken is returned for access or refresh tokens that don't
+	// belong to a session, expired or were revoked
+	ErrInvalidToken = apperror.Unauthenticated("invalid or expired token")
+
+	// ErrPasswordsDisabled is returned by password operations when users log
+	// in with single sign-on instead
+	ErrPasswordsDisabled = apperror.Unauthenticated("passwords are disabled, log in with single sign-on")
+
+	// ErrNotMember is returned for users acting in a workspace they aren't a
+	// member of
+	ErrNotMember = apperror.Forbidden("not a member of the workspace")
+)
+
+type userKey struct{}
+
+type sessionKey struct{}
+
+// NewContext returns a copy of parent acting as u
+func NewContext(parent context.Context, u *ent.User) context.Context {
+	return context.WithValue(parent, userKey{}, u)
+}
+
+// FromContext returns the user ctx acts as
+func FromContext(ctx context.Context) (*ent.User, bool) {
+	u, ok := ctx.Value(userKey{}).(*ent.User)
+	return u, ok && u != nil
+}
+
+// SessionFromContext returns the ID of the session ctx was authenticated by
+func SessionFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(sessionKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// Authenticate returns a copy of ctx acting as the user accessToken was
+// issued to, with their role in the workspace. Tokens carry the workspace
+// they were issued in, and ctx acts in it unless it selects one already.
+// Users have to be a member of the workspace they act in, checked on every
+// request so removed members and changed roles take effect right away.
+func Authenticate(ctx context.Context, client *ent.Client, keys *Keys, accessToken string) (context.Context, error) {
+	ctx, _, err := authenticate(ctx, client, keys, accessToken)
+	return ctx, err
+}
+
+// Watch authenticates a long-lived connection like Authenticate. The
+// returned context is cancelled once the access token expires, or when a
+// check every interval finds that the token no longer authenticates as it
+// did: its session was logged out, its user deleted, or their membership
+// removed or changed. The checks stop once parent is done.
+func Watch(parent context.Context, client *ent.Client, keys *Keys, accessToken string, interval time.Duration) (context.Context, error) {
+	ctx, claims, err := authenticate(parent, client, keys, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Expiry == nil {
+		return nil, ErrInvalidToken
+	}
+	role, hasRole := RoleFromContext(ctx)
+
+	ctx, cancel := context.WithDeadline(ctx, claims.Expiry.Time())
+	go func() {
+		defer cancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, _, err := authenticate(parent, client, keys, accessToken)
+			switch {
+			case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrNotMember):
+				return
+			case err != nil:
+				// Keep the connection through transient failures
+				if ctx.Err() == nil {
+					log.Printf("failed to check session: %v", err)
+				}
+				continue
+			}
+			if currentRole, ok := RoleFromContext(current); ok != hasRole || currentRole != role {
+				return
+			}
+		}
+	}()
+	return ctx, nil
+}
+
+// authenticate implements Authenticate, and returns the claims of
+// accessToken too
+func authenticate(ctx context.Context, client *ent.Client, keys *Keys, accessToken string) (context.Context, *Claims, error) {
+	claims, err := keys.Verify(ctx, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	// Tokens of logged out sessions are denied until they expire
+	denied, err := client.DeniedSession.Query().
+		Where(deniedsession.ID(claims.SessionID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if denied {
+		return nil, nil, ErrInvalidToken
+	}
+
+	// Users are identities shared by every workspace. Deleted users are
+	// logged out.
+	u, err := client.User.Get(tenant.System(ctx), userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	if _, ok := tenant.FromContext(ctx); !ok && claims.WorkspaceID != nil {
+		ctx = tenant.NewContext(ctx, *claims.WorkspaceID)
+	}
+	if _, ok := tenant.FromContext(ctx); ok {
+		m, err := client.Membership.Query().Where(membership.UserID(u.ID)).Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, nil, ErrNotMember
+			}
+			return nil, nil, err
+		}
+		ctx = WithRole(ctx, m.Role)
+	}
+
+	return NewContext(context.WithValue(ctx, sessionKey{}, claims.SessionID), u), claims, nil
+}
+
+// Middleware authenticates requests carrying an access token in their
+// Authorization header. Invalid tokens are rejected with 401 so clients know
+// to refresh their session. It has to run inside tenant.Middleware.
+func Middleware(client *ent.Client, keys *Keys) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				http.Error(w, "unsupported authorization scheme", http.StatusUnauthorized)
+				return
+			}
+
+			ctx, err := Authenticate(r.Context(), client, keys, token)
+			switch {
+			case errors.Is(err, ErrNotMember):
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			case errors.Is(err, ErrInvalidToken):
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			case err != nil:
+				log.Printf("failed to authenticate request: %v", err)
+				http.Error(w, "failed to authenticate request", http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// hashToken returns the hash a token is stored as. Tokens are random, so a
+// fast hash is enough.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
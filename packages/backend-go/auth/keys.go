@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/ent/signingkey"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	// Issuer is the "iss" claim of the access tokens this server signs
+	Issuer = "backend-go"
+
+	// KeyRotationInterval is how long a signing key signs access tokens
+	// before a new one takes over. Retired keys keep verifying the tokens
+	// they signed until those expired.
+	KeyRotationInterval = 24 * time.Hour
+
+	// keyCheckInterval is how often Run looks for keys to rotate
+	keyCheckInterval = time.Hour
+
+	// keyReloadInterval is how often tokens of an unknown key load the keys
+	// again, so forged key IDs can't flood the database
+	keyReloadInterval = 10 * time.Second
+)
+
+// Claims are the claims of an access token. The subject is the ID of the
+// user, and the workspace the one the token was issued in, if any.
+type Claims struct {
+	jwt.Claims
+	SessionID   uuid.UUID  `json:"sid"`
+	WorkspaceID *uuid.UUID `json:"wid,omitempty"`
+}
+
+// Keys signs access tokens as EdDSA JWTs and verifies them. The keys are
+// stored in the database so every server instance signs with the same key
+// and accepts the tokens of the others. They are encrypted with a secret
+// every instance shares, so a leaked signing_keys table can't sign tokens.
+type Keys struct {
+	client *ent.Client
+	// aead encrypts the private keys as they are stored
+	aead cipher.AEAD
+
+	mu sync.RWMutex
+	// keys are the keys still in use, newest first
+	keys []signingKey
+	// loadedAt is when the keys were last loaded
+	loadedAt time.Time
+}
+
+// signingKey is a decrypted SigningKey
+type signingKey struct {
+	id        uuid.UUID
+	private   ed25519.PrivateKey
+	createdAt time.Time
+}
+
+// NewKeys creates the signing keys stored by client, encrypted with secret.
+// Tokens can be signed once the first key was generated by Rotate.
+func NewKeys(client *ent.Client, secret string) (*Keys, error) {
+	if secret == "" {
+		return nil, errors.New("the signing key secret is empty")
+	}
+	sum := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key cipher: %w", err)
+	}
+	return &Keys{client: client, aead: aead}, nil
+}
+
+// Run rotates the signing key right away and then whenever it is due until
+// ctx is done. Failures are logged and retried on the next check.
+func (k *Keys) Run(ctx context.Context) {
+	ticker := time.NewTicker(keyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := k.Rotate(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("keys: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Rotate generates a new signing key if the newest one signed for longer
+// than KeyRotationInterval, and removes keys that no unexpired token was
+// signed with
+func (k *Keys) Rotate(ctx context.Context) error {
+	now := time.Now()
+
+	_, err := k.client.SigningKey.Delete().
+		Where(signingkey.CreatedAtLT(now.Add(-KeyRotationInterval - AccessTokenTTL))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove retired signing keys: %w", err)
+	}
+
+	if err := k.load(ctx); err != nil {
+		return err
+	}
+	if current := k.current(); current != nil && now.Sub(current.createdAt) < KeyRotationInterval {
+		return nil
+	}
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	sealed, err := k.Seal(private)
+	if err != nil {
+		return err
+	}
+	err = k.client.SigningKey.Create().
+		SetPrivateKey(sealed).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store signing key: %w", err)
+	}
+	return k.load(ctx)
+}
+
+// Sign returns claims as a JWT signed with the current key. It never writes,
+// so it can sign within the transaction of a mutation.
+func (k *Keys) Sign(ctx context.Context, claims Claims) (string, error) {
+	// Another instance may have rotated the key since it was last loaded
+	key := k.current()
+	if key == nil || time.Since(key.createdAt) >= KeyRotationInterval {
+		if err := k.load(ctx); err != nil {
+			return "", err
+		}
+		if key = k.current(); key == nil {
+			return "", errors.New("no signing key, keys have to be rotated first")
+		}
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.EdDSA, Key: key.private},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader(jose.HeaderKey("kid"), key.id.String()),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	claims.Issuer = Issuer
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+// Verify returns the claims of token if one of the keys signed it and it
+// hasn't expired. Signatures alone don't tell whether its session was
+// logged out since, see Authenticate.
+func (k *Keys) Verify(ctx context.Context, token string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.EdDSA})
+	if err != nil || len(parsed.Headers) != 1 {
+		return nil, ErrInvalidToken
+	}
+	kid, err := uuid.Parse(parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// Another instance may have rotated the key since it was last loaded.
+	// Unknown keys load them again at most every keyReloadInterval.
+	key := k.find(kid)
+	if key == nil && k.reloadDue() {
+		if err := k.load(ctx); err != nil {
+			return nil, err
+		}
+		key = k.find(kid)
+	}
+	if key == nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := parsed.Claims(key.private.Public(), &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := claims.Validate(jwt.Expected{Issuer: Issuer}); err != nil {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// ServeHTTP publishes the public keys as JSON Web Key Set, so other services
+// can verify access tokens themselves
+func (k *Keys) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := k.load(r.Context()); err != nil {
+		log.Printf("failed to load signing keys: %v", err)
+		http.Error(w, "failed to load signing keys", http.StatusInternalServerError)
+		return
+	}
+
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	k.mu.RLock()
+	for _, key := range k.keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{
+			Key:       key.private.Public(),
+			KeyID:     key.id.String(),
+			Algorithm: string(jose.EdDSA),
+			Use:       "sig",
+		})
+	}
+	k.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	// Verifiers fetch the set again when they meet a token of a new key
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err := json.NewEncoder(w).Encode(set); err != nil {
+		log.Printf("failed to write signing keys: %v", err)
+	}
+}
+
+// Seal encrypts a private key as it is stored, the nonce followed by the
+// AES-GCM ciphertext
+func (k *Keys) Seal(private ed25519.PrivateKey) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return k.aead.Seal(nonce, nonce, private, nil), nil
+}
+
+// open decrypts a private key stored by Seal
+func (k *Keys) open(sealed []byte) (ed25519.PrivateKey, error) {
+	if len(sealed) < k.aead.NonceSize() {
+		return nil, errors.New("sealed key is too short")
+	}
+	nonce, ciphertext := sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():]
+	private, err := k.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(private) != ed25519.PrivateKeySize {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+	return private, nil
+}
+
+// load reads the keys still in use. Keys that don't decrypt, as they were
+// stored with another secret, are skipped, and Rotate replaces them.
+func (k *Keys) load(ctx context.Context) error {
+	entKeys, err := k.client.SigningKey.Query().
+		Where(signingkey.CreatedAtGTE(time.Now().Add(-KeyRotationInterval - AccessTokenTTL))).
+		Order(ent.Desc(signingkey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	keys := make([]signingKey, 0, len(entKeys))
+	for _, entKey := range entKeys {
+		private, err := k.open(entKey.PrivateKey)
+		if err != nil {
+			log.Printf("keys: skipped signing key %s: failed to decrypt it: %v", entKey.ID, err)
+			continue
+		}
+		keys = append(keys, signingKey{id: entKey.ID, private: private, createdAt: entKey.CreatedAt})
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.loadedAt = time.Now()
+	k.mu.Unlock()
+	return nil
+}
+
+// reloadDue reports whether the keys were loaded longer than
+// keyReloadInterval ago
+func (k *Keys) reloadDue() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return time.Since(k.loadedAt) >= keyReloadInterval
+}
+
+// current returns the newest key, if any
+func (k *Keys) current() *signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return nil
+	}
+	return &k.keys[0]
+}
+
+// find returns the key with id, if it's still in use
+func (k *Keys) find(id uuid.UUID) *signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for i := range k.keys {
+		if k.keys[i].id == id {
+			return &k.keys[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,453 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/ent/user"
+	"backend-go/tenant"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// flowCookie carries the state of a login between its routes
+	flowCookie = "oidc_flow"
+
+	// flowTTL is how long users have to log in with the provider
+	flowTTL = 10 * time.Minute
+)
+
+// errUnverifiedEmail is returned for ID tokens whose email the provider
+// didn't verify, which could otherwise claim the user of someone else
+var errUnverifiedEmail = errors.New("email of the ID token is missing or unverified")
+
+// OIDCConfig configures single sign-on with an OpenID Connect provider
+type OIDCConfig struct {
+	// IssuerURL is where the provider publishes its discovery document
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL of the callback route, as registered with the
+	// provider
+	RedirectURL string
+
+	// PostLoginURL receives the tokens of the session in its fragment after
+	// logging in. Without one the callback responds with them as JSON.
+	PostLoginURL string
+
+	// PostLogoutURL is where the provider sends users after logging out
+	PostLogoutURL string
+
+	// Roles grant workspace roles for claims of the ID token
+	Roles []RoleRule
+}
+
+// RoleRule grants Role in a workspace to users whose ID token has Value in
+// Claim. Claims may hold a single value or a list of them, like groups.
+type RoleRule struct {
+	Claim       string          `json:"claim"`
+	Value       string          `json:"value"`
+	WorkspaceID uuid.UUID       `json:"workspaceId"`
+	Role        membership.Role `json:"role"`
+}
+
+// ParseRoleRules parses role rules from a JSON list, like
+// [{"claim": "groups", "value": "admins", "workspaceId": "…", "role": "ADMIN"}]
+func ParseRoleRules(data string) ([]RoleRule, error) {
+	var rules []RoleRule
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		return nil, fmt.Errorf("invalid role rules: %w", err)
+	}
+	for i, rule := range rules {
+		if rule.Claim == "" || rule.WorkspaceID == uuid.Nil {
+			return nil, fmt.Errorf("role rule %d needs a claim and a workspace", i)
+		}
+		if err := membership.RoleValidator(rule.Role); err != nil {
+			return nil, fmt.Errorf("role rule %d: %w", i, err)
+		}
+	}
+	return rules, nil
+}
+
+// matches reports whether claims satisfy the rule
+func (rule RoleRule) matches(claims map[string]any) bool {
+	switch value := claims[rule.Claim].(type) {
+	case string:
+		return value == rule.Value
+	case []any:
+		for _, v := range value {
+			if v == rule.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OIDC logs users in with an OpenID Connect provider, using the
+// authorization code flow with PKCE. Users are matched to the User with the
+// email of their ID token, and created on their first login.
+type OIDC struct {
+	client   *ent.Client
+	keys     *Keys
+	config   OIDCConfig
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+
+	// endSessionURL logs users out of the provider, if it supports that
+	endSessionURL string
+}
+
+// NewOIDC discovers the provider of config
+func NewOIDC(ctx context.Context, client *ent.Client, keys *Keys, config OIDCConfig) (*OIDC, error) {
+	provider, err := oidc.NewProvider(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+	var metadata struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to read OIDC provider metadata: %w", err)
+	}
+
+	return &OIDC{
+		client: client,
+		keys:   keys,
+		config: config,
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  config.RedirectURL,
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+		verifier:      provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		endSessionURL: metadata.EndSessionEndpoint,
+	}, nil
+}
+
+// flow is the state of a login kept in a cookie until the provider calls
+// back
+type flow struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	// WorkspaceID is the workspace the session is opened in, if any
+	WorkspaceID *uuid.UUID `json:"workspaceId,omitempty"`
+}
+
+// Login sends users to the provider to log in. The session is opened in the
+// workspace the request selects.
+func (o *OIDC) Login(w http.ResponseWriter, r *http.Request) {
+	f, value, err := newFlow(r.Context())
+	if err != nil {
+		log.Printf("failed to start login: %v", err)
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, o.cookie(value, flowTTL))
+
+	http.Redirect(w, r, o.oauth2.AuthCodeURL(f.State,
+		oauth2.S256ChallengeOption(f.Verifier),
+		oidc.Nonce(f.Nonce),
+	), http.StatusFound)
+}
+
+// Callback completes a login once the provider sends users back, opening a
+// session for them
+func (o *OIDC) Callback(w http.ResponseWriter, r *http.Request) {
+	// Flows can only be completed once
+	http.SetCookie(w, o.cookie("", -1))
+
+	f, ok := readFlow(r)
+	query := r.URL.Query()
+	if !ok || query.Get("state") != f.State {
+		http.Error(w, "invalid or expired login, try again", http.StatusBadRequest)
+		return
+	}
+	if reason := query.Get("error"); reason != "" {
+		http.Error(w, "login failed: "+reason, http.StatusUnauthorized)
+		return
+	}
+
+	ctx := r.Context()
+	token, err := o.oauth2.Exchange(ctx, query.Get("code"), oauth2.VerifierOption(f.Verifier))
+	if err != nil {
+		log.Printf("failed to exchange authorization code: %v", err)
+		http.Error(w, "login failed", http.StatusUnauthorized)
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		http.Error(w, "login failed: no ID token", http.StatusUnauthorized)
+		return
+	}
+	idToken, err := o.verifier.Verify(ctx, rawIDToken)
+	if err != nil || idToken.Nonce != f.Nonce {
+		log.Printf("failed to verify ID token: %v", err)
+		http.Error(w, "login failed", http.StatusUnauthorized)
+		return
+	}
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		log.Printf("failed to read ID token claims: %v", err)
+		http.Error(w, "login failed", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := o.openSession(ctx, claims, f.WorkspaceID)
+	switch {
+	case errors.Is(err, errUnverifiedEmail):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		log.Printf("failed to log in with OIDC: %v", err)
+		http.Error(w, "login failed", http.StatusInternalServerError)
+		return
+	}
+
+	if o.config.PostLoginURL != "" {
+		fragment := url.Values{
+			"access_token":  {tokens.AccessToken},
+			"expires_at":    {tokens.AccessExpiresAt.Format(time.RFC3339)},
+			"refresh_token": {tokens.RefreshToken},
+		}
+		http.Redirect(w, r, o.config.PostLoginURL+"#"+fragment.Encode(), http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	err = json.NewEncoder(w).Encode(map[string]any{
+		"accessToken":  tokens.AccessToken,
+		"expiresAt":    tokens.AccessExpiresAt,
+		"refreshToken": tokens.RefreshToken,
+	})
+	if err != nil {
+		log.Printf("failed to write tokens: %v", err)
+	}
+}
+
+// Logout ends the session of the request, if any, and sends users to the
+// provider to log out there too
+func (o *OIDC) Logout(w http.ResponseWriter, r *http.Request) {
+	if sessionID, ok := SessionFromContext(r.Context()); ok {
+		if err := RevokeSession(r.Context(), o.client, sessionID); err != nil {
+			log.Printf("failed to log out: %v", err)
+			http.Error(w, "failed to log out", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if o.endSessionURL == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	params := url.Values{"client_id": {o.config.ClientID}}
+	if o.config.PostLogoutURL != "" {
+		params.Set("post_logout_redirect_uri", o.config.PostLogoutURL)
+	}
+	separator := "?"
+	if strings.Contains(o.endSessionURL, "?") {
+		separator = "&"
+	}
+	http.Redirect(w, r, o.endSessionURL+separator+params.Encode(), http.StatusFound)
+}
+
+// openSession logs the user of claims in, creating them on their first login
+// and granting the roles their claims map to
+func (o *OIDC) openSession(ctx context.Context, claims map[string]any, workspaceID *uuid.UUID) (*Tokens, error) {
+	email, _ := claims["email"].(string)
+	// Providers may let users enter any address, so only verified ones
+	// identify users
+	if verified, _ := claims["email_verified"].(bool); email == "" || !verified {
+		return nil, errUnverifiedEmail
+	}
+	name, _ := claims["name"].(string)
+	if name == "" {
+		name = email
+	}
+
+	tx, err := o.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	// Users are identities shared by every workspace
+	u, err := client.User.Query().Where(user.Email(NormalizeEmail(email))).Only(tenant.System(ctx))
+	if ent.IsNotFound(err) {
+		u, err = client.User.Create().
+			SetEmail(email).
+			SetName(name).
+			Save(tenant.Detach(ctx))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	if err := o.grantRoles(ctx, client, u, claims); err != nil {
+		return nil, err
+	}
+
+	if workspaceID != nil {
+		ctx = tenant.NewContext(ctx, *workspaceID)
+	}
+	tokens, err := OpenSession(ctx, client, o.keys, u)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit login: %w", err)
+	}
+	return tokens, nil
+}
+
+// grantRoles syncs the memberships of u in the workspaces of the role rules
+// with claims: u gets the most privileged role the matching rules grant, and
+// leaves the workspaces none of them matches anymore. Workspaces no rule
+// names are left alone, and so are the last owners of a workspace, which
+// would otherwise be left without one.
+func (o *OIDC) grantRoles(ctx context.Context, client *ent.Client, u *ent.User, claims map[string]any) error {
+	roles := map[uuid.UUID]membership.Role{}
+	managed := map[uuid.UUID]bool{}
+	for _, rule := range o.config.Roles {
+		managed[rule.WorkspaceID] = true
+		role, ok := roles[rule.WorkspaceID]
+		if rule.matches(claims) && (!ok || roleRank[rule.Role] > roleRank[role]) {
+			roles[rule.WorkspaceID] = rule.Role
+		}
+	}
+
+	// Memberships of every workspace are managed here
+	ctx = tenant.System(ctx)
+	memberships, err := client.Membership.Query().
+		Where(membership.UserID(u.ID)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load memberships: %w", err)
+	}
+	current := make(map[uuid.UUID]*ent.Membership, len(memberships))
+	for _, m := range memberships {
+		current[m.WorkspaceID] = m
+	}
+
+	for workspaceID := range managed {
+		role, granted := roles[workspaceID]
+		m, member := current[workspaceID]
+		if member && granted && m.Role == role {
+			continue
+		}
+		if member && m.Role == membership.RoleOWNER {
+			last, err := isLastOwner(ctx, client, m)
+			if err != nil {
+				return err
+			}
+			if last {
+				log.Printf("oidc: keeping %s the owner of workspace %s, it has no other", u.Email, workspaceID)
+				continue
+			}
+		}
+
+		switch {
+		case member && granted:
+			err = client.Membership.UpdateOne(m).SetRole(role).Exec(ctx)
+		case granted:
+			err = client.Membership.Create().
+				SetWorkspaceID(workspaceID).
+				SetUserID(u.ID).
+				SetRole(role).
+				Exec(ctx)
+		default:
+			err = client.Membership.DeleteOne(m).Exec(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to sync membership: %w", err)
+		}
+	}
+	return nil
+}
+
+// isLastOwner reports whether the owner of m is the only one of its workspace
+func isLastOwner(ctx context.Context, client *ent.Client, m *ent.Membership) (bool, error) {
+	others, err := client.Membership.Query().
+		Where(
+			membership.WorkspaceID(m.WorkspaceID),
+			membership.RoleEQ(membership.RoleOWNER),
+			membership.UserIDNEQ(m.UserID),
+		).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up owners: %w", err)
+	}
+	return !others, nil
+}
+
+// cookie returns the flow cookie with value, expiring after maxAge
+func (o *OIDC) cookie(value string, maxAge time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     flowCookie,
+		Value:    value,
+		Path:     "/auth",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(o.config.RedirectURL, "https://"),
+		// The provider sends users back with a top-level navigation
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// newFlow starts a login in the workspace ctx acts in, returning it along
+// with its cookie value
+func newFlow(ctx context.Context) (flow, string, error) {
+	state, err := randomToken()
+	if err != nil {
+		return flow{}, "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return flow{}, "", err
+	}
+	f := flow{State: state, Nonce: nonce, Verifier: oauth2.GenerateVerifier()}
+	if workspaceID, ok := tenant.FromContext(ctx); ok {
+		f.WorkspaceID = &workspaceID
+	}
+
+	value, err := json.Marshal(f)
+	if err != nil {
+		return flow{}, "", err
+	}
+	return f, base64.RawURLEncoding.EncodeToString(value), nil
+}
+
+// readFlow returns the flow of the login r completes
+func readFlow(r *http.Request) (flow, bool) {
+	var f flow
+	cookie, err := r.Cookie(flowCookie)
+	if err != nil {
+		return f, false
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return f, false
+	}
+	if err := json.Unmarshal(value, &f); err != nil || f.State == "" {
+		return f, false
+	}
+	return f, true
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+
+	"backend-go/apperror"
+	"backend-go/ent/membership"
+	"backend-go/tenant"
+)
+
+// roleRank orders roles from the least to the most privileged
+var roleRank = map[membership.Role]int{
+	membership.RoleVIEWER: 0,
+	membership.RoleMEMBER: 1,
+	membership.RoleADMIN:  2,
+	membership.RoleOWNER:  3,
+}
+
+type roleKey struct{}
+
+// WithRole returns a copy of parent acting with role in its workspace
+func WithRole(parent context.Context, role membership.Role) context.Context {
+	return context.WithValue(parent, roleKey{}, role)
+}
+
+// RoleFromContext returns the role ctx acts with in its workspace
+func RoleFromContext(ctx context.Context) (membership.Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(membership.Role)
+	return role, ok
+}
+
+// HasRole reports whether ctx acts with role or a more privileged one
+func HasRole(ctx context.Context, role membership.Role) bool {
+	current, ok := RoleFromContext(ctx)
+	return ok && roleRank[current] >= roleRank[role]
+}
+
+// RequireRole fails unless ctx acts with role or a more privileged one.
+// Anonymous requests and requests without a workspace fail with
+// UNAUTHENTICATED, members with a lesser role with FORBIDDEN.
+func RequireRole(ctx context.Context, role membership.Role) error {
+	if HasRole(ctx, role) {
+		return nil
+	}
+	if _, ok := RoleFromContext(ctx); ok {
+		return apperror.Forbidden("needs the %s role", role)
+	}
+	if _, ok := FromContext(ctx); !ok {
+		return ErrNotLoggedIn
+	}
+	if _, ok := tenant.FromContext(ctx); !ok {
+		return tenant.ErrNoWorkspace
+	}
+	return ErrNotMember
+}
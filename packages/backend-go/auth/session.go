@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/ent/session"
+	"backend-go/ent/user"
+	"backend-go/tenant"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+)
+
+// Tokens are the credentials of a session handed out to its client
+type Tokens struct {
+	AccessToken     string
+	AccessExpiresAt time.Time
+	RefreshToken    string
+	User            *ent.User
+}
+
+// Login opens a session for the user with email if password is theirs
+func Login(ctx context.Context, client *ent.Client, keys *Keys, email, password string) (*Tokens, error) {
+	// Users are identities shared by every workspace
+	u, err := client.User.Query().Where(user.Email(NormalizeEmail(email))).Only(tenant.System(ctx))
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	hash := dummyHash
+	if u != nil && u.PasswordHash != nil {
+		hash = *u.PasswordHash
+	}
+	ok, err := VerifyPassword(hash, password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || u == nil || u.PasswordHash == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return OpenSession(ctx, client, keys, u)
+}
+
+// NormalizeEmail returns email the way users store it. Emails are saved in
+// lower case, so the unique index keeps one user per address however it is
+// typed.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(email)
+}
+
+// OpenSession logs u in with a new session
+func OpenSession(ctx context.Context, client *ent.Client, keys *Keys, u *ent.User) (*Tokens, error) {
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := client.Session.Create().
+		SetUserID(u.ID).
+		SetRefreshTokenHash(hashToken(refreshToken)).
+		SetRefreshExpiresAt(time.Now().Add(RefreshTokenTTL)).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	return issueTokens(ctx, client, keys, s, u, refreshToken)
+}
+
+// RefreshSession replaces the refresh token of the session refreshToken
+// belongs to and issues a new access token. The old refresh token stops
+// working, so a stolen one can only be used once before the session's client
+// notices. Of concurrent refreshes with the same token only one can replace
+// it; the others mean it leaked, and log the session out. That has to stick
+// although they fail, so client must not be a transaction rolled back on
+// errors.
+func RefreshSession(ctx context.Context, client *ent.Client, keys *Keys, refreshToken string) (*Tokens, error) {
+	s, err := client.Session.Query().
+		Where(session.RefreshTokenHash(hashToken(refreshToken))).
+		WithUser().
+		Only(tenant.System(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to look up session: %w", err)
+	}
+	if s.RevokedAt != nil || time.Now().After(s.RefreshExpiresAt) || s.Edges.User == nil {
+		return nil, ErrInvalidToken
+	}
+
+	newRefreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	n, err := client.Session.Update().
+		Where(session.ID(s.ID), session.RefreshTokenHash(hashToken(refreshToken))).
+		SetRefreshTokenHash(hashToken(newRefreshToken)).
+		SetRefreshExpiresAt(time.Now().Add(RefreshTokenTTL)).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh session: %w", err)
+	}
+	if n == 0 {
+		// Another refresh replaced the token since it was looked up
+		if err := RevokeSession(ctx, client, s.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+	return issueTokens(ctx, client, keys, s, s.Edges.User, newRefreshToken)
+}
+
+// RevokeSession logs the session with id out
+func RevokeSession(ctx context.Context, client *ent.Client, id uuid.UUID) error {
+	n, err := client.Session.Update().
+		Where(session.ID(id), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if n == 0 {
+		return nil
+	}
+	return deny(ctx, client, id)
+}
+
+// RevokeOtherSessions logs every session of the user with userID out but the
+// one with keep
+func RevokeOtherSessions(ctx context.Context, client *ent.Client, userID, keep uuid.UUID) error {
+	ids, err := client.Session.Query().
+		Where(session.UserID(userID), session.IDNEQ(keep), session.RevokedAtIsNil()).
+		IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err = client.Session.Update().
+		Where(session.IDIn(ids...)).
+		SetRevokedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return deny(ctx, client, ids...)
+}
+
+// deny puts the sessions with ids on the denylist until every access token
+// issued for them expired
+func deny(ctx context.Context, client *ent.Client, ids ...uuid.UUID) error {
+	expiresAt := time.Now().Add(AccessTokenTTL + jwt.DefaultLeeway)
+	builders := make([]*ent.DeniedSessionCreate, len(ids))
+	for i, id := range ids {
+		builders[i] = client.DeniedSession.Create().
+			SetID(id).
+			SetExpiresAt(expiresAt)
+	}
+	if err := client.DeniedSession.CreateBulk(builders...).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to deny sessions: %w", err)
+	}
+	return nil
+}
+
+// issueTokens signs an access token for the session s of u. Tokens issued in
+// a workspace u is a member of carry it, so requests with them act in it
+// without selecting one.
+func issueTokens(ctx context.Context, client *ent.Client, keys *Keys, s *ent.Session, u *ent.User, refreshToken string) (*Tokens, error) {
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL).Truncate(time.Second)
+	claims := Claims{
+		Claims: jwt.Claims{
+			ID:       uuid.NewString(),
+			Subject:  u.ID.String(),
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(expiresAt),
+		},
+		SessionID: s.ID,
+	}
+
+	if workspaceID, ok := tenant.FromContext(ctx); ok {
+		member, err := client.Membership.Query().Where(membership.UserID(u.ID)).Exist(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up membership: %w", err)
+		}
+		if member {
+			claims.WorkspaceID = &workspaceID
+		}
+	}
+
+	accessToken, err := keys.Sign(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		AccessToken:     accessToken,
+		AccessExpiresAt: expiresAt,
+		RefreshToken:    refreshToken,
+		User:            u,
+	}, nil
+}
+
+// randomToken returns 256 random bits, URL-safe encoded
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
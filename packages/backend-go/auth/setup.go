@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/user"
+	"backend-go/tenant"
+)
+
+// PasswordSetupTTL is how long a password setup token can be used
+const PasswordSetupTTL = 7 * 24 * time.Hour
+
+// ErrHasPassword is returned when setting up the password of a user who
+// already has one
+var ErrHasPassword = apperror.Conflict("user already has a password")
+
+// IssuePasswordSetup hands out a one-time token u sets up their first
+// password with, see SetUpPassword. Issuing another one replaces it. Only a
+// hash of the token is stored.
+func IssuePasswordSetup(ctx context.Context, client *ent.Client, u *ent.User) (string, time.Time, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(PasswordSetupTTL).Truncate(time.Second)
+	n, err := client.User.Update().
+		Where(user.ID(u.ID), user.PasswordHashIsNil()).
+		SetPasswordSetupHash(hashToken(token)).
+		SetPasswordSetupExpiresAt(expiresAt).
+		Save(tenant.System(ctx))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to issue password setup: %w", err)
+	}
+	if n == 0 {
+		return "", time.Time{}, ErrHasPassword
+	}
+	return token, expiresAt, nil
+}
+
+// SetUpPassword gives the user token was issued to the password hash and
+// logs them in. The token only works once, and not at all once the user has
+// a password.
+func SetUpPassword(ctx context.Context, client *ent.Client, keys *Keys, token, hash string) (*Tokens, error) {
+	// Users are identities shared by every workspace
+	u, err := client.User.Query().
+		Where(user.PasswordSetupHash(hashToken(token))).
+		Only(tenant.System(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if u.PasswordHash != nil || u.PasswordSetupExpiresAt == nil || time.Now().After(*u.PasswordSetupExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+
+	// Of concurrent setups with the same token only one sets the password
+	u, err = client.User.UpdateOne(u).
+		Where(user.PasswordSetupHash(hashToken(token)), user.PasswordHashIsNil()).
+		SetPasswordHash(hash).
+		ClearPasswordSetupHash().
+		ClearPasswordSetupExpiresAt().
+		Save(tenant.System(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to set up password: %w", err)
+	}
+	return OpenSession(ctx, client, keys, u)
+}
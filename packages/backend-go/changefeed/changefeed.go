@@ -0,0 +1,304 @@
+// Package changefeed turns row changes in Postgres into pubsub events.
+//
+// Both backends write to the same todos and users tables, so events published
+// by a single process would miss writes made by the TypeScript backend or
+// another replica. Instead, a trigger (see trigger.sql) appends every change
+// to the change_events table and notifies the change_events channel. Feed
+// listens on that channel, reads the new rows and fans them out to its
+// subscribers.
+//
+// Notifications only wake the feed up; the table is the source of truth. When
+// the connection drops, the listener reconnects on its own and the feed
+// catches up on every row written in the meantime.
+//
+// Every feed records how far it read in the change_feed_consumers table, so a
+// restarted feed resumes where it stopped. Change events are only pruned once
+// every feed read past them.
+package changefeed
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/pubsub"
+
+	"github.com/lib/pq"
+)
+
+// TriggerSQL creates the change_events table and the triggers feeding it
+//
+//go:embed trigger.sql
+var TriggerSQL string
+
+// channel is the notification channel the trigger announces changes on
+const channel = "change_events"
+
+const (
+	minReconnectInterval = 100 * time.Millisecond
+	maxReconnectInterval = 10 * time.Second
+
+	// pollInterval bounds how long an event may wait when a notification is
+	// lost or its rows aren't readable yet
+	pollInterval = 5 * time.Second
+
+	// consumerRetention is how long a feed that stopped reading is waited
+	// for. Its change events are pruned afterwards, and it starts over from
+	// the transactions still running when it comes back.
+	consumerRetention = 24 * time.Hour
+)
+
+// Install creates or updates the change feed trigger. It is safe to run
+// concurrently from several processes.
+func Install(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, TriggerSQL); err != nil {
+		return fmt.Errorf("failed to install change feed trigger: %w", err)
+	}
+	return nil
+}
+
+// Feed is a pubsub.Broker delivering the changes recorded by the trigger
+type Feed struct {
+	db       *sql.DB
+	listener *pq.Listener
+	local    *pubsub.MemoryBroker
+
+	// id names the feed in change_feed_consumers
+	id string
+
+	// watermark is the oldest transaction whose changes haven't been read
+	// yet. Only Run touches it.
+	watermark string
+}
+
+// New creates a feed reading change events through db and listening for
+// notifications on a separate connection to dsn. id names the feed across
+// restarts and has to differ between the processes sharing the database.
+// Call Run to start it.
+func New(db *sql.DB, dsn, id string) *Feed {
+	listener := pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("change feed connection: %v", err)
+		}
+	})
+
+	return &Feed{
+		db:       db,
+		listener: listener,
+		local:    pubsub.NewMemoryBroker(),
+		id:       id,
+	}
+}
+
+// Subscribe returns the changes read by the feed until ctx is done
+func (f *Feed) Subscribe(ctx context.Context) (<-chan pubsub.Event, error) {
+	return f.local.Subscribe(ctx)
+}
+
+// Run delivers changes to subscribers until ctx is done. Failed reads are
+// logged and retried, so Run only returns early if it can't start listening.
+func (f *Feed) Run(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		f.listener.Close()
+	}()
+
+	if err := f.listener.Listen(channel); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to listen for changes: %w", err)
+	}
+
+	// Pick up where the feed stopped reading
+	for f.watermark == "" {
+		if err := f.resume(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("change feed: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-f.listener.Notify:
+			// A nil notification means the connection was re-established and
+			// notifications may have been missed; catching up covers both
+		case <-ticker.C:
+			if err := f.prune(ctx); err != nil {
+				log.Printf("change feed: %v", err)
+			}
+		}
+
+		if err := f.catchUp(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("change feed: %v", err)
+		}
+	}
+}
+
+// catchUp delivers the changes of every transaction that finished since the
+// last call
+func (f *Feed) catchUp(ctx context.Context) error {
+	// Repeatable read makes the horizon and the rows come from one snapshot
+	tx, err := f.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to read changes: %w", err)
+	}
+	defer tx.Rollback()
+
+	var horizon string
+	if err := tx.QueryRowContext(ctx, `SELECT pg_snapshot_xmin(pg_current_snapshot())::text`).Scan(&horizon); err != nil {
+		return fmt.Errorf("failed to read transaction horizon: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT table_name, op, data FROM change_events
+		WHERE tx_id >= $1::xid8 AND tx_id < $2::xid8
+		ORDER BY tx_id, id`,
+		f.watermark, horizon,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to read changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, op string
+		var data []byte
+		if err := rows.Scan(&table, &op, &data); err != nil {
+			return fmt.Errorf("failed to read changes: %w", err)
+		}
+
+		event, err := decodeEvent(table, op, data)
+		if err != nil {
+			log.Printf("change feed: skipping change: %v", err)
+			continue
+		}
+		if err := f.local.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read changes: %w", err)
+	}
+
+	if horizon == f.watermark {
+		return nil
+	}
+	f.watermark = horizon
+	return f.save(ctx)
+}
+
+// resume starts from where the feed stopped reading before, or from the
+// changes of transactions still running if it never read any
+func (f *Feed) resume(ctx context.Context) error {
+	var watermark string
+	err := f.db.QueryRowContext(ctx,
+		`SELECT watermark::text FROM change_feed_consumers WHERE id = $1`,
+		f.id,
+	).Scan(&watermark)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = f.db.QueryRowContext(ctx, `SELECT pg_snapshot_xmin(pg_current_snapshot())::text`).Scan(&watermark)
+		if err != nil {
+			return fmt.Errorf("failed to read transaction horizon: %w", err)
+		}
+		f.watermark = watermark
+		return f.save(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read watermark: %w", err)
+	}
+	f.watermark = watermark
+	return nil
+}
+
+// save records how far the feed read. It also tells prune the feed is still
+// around, so it runs on every poll even without changes.
+func (f *Feed) save(ctx context.Context) error {
+	_, err := f.db.ExecContext(ctx, `
+		INSERT INTO change_feed_consumers (id, watermark) VALUES ($1, $2::xid8)
+		ON CONFLICT (id) DO UPDATE SET watermark = excluded.watermark, updated_at = now()`,
+		f.id, f.watermark,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save watermark: %w", err)
+	}
+	return nil
+}
+
+// prune forgets feeds that stopped reading longer than consumerRetention ago
+// and removes the change events every remaining feed read
+func (f *Feed) prune(ctx context.Context) error {
+	if err := f.save(ctx); err != nil {
+		return err
+	}
+
+	_, err := f.db.ExecContext(ctx,
+		`DELETE FROM change_feed_consumers WHERE updated_at < now() - make_interval(secs => $1)`,
+		consumerRetention.Seconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune change feed consumers: %w", err)
+	}
+
+	_, err = f.db.ExecContext(ctx,
+		`DELETE FROM change_events WHERE tx_id < (SELECT min(watermark) FROM change_feed_consumers)`,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune change events: %w", err)
+	}
+	return nil
+}
+
+// decodeEvent converts a change_events row into an event
+func decodeEvent(table, op string, data []byte) (pubsub.Event, error) {
+	var event pubsub.Event
+	switch op {
+	case "INSERT":
+		event.Op = pubsub.OpCreate
+	case "UPDATE":
+		event.Op = pubsub.OpUpdate
+	case "DELETE":
+		event.Op = pubsub.OpDelete
+	case "REMIND":
+		event.Op = pubsub.OpRemind
+	default:
+		return event, fmt.Errorf("unknown operation %q", op)
+	}
+
+	// Rows are encoded with their column names, which ent uses as JSON keys
+	var err error
+	switch table {
+	case "todos":
+		event.Todo = &ent.Todo{}
+		err = json.Unmarshal(data, event.Todo)
+	case "users":
+		event.User = &ent.User{}
+		err = json.Unmarshal(data, event.User)
+	default:
+		return event, fmt.Errorf("unknown table %q", table)
+	}
+	if err != nil {
+		return event, fmt.Errorf("failed to decode %s row: %w", table, err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,204 @@
+package changefeed
+
+import (
+	"context"
+	"log"
+
+	"backend-go/ent"
+	"backend-go/ent/hook"
+	"backend-go/ent/schema"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"backend-go/pubsub"
+
+	"github.com/google/uuid"
+)
+
+// Fake is an in-memory stand-in for Feed on databases without LISTEN/NOTIFY,
+// such as the SQLite test database. It plays the part of the trigger with ent
+// hooks, so only writes made through the hooked client are seen, and foreign
+// key actions like clearing todos.user_id go unnoticed.
+type Fake struct {
+	local *pubsub.MemoryBroker
+}
+
+// NewFake creates a fake feed for the writes made through client
+func NewFake(client *ent.Client) *Fake {
+	f := &Fake{local: pubsub.NewMemoryBroker()}
+	client.Todo.Use(f.todoHook)
+	client.User.Use(f.userHook)
+	return f
+}
+
+// Subscribe returns the changes seen by the fake until ctx is done
+func (f *Fake) Subscribe(ctx context.Context) (<-chan pubsub.Event, error) {
+	return f.local.Subscribe(ctx)
+}
+
+// todoHook records the todos changed by a mutation
+func (f *Fake) todoHook(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+		if m.Op().Is(ent.OpCreate) {
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return v, err
+			}
+			f.publish(ctx, m.Tx, []pubsub.Event{{Op: pubsub.OpCreate, Todo: v.(*ent.Todo)}})
+			return v, nil
+		}
+
+		// Rows are compared before and after the mutation, including the
+		// soft-deleted ones. The op is kept as soft deletes turn m into an
+		// update.
+		mutationOp := m.Op()
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changed := func() (map[uuid.UUID]*ent.Todo, error) {
+			rows, err := m.Client().Todo.Query().Where(todo.IDIn(ids...)).All(schema.SkipSoftDelete(ctx))
+			byID := make(map[uuid.UUID]*ent.Todo, len(rows))
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+			return byID, err
+		}
+
+		before, err := changed()
+		if err != nil {
+			return nil, err
+		}
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return v, err
+		}
+		after, err := changed()
+		if err != nil {
+			return nil, err
+		}
+
+		var events []pubsub.Event
+		for _, id := range ids {
+			old, row := before[id], after[id]
+			op, ok := classify(mutationOp, old != nil && old.DeletedAt != nil, row == nil, row != nil && row.DeletedAt != nil)
+			if !ok {
+				continue
+			}
+			if row == nil {
+				row = old
+			}
+			events = append(events, pubsub.Event{Op: op, Todo: row})
+			if op == pubsub.OpUpdate && old != nil && old.RemindedAt == nil && row.RemindedAt != nil {
+				events = append(events, pubsub.Event{Op: pubsub.OpRemind, Todo: row})
+			}
+		}
+		f.publish(ctx, m.Tx, events)
+		return v, nil
+	})
+}
+
+// userHook records the users changed by a mutation
+func (f *Fake) userHook(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+		if m.Op().Is(ent.OpCreate) {
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return v, err
+			}
+			f.publish(ctx, m.Tx, []pubsub.Event{{Op: pubsub.OpCreate, User: v.(*ent.User)}})
+			return v, nil
+		}
+
+		// Rows are compared before and after the mutation, including the
+		// soft-deleted ones. The op is kept as soft deletes turn m into an
+		// update.
+		mutationOp := m.Op()
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changed := func() (map[uuid.UUID]*ent.User, error) {
+			rows, err := m.Client().User.Query().Where(user.IDIn(ids...)).All(schema.SkipSoftDelete(ctx))
+			byID := make(map[uuid.UUID]*ent.User, len(rows))
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+			return byID, err
+		}
+
+		before, err := changed()
+		if err != nil {
+			return nil, err
+		}
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return v, err
+		}
+		after, err := changed()
+		if err != nil {
+			return nil, err
+		}
+
+		var events []pubsub.Event
+		for _, id := range ids {
+			old, row := before[id], after[id]
+			op, ok := classify(mutationOp, old != nil && old.DeletedAt != nil, row == nil, row != nil && row.DeletedAt != nil)
+			if !ok {
+				continue
+			}
+			if row == nil {
+				row = old
+			}
+			events = append(events, pubsub.Event{Op: op, User: row})
+		}
+		f.publish(ctx, m.Tx, events)
+		return v, nil
+	})
+}
+
+// classify returns the event a row changed by an update or delete is
+// announced as, following the trigger: soft deletes are deletes, restores are
+// creates, and rows that stay deleted are left out
+func classify(op ent.Op, wasDeleted, gone, isDeleted bool) (pubsub.Op, bool) {
+	switch {
+	case op.Is(ent.OpDelete | ent.OpDeleteOne):
+		// Soft deletes run again as updates, so only hard-deleted rows count
+		// here. Purged rows were announced when they were soft-deleted.
+		return pubsub.OpDelete, gone && !wasDeleted
+	case gone:
+		return "", false
+	case !wasDeleted && isDeleted:
+		return pubsub.OpDelete, true
+	case wasDeleted && !isDeleted:
+		return pubsub.OpCreate, true
+	default:
+		return pubsub.OpUpdate, !isDeleted
+	}
+}
+
+// publish delivers events once the transaction of the mutation commits, or
+// right away for mutations outside a transaction, like NOTIFY would
+func (f *Fake) publish(ctx context.Context, mutationTx func() (*ent.Tx, error), events []pubsub.Event) {
+	deliver := func() {
+		for _, event := range events {
+			if err := f.local.Publish(ctx, event); err != nil {
+				log.Printf("fake change feed: %v", err)
+			}
+		}
+	}
+
+	tx, err := mutationTx()
+	if err != nil {
+		deliver()
+		return
+	}
+	tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			if err := next.Commit(ctx, tx); err != nil {
+				return err
+			}
+			deliver()
+			return nil
+		})
+	})
+}
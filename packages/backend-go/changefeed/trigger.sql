@@ -0,0 +1,94 @@
+-- Change feed for GraphQL subscriptions, installed by changefeed.Install.
+--
+-- Every insert, update and delete on todos and users is appended to
+-- change_events and announced on the change_events channel. Listeners read
+-- the rows themselves rather than relying on NOTIFY payloads, so a listener
+-- that lost its connection can catch up on what it missed.
+--
+-- Soft deletes and restores are updates of deleted_at, but are recorded as
+-- DELETE and INSERT, since rows in the trash are hidden from clients. For the
+-- same reason, changes to deleted rows and purging them aren't recorded.
+--
+-- tx_id records the writing transaction. Readers only consume rows of
+-- transactions older than the oldest one still running, which guarantees no
+-- earlier row can become visible after they moved past it.
+--
+-- Stamping reminded_at on a todo is recorded as a REMIND besides the UPDATE,
+-- announcing that the todo comes due, see package remind.
+--
+-- Password hashes are left out of the recorded rows.
+
+SELECT pg_advisory_xact_lock(hashtext('change_events'));
+
+CREATE TABLE IF NOT EXISTS change_events (
+    id BIGSERIAL PRIMARY KEY,
+    tx_id XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    table_name TEXT NOT NULL,
+    op TEXT NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS change_events_tx_id_idx ON change_events (tx_id, id);
+-- Events used to be pruned by age
+DROP INDEX IF EXISTS change_events_created_at_idx;
+
+-- How far every feed read, so restarted feeds resume where they stopped.
+-- change_events are pruned below the lowest watermark.
+CREATE TABLE IF NOT EXISTS change_feed_consumers (
+    id TEXT PRIMARY KEY,
+    watermark XID8 NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION record_change() RETURNS trigger AS $$
+DECLARE
+    changed RECORD;
+    change_op TEXT := TG_OP;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.deleted_at IS NOT NULL THEN
+            RETURN NULL;
+        END IF;
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            change_op := 'DELETE';
+        ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+            change_op := 'INSERT';
+        ELSIF NEW.deleted_at IS NOT NULL THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    INSERT INTO change_events (table_name, op, data)
+    VALUES (TG_TABLE_NAME, change_op, to_jsonb(changed) - 'password_hash');
+
+    -- Users have no reminded_at, so the todos are told apart first
+    IF change_op = 'UPDATE' AND TG_TABLE_NAME = 'todos' THEN
+        IF OLD.reminded_at IS NULL AND NEW.reminded_at IS NOT NULL THEN
+            INSERT INTO change_events (table_name, op, data)
+            VALUES (TG_TABLE_NAME, 'REMIND', to_jsonb(NEW));
+        END IF;
+    END IF;
+
+    -- Notifications are delivered on commit and identical ones are merged
+    PERFORM pg_notify('change_events', TG_TABLE_NAME);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS todos_change_feed ON todos;
+CREATE TRIGGER todos_change_feed
+    AFTER INSERT OR UPDATE OR DELETE ON todos
+    FOR EACH ROW EXECUTE FUNCTION record_change();
+
+DROP TRIGGER IF EXISTS users_change_feed ON users;
+CREATE TRIGGER users_change_feed
+    AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION record_change();
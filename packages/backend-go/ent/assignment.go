@@ -0,0 +1,166 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/assignment"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Assignment is the model entity for the Assignment schema.
+type Assignment struct {
+	config `json:"-"`
+	// TodoID holds the value of the "todo_id" field.
+	TodoID uuid.UUID `json:"todo_id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the AssignmentQuery when eager-loading is set.
+	Edges        AssignmentEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// AssignmentEdges holds the relations/edges for other nodes in the graph.
+type AssignmentEdges struct {
+	// Todo holds the value of the todo edge.
+	Todo *Todo `json:"todo,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// TodoOrErr returns the Todo value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AssignmentEdges) TodoOrErr() (*Todo, error) {
+	if e.Todo != nil {
+		return e.Todo, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: todo.Label}
+	}
+	return nil, &NotLoadedError{edge: "todo"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AssignmentEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Assignment) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case assignment.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case assignment.FieldTodoID, assignment.FieldUserID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Assignment fields.
+func (_m *Assignment) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case assignment.FieldTodoID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field todo_id", values[i])
+			} else if value != nil {
+				_m.TodoID = *value
+			}
+		case assignment.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				_m.UserID = *value
+			}
+		case assignment.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Assignment.
+// This includes values selected through modifiers, order, etc.
+func (_m *Assignment) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryTodo queries the "todo" edge of the Assignment entity.
+func (_m *Assignment) QueryTodo() *TodoQuery {
+	return NewAssignmentClient(_m.config).QueryTodo(_m)
+}
+
+// QueryUser queries the "user" edge of the Assignment entity.
+func (_m *Assignment) QueryUser() *UserQuery {
+	return NewAssignmentClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this Assignment.
+// Note that you need to call Assignment.Unwrap() before calling this method if this Assignment
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Assignment) Update() *AssignmentUpdateOne {
+	return NewAssignmentClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Assignment entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Assignment) Unwrap() *Assignment {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Assignment is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Assignment) String() string {
+	var builder strings.Builder
+	builder.WriteString("Assignment(")
+	builder.WriteString("todo_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TodoID))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Assignments is a parsable slice of Assignment.
+type Assignments []*Assignment
@@ -0,0 +1,113 @@
+// Code generated by ent, DO NOT EDIT.
+
+package assignment
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the assignment type in the database.
+	Label = "assignment"
+	// FieldTodoID holds the string denoting the todo_id field in the database.
+	FieldTodoID = "todo_id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgeTodo holds the string denoting the todo edge name in mutations.
+	EdgeTodo = "todo"
+	// EdgeUser holds the string denoting the user edge name in mutations.
+	EdgeUser = "user"
+	// TodoFieldID holds the string denoting the ID field of the Todo.
+	TodoFieldID = "id"
+	// UserFieldID holds the string denoting the ID field of the User.
+	UserFieldID = "id"
+	// Table holds the table name of the assignment in the database.
+	Table = "todo_assignees"
+	// TodoTable is the table that holds the todo relation/edge.
+	TodoTable = "todo_assignees"
+	// TodoInverseTable is the table name for the Todo entity.
+	// It exists in this package in order to avoid circular dependency with the "todo" package.
+	TodoInverseTable = "todos"
+	// TodoColumn is the table column denoting the todo relation/edge.
+	TodoColumn = "todo_id"
+	// UserTable is the table that holds the user relation/edge.
+	UserTable = "todo_assignees"
+	// UserInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	UserInverseTable = "users"
+	// UserColumn is the table column denoting the user relation/edge.
+	UserColumn = "user_id"
+)
+
+// Columns holds all SQL columns for assignment fields.
+var Columns = []string{
+	FieldTodoID,
+	FieldUserID,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the Assignment queries.
+type OrderOption func(*sql.Selector)
+
+// ByTodoID orders the results by the todo_id field.
+func ByTodoID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTodoID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByTodoField orders the results by todo field.
+func ByTodoField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTodoStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByUserField orders the results by user field.
+func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newUserStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newTodoStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, TodoColumn),
+		sqlgraph.To(TodoInverseTable, TodoFieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, TodoTable, TodoColumn),
+	)
+}
+func newUserStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, UserColumn),
+		sqlgraph.To(UserInverseTable, UserFieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, UserTable, UserColumn),
+	)
+}
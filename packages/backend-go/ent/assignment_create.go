@@ -0,0 +1,264 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/assignment"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// AssignmentCreate is the builder for creating a Assignment entity.
+type AssignmentCreate struct {
+	config
+	mutation *AssignmentMutation
+	hooks    []Hook
+}
+
+// SetTodoID sets the "todo_id" field.
+func (_c *AssignmentCreate) SetTodoID(v uuid.UUID) *AssignmentCreate {
+	_c.mutation.SetTodoID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *AssignmentCreate) SetUserID(v uuid.UUID) *AssignmentCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AssignmentCreate) SetCreatedAt(v time.Time) *AssignmentCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AssignmentCreate) SetNillableCreatedAt(v *time.Time) *AssignmentCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetTodo sets the "todo" edge to the Todo entity.
+func (_c *AssignmentCreate) SetTodo(v *Todo) *AssignmentCreate {
+	return _c.SetTodoID(v.ID)
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *AssignmentCreate) SetUser(v *User) *AssignmentCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the AssignmentMutation object of the builder.
+func (_c *AssignmentCreate) Mutation() *AssignmentMutation {
+	return _c.mutation
+}
+
+// Save creates the Assignment in the database.
+func (_c *AssignmentCreate) Save(ctx context.Context) (*Assignment, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AssignmentCreate) SaveX(ctx context.Context) *Assignment {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AssignmentCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AssignmentCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AssignmentCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := assignment.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AssignmentCreate) check() error {
+	if _, ok := _c.mutation.TodoID(); !ok {
+		return &ValidationError{Name: "todo_id", err: errors.New(`ent: missing required field "Assignment.todo_id"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Assignment.user_id"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Assignment.created_at"`)}
+	}
+	if len(_c.mutation.TodoIDs()) == 0 {
+		return &ValidationError{Name: "todo", err: errors.New(`ent: missing required edge "Assignment.todo"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Assignment.user"`)}
+	}
+	return nil
+}
+
+func (_c *AssignmentCreate) sqlSave(ctx context.Context) (*Assignment, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
+
+func (_c *AssignmentCreate) createSpec() (*Assignment, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Assignment{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(assignment.Table, nil)
+	)
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(assignment.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.TodoIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.TodoTable,
+			Columns: []string{assignment.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.TodoID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.UserTable,
+			Columns: []string{assignment.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// AssignmentCreateBulk is the builder for creating many Assignment entities in bulk.
+type AssignmentCreateBulk struct {
+	config
+	err      error
+	builders []*AssignmentCreate
+}
+
+// Save creates the Assignment entities in the database.
+func (_c *AssignmentCreateBulk) Save(ctx context.Context) ([]*Assignment, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Assignment, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AssignmentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AssignmentCreateBulk) SaveX(ctx context.Context) []*Assignment {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AssignmentCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AssignmentCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,424 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/assignment"
+	"backend-go/ent/predicate"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// AssignmentUpdate is the builder for updating Assignment entities.
+type AssignmentUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AssignmentMutation
+}
+
+// Where appends a list predicates to the AssignmentUpdate builder.
+func (_u *AssignmentUpdate) Where(ps ...predicate.Assignment) *AssignmentUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTodoID sets the "todo_id" field.
+func (_u *AssignmentUpdate) SetTodoID(v uuid.UUID) *AssignmentUpdate {
+	_u.mutation.SetTodoID(v)
+	return _u
+}
+
+// SetNillableTodoID sets the "todo_id" field if the given value is not nil.
+func (_u *AssignmentUpdate) SetNillableTodoID(v *uuid.UUID) *AssignmentUpdate {
+	if v != nil {
+		_u.SetTodoID(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *AssignmentUpdate) SetUserID(v uuid.UUID) *AssignmentUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *AssignmentUpdate) SetNillableUserID(v *uuid.UUID) *AssignmentUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetTodo sets the "todo" edge to the Todo entity.
+func (_u *AssignmentUpdate) SetTodo(v *Todo) *AssignmentUpdate {
+	return _u.SetTodoID(v.ID)
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *AssignmentUpdate) SetUser(v *User) *AssignmentUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the AssignmentMutation object of the builder.
+func (_u *AssignmentUpdate) Mutation() *AssignmentMutation {
+	return _u.mutation
+}
+
+// ClearTodo clears the "todo" edge to the Todo entity.
+func (_u *AssignmentUpdate) ClearTodo() *AssignmentUpdate {
+	_u.mutation.ClearTodo()
+	return _u
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *AssignmentUpdate) ClearUser() *AssignmentUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AssignmentUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AssignmentUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AssignmentUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AssignmentUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AssignmentUpdate) check() error {
+	if _u.mutation.TodoCleared() && len(_u.mutation.TodoIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Assignment.todo"`)
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Assignment.user"`)
+	}
+	return nil
+}
+
+func (_u *AssignmentUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(assignment.Table, assignment.Columns, sqlgraph.NewFieldSpec(assignment.FieldTodoID, field.TypeUUID), sqlgraph.NewFieldSpec(assignment.FieldUserID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.TodoCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.TodoTable,
+			Columns: []string{assignment.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TodoIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.TodoTable,
+			Columns: []string{assignment.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.UserTable,
+			Columns: []string{assignment.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.UserTable,
+			Columns: []string{assignment.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{assignment.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AssignmentUpdateOne is the builder for updating a single Assignment entity.
+type AssignmentUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AssignmentMutation
+}
+
+// SetTodoID sets the "todo_id" field.
+func (_u *AssignmentUpdateOne) SetTodoID(v uuid.UUID) *AssignmentUpdateOne {
+	_u.mutation.SetTodoID(v)
+	return _u
+}
+
+// SetNillableTodoID sets the "todo_id" field if the given value is not nil.
+func (_u *AssignmentUpdateOne) SetNillableTodoID(v *uuid.UUID) *AssignmentUpdateOne {
+	if v != nil {
+		_u.SetTodoID(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *AssignmentUpdateOne) SetUserID(v uuid.UUID) *AssignmentUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *AssignmentUpdateOne) SetNillableUserID(v *uuid.UUID) *AssignmentUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetTodo sets the "todo" edge to the Todo entity.
+func (_u *AssignmentUpdateOne) SetTodo(v *Todo) *AssignmentUpdateOne {
+	return _u.SetTodoID(v.ID)
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *AssignmentUpdateOne) SetUser(v *User) *AssignmentUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the AssignmentMutation object of the builder.
+func (_u *AssignmentUpdateOne) Mutation() *AssignmentMutation {
+	return _u.mutation
+}
+
+// ClearTodo clears the "todo" edge to the Todo entity.
+func (_u *AssignmentUpdateOne) ClearTodo() *AssignmentUpdateOne {
+	_u.mutation.ClearTodo()
+	return _u
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *AssignmentUpdateOne) ClearUser() *AssignmentUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the AssignmentUpdate builder.
+func (_u *AssignmentUpdateOne) Where(ps ...predicate.Assignment) *AssignmentUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AssignmentUpdateOne) Select(field string, fields ...string) *AssignmentUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Assignment entity.
+func (_u *AssignmentUpdateOne) Save(ctx context.Context) (*Assignment, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AssignmentUpdateOne) SaveX(ctx context.Context) *Assignment {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AssignmentUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AssignmentUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AssignmentUpdateOne) check() error {
+	if _u.mutation.TodoCleared() && len(_u.mutation.TodoIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Assignment.todo"`)
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Assignment.user"`)
+	}
+	return nil
+}
+
+func (_u *AssignmentUpdateOne) sqlSave(ctx context.Context) (_node *Assignment, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(assignment.Table, assignment.Columns, sqlgraph.NewFieldSpec(assignment.FieldTodoID, field.TypeUUID), sqlgraph.NewFieldSpec(assignment.FieldUserID, field.TypeUUID))
+	if id, ok := _u.mutation.TodoID(); !ok {
+		return nil, &ValidationError{Name: "todo_id", err: errors.New(`ent: missing "Assignment.todo_id" for update`)}
+	} else {
+		_spec.Node.CompositeID[0].Value = id
+	}
+	if id, ok := _u.mutation.UserID(); !ok {
+		return nil, &ValidationError{Name: "user_id", err: errors.New(`ent: missing "Assignment.user_id" for update`)}
+	} else {
+		_spec.Node.CompositeID[1].Value = id
+	}
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, len(fields))
+		for i, f := range fields {
+			if !assignment.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			_spec.Node.Columns[i] = f
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.TodoCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.TodoTable,
+			Columns: []string{assignment.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TodoIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.TodoTable,
+			Columns: []string{assignment.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.UserTable,
+			Columns: []string{assignment.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   assignment.UserTable,
+			Columns: []string{assignment.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Assignment{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{assignment.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
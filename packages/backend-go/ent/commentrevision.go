@@ -0,0 +1,188 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/comment"
+	"backend-go/ent/commentrevision"
+	"backend-go/ent/workspace"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// CommentRevision is the model entity for the CommentRevision schema.
+type CommentRevision struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// WorkspaceID holds the value of the "workspace_id" field.
+	WorkspaceID uuid.UUID `json:"workspace_id,omitempty"`
+	// Body holds the value of the "body" field.
+	Body string `json:"body,omitempty"`
+	// CommentID holds the value of the "comment_id" field.
+	CommentID uuid.UUID `json:"comment_id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the CommentRevisionQuery when eager-loading is set.
+	Edges        CommentRevisionEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// CommentRevisionEdges holds the relations/edges for other nodes in the graph.
+type CommentRevisionEdges struct {
+	// Workspace holds the value of the workspace edge.
+	Workspace *Workspace `json:"workspace,omitempty"`
+	// Comment holds the value of the comment edge.
+	Comment *Comment `json:"comment,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// WorkspaceOrErr returns the Workspace value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e CommentRevisionEdges) WorkspaceOrErr() (*Workspace, error) {
+	if e.Workspace != nil {
+		return e.Workspace, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: workspace.Label}
+	}
+	return nil, &NotLoadedError{edge: "workspace"}
+}
+
+// CommentOrErr returns the Comment value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e CommentRevisionEdges) CommentOrErr() (*Comment, error) {
+	if e.Comment != nil {
+		return e.Comment, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: comment.Label}
+	}
+	return nil, &NotLoadedError{edge: "comment"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CommentRevision) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case commentrevision.FieldBody:
+			values[i] = new(sql.NullString)
+		case commentrevision.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case commentrevision.FieldID, commentrevision.FieldWorkspaceID, commentrevision.FieldCommentID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CommentRevision fields.
+func (_m *CommentRevision) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case commentrevision.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case commentrevision.FieldWorkspaceID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field workspace_id", values[i])
+			} else if value != nil {
+				_m.WorkspaceID = *value
+			}
+		case commentrevision.FieldBody:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field body", values[i])
+			} else if value.Valid {
+				_m.Body = value.String
+			}
+		case commentrevision.FieldCommentID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field comment_id", values[i])
+			} else if value != nil {
+				_m.CommentID = *value
+			}
+		case commentrevision.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CommentRevision.
+// This includes values selected through modifiers, order, etc.
+func (_m *CommentRevision) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryWorkspace queries the "workspace" edge of the CommentRevision entity.
+func (_m *CommentRevision) QueryWorkspace() *WorkspaceQuery {
+	return NewCommentRevisionClient(_m.config).QueryWorkspace(_m)
+}
+
+// QueryComment queries the "comment" edge of the CommentRevision entity.
+func (_m *CommentRevision) QueryComment() *CommentQuery {
+	return NewCommentRevisionClient(_m.config).QueryComment(_m)
+}
+
+// Update returns a builder for updating this CommentRevision.
+// Note that you need to call CommentRevision.Unwrap() before calling this method if this CommentRevision
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *CommentRevision) Update() *CommentRevisionUpdateOne {
+	return NewCommentRevisionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the CommentRevision entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *CommentRevision) Unwrap() *CommentRevision {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: CommentRevision is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *CommentRevision) String() string {
+	var builder strings.Builder
+	builder.WriteString("CommentRevision(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("workspace_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.WorkspaceID))
+	builder.WriteString(", ")
+	builder.WriteString("body=")
+	builder.WriteString(_m.Body)
+	builder.WriteString(", ")
+	builder.WriteString("comment_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CommentID))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CommentRevisions is a parsable slice of CommentRevision.
+type CommentRevisions []*CommentRevision
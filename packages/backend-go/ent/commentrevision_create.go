@@ -0,0 +1,318 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/comment"
+	"backend-go/ent/commentrevision"
+	"backend-go/ent/workspace"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// CommentRevisionCreate is the builder for creating a CommentRevision entity.
+type CommentRevisionCreate struct {
+	config
+	mutation *CommentRevisionMutation
+	hooks    []Hook
+}
+
+// SetWorkspaceID sets the "workspace_id" field.
+func (_c *CommentRevisionCreate) SetWorkspaceID(v uuid.UUID) *CommentRevisionCreate {
+	_c.mutation.SetWorkspaceID(v)
+	return _c
+}
+
+// SetBody sets the "body" field.
+func (_c *CommentRevisionCreate) SetBody(v string) *CommentRevisionCreate {
+	_c.mutation.SetBody(v)
+	return _c
+}
+
+// SetCommentID sets the "comment_id" field.
+func (_c *CommentRevisionCreate) SetCommentID(v uuid.UUID) *CommentRevisionCreate {
+	_c.mutation.SetCommentID(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *CommentRevisionCreate) SetCreatedAt(v time.Time) *CommentRevisionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *CommentRevisionCreate) SetNillableCreatedAt(v *time.Time) *CommentRevisionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *CommentRevisionCreate) SetID(v uuid.UUID) *CommentRevisionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *CommentRevisionCreate) SetNillableID(v *uuid.UUID) *CommentRevisionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetWorkspace sets the "workspace" edge to the Workspace entity.
+func (_c *CommentRevisionCreate) SetWorkspace(v *Workspace) *CommentRevisionCreate {
+	return _c.SetWorkspaceID(v.ID)
+}
+
+// SetComment sets the "comment" edge to the Comment entity.
+func (_c *CommentRevisionCreate) SetComment(v *Comment) *CommentRevisionCreate {
+	return _c.SetCommentID(v.ID)
+}
+
+// Mutation returns the CommentRevisionMutation object of the builder.
+func (_c *CommentRevisionCreate) Mutation() *CommentRevisionMutation {
+	return _c.mutation
+}
+
+// Save creates the CommentRevision in the database.
+func (_c *CommentRevisionCreate) Save(ctx context.Context) (*CommentRevision, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *CommentRevisionCreate) SaveX(ctx context.Context) *CommentRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CommentRevisionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CommentRevisionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *CommentRevisionCreate) defaults() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		if commentrevision.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized commentrevision.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := commentrevision.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		if commentrevision.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized commentrevision.DefaultID (forgotten import ent/runtime?)")
+		}
+		v := commentrevision.DefaultID()
+		_c.mutation.SetID(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *CommentRevisionCreate) check() error {
+	if _, ok := _c.mutation.WorkspaceID(); !ok {
+		return &ValidationError{Name: "workspace_id", err: errors.New(`ent: missing required field "CommentRevision.workspace_id"`)}
+	}
+	if _, ok := _c.mutation.Body(); !ok {
+		return &ValidationError{Name: "body", err: errors.New(`ent: missing required field "CommentRevision.body"`)}
+	}
+	if _, ok := _c.mutation.CommentID(); !ok {
+		return &ValidationError{Name: "comment_id", err: errors.New(`ent: missing required field "CommentRevision.comment_id"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "CommentRevision.created_at"`)}
+	}
+	if len(_c.mutation.WorkspaceIDs()) == 0 {
+		return &ValidationError{Name: "workspace", err: errors.New(`ent: missing required edge "CommentRevision.workspace"`)}
+	}
+	if len(_c.mutation.CommentIDs()) == 0 {
+		return &ValidationError{Name: "comment", err: errors.New(`ent: missing required edge "CommentRevision.comment"`)}
+	}
+	return nil
+}
+
+func (_c *CommentRevisionCreate) sqlSave(ctx context.Context) (*CommentRevision, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *CommentRevisionCreate) createSpec() (*CommentRevision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CommentRevision{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(commentrevision.Table, sqlgraph.NewFieldSpec(commentrevision.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Body(); ok {
+		_spec.SetField(commentrevision.FieldBody, field.TypeString, value)
+		_node.Body = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(commentrevision.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.WorkspaceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   commentrevision.WorkspaceTable,
+			Columns: []string{commentrevision.WorkspaceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workspace.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.WorkspaceID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.CommentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   commentrevision.CommentTable,
+			Columns: []string{commentrevision.CommentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.CommentID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// CommentRevisionCreateBulk is the builder for creating many CommentRevision entities in bulk.
+type CommentRevisionCreateBulk struct {
+	config
+	err      error
+	builders []*CommentRevisionCreate
+}
+
+// Save creates the CommentRevision entities in the database.
+func (_c *CommentRevisionCreateBulk) Save(ctx context.Context) ([]*CommentRevision, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*CommentRevision, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CommentRevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *CommentRevisionCreateBulk) SaveX(ctx context.Context) []*CommentRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *CommentRevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *CommentRevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,203 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/commentrevision"
+	"backend-go/ent/predicate"
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CommentRevisionUpdate is the builder for updating CommentRevision entities.
+type CommentRevisionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CommentRevisionMutation
+}
+
+// Where appends a list predicates to the CommentRevisionUpdate builder.
+func (_u *CommentRevisionUpdate) Where(ps ...predicate.CommentRevision) *CommentRevisionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Mutation returns the CommentRevisionMutation object of the builder.
+func (_u *CommentRevisionUpdate) Mutation() *CommentRevisionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *CommentRevisionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CommentRevisionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *CommentRevisionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CommentRevisionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CommentRevisionUpdate) check() error {
+	if _u.mutation.WorkspaceCleared() && len(_u.mutation.WorkspaceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "CommentRevision.workspace"`)
+	}
+	if _u.mutation.CommentCleared() && len(_u.mutation.CommentIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "CommentRevision.comment"`)
+	}
+	return nil
+}
+
+func (_u *CommentRevisionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(commentrevision.Table, commentrevision.Columns, sqlgraph.NewFieldSpec(commentrevision.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{commentrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// CommentRevisionUpdateOne is the builder for updating a single CommentRevision entity.
+type CommentRevisionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CommentRevisionMutation
+}
+
+// Mutation returns the CommentRevisionMutation object of the builder.
+func (_u *CommentRevisionUpdateOne) Mutation() *CommentRevisionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the CommentRevisionUpdate builder.
+func (_u *CommentRevisionUpdateOne) Where(ps ...predicate.CommentRevision) *CommentRevisionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *CommentRevisionUpdateOne) Select(field string, fields ...string) *CommentRevisionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated CommentRevision entity.
+func (_u *CommentRevisionUpdateOne) Save(ctx context.Context) (*CommentRevision, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *CommentRevisionUpdateOne) SaveX(ctx context.Context) *CommentRevision {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *CommentRevisionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *CommentRevisionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *CommentRevisionUpdateOne) check() error {
+	if _u.mutation.WorkspaceCleared() && len(_u.mutation.WorkspaceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "CommentRevision.workspace"`)
+	}
+	if _u.mutation.CommentCleared() && len(_u.mutation.CommentIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "CommentRevision.comment"`)
+	}
+	return nil
+}
+
+func (_u *CommentRevisionUpdateOne) sqlSave(ctx context.Context) (_node *CommentRevision, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(commentrevision.Table, commentrevision.Columns, sqlgraph.NewFieldSpec(commentrevision.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CommentRevision.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, commentrevision.FieldID)
+		for _, f := range fields {
+			if !commentrevision.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != commentrevision.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &CommentRevision{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{commentrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -3,8 +3,19 @@
 package ent
 
 import (
+	"backend-go/ent/assignment"
+	"backend-go/ent/comment"
+	"backend-go/ent/commentrevision"
+	"backend-go/ent/deniedsession"
+	"backend-go/ent/invitation"
+	"backend-go/ent/membership"
+	"backend-go/ent/project"
+	"backend-go/ent/session"
+	"backend-go/ent/signingkey"
+	"backend-go/ent/tag"
 	"backend-go/ent/todo"
 	"backend-go/ent/user"
+	"backend-go/ent/workspace"
 	"context"
 	"errors"
 	"fmt"
@@ -74,8 +85,19 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			todo.Table: todo.ValidColumn,
-			user.Table: user.ValidColumn,
+			assignment.Table:      assignment.ValidColumn,
+			comment.Table:         comment.ValidColumn,
+			commentrevision.Table: commentrevision.ValidColumn,
+			deniedsession.Table:   deniedsession.ValidColumn,
+			invitation.Table:      invitation.ValidColumn,
+			membership.Table:      membership.ValidColumn,
+			project.Table:         project.ValidColumn,
+			session.Table:         session.ValidColumn,
+			signingkey.Table:      signingkey.ValidColumn,
+			tag.Table:             tag.ValidColumn,
+			todo.Table:            todo.ValidColumn,
+			user.Table:            user.ValidColumn,
+			workspace.Table:       workspace.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -11,7 +11,9 @@ import (
 )
 
 func main() {
-	if err := entc.Generate("./ent/schema", &gen.Config{}); err != nil {
+	if err := entc.Generate("./ent/schema", &gen.Config{
+		Features: []gen.Feature{gen.FeatureIntercept, gen.FeaturePrivacy},
+	}); err != nil {
 		log.Fatalf("running ent codegen: %v", err)
 	}
 }
@@ -2,58 +2,4 @@
 
 package ent
 
-import (
-	"backend-go/ent/schema"
-	"backend-go/ent/todo"
-	"backend-go/ent/user"
-
-	"github.com/google/uuid"
-)
-
-// The init function reads all schema descriptors with runtime code
-// (default values, validators, hooks and policies) and stitches it
-// to their package variables.
-func init() {
-	todoFields := schema.Todo{}.Fields()
-	_ = todoFields
-	// todoDescTitle is the schema descriptor for title field.
-	todoDescTitle := todoFields[1].Descriptor()
-	// todo.TitleValidator is a validator for the "title" field. It is called by the builders before save.
-	todo.TitleValidator = func() func(string) error {
-		validators := todoDescTitle.Validators
-		fns := [...]func(string) error{
-			validators[0].(func(string) error),
-			validators[1].(func(string) error),
-		}
-		return func(title string) error {
-			for _, fn := range fns {
-				if err := fn(title); err != nil {
-					return err
-				}
-			}
-			return nil
-		}
-	}()
-	// todoDescCompleted is the schema descriptor for completed field.
-	todoDescCompleted := todoFields[2].Descriptor()
-	// todo.DefaultCompleted holds the default value on creation for the completed field.
-	todo.DefaultCompleted = todoDescCompleted.Default.(bool)
-	// todoDescID is the schema descriptor for id field.
-	todoDescID := todoFields[0].Descriptor()
-	// todo.DefaultID holds the default value on creation for the id field.
-	todo.DefaultID = todoDescID.Default.(func() uuid.UUID)
-	userFields := schema.User{}.Fields()
-	_ = userFields
-	// userDescEmail is the schema descriptor for email field.
-	userDescEmail := userFields[1].Descriptor()
-	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
-	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
-	// userDescName is the schema descriptor for name field.
-	userDescName := userFields[2].Descriptor()
-	// user.NameValidator is a validator for the "name" field. It is called by the builders before save.
-	user.NameValidator = userDescName.Validators[0].(func(string) error)
-	// userDescID is the schema descriptor for id field.
-	userDescID := userFields[0].Descriptor()
-	// user.DefaultID holds the default value on creation for the id field.
-	user.DefaultID = userDescID.Default.(func() uuid.UUID)
-}
+// The schema-stitching logic is generated in backend-go/ent/runtime/runtime.go
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Assignment holds the schema definition for the Assignment entity, the edge
+// between a todo and one of its assignees. It remembers when the user was
+// assigned so the first assignee stays the same as others come and go.
+type Assignment struct {
+	ent.Schema
+}
+
+// Fields of the Assignment
+func (Assignment) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("todo_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Assignment - Assignment joins a todo and a user, and goes when
+// either of them is purged
+func (Assignment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("todo", Todo.Type).
+			Field("todo_id").
+			Unique().
+			Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("user", User.Type).
+			Field("user_id").
+			Unique().
+			Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Annotations configures the composite key and table name
+func (Assignment) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		field.ID("todo_id", "user_id"),
+		entsql.Annotation{Table: "todo_assignees"},
+	}
+}
@@ -0,0 +1,196 @@
+package schema
+
+import (
+	"cmp"
+	"context"
+	"errors"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	gen "backend-go/ent"
+	"backend-go/ent/comment"
+	"backend-go/ent/hook"
+	"backend-go/ent/membership"
+	"backend-go/ent/privacy"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Comment holds the schema definition for the Comment entity.
+type Comment struct {
+	ent.Schema
+}
+
+// Mixin of the Comment - adds the created_at and updated_at timestamps and
+// scopes comments to their workspace
+func (Comment) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the Comment
+func (Comment) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.Text("body").
+			NotEmpty(),
+		field.Bool("edited").
+			Default(false),
+		field.UUID("todo_id", uuid.UUID{}).
+			Immutable(),
+		// Cleared when the author is purged, keeping their comments
+		field.UUID("author_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Immutable(),
+	}
+}
+
+// Edges of the Comment - Comment belongs to a workspace, a todo and its
+// author, and keeps the bodies it had before each edit
+func (Comment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("comments").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("todo", Todo.Type).
+			Ref("comments").
+			Field("todo_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("author", User.Type).
+			Ref("comments").
+			Field("author_id").
+			Unique().
+			Immutable(),
+		edge.To("revisions", CommentRevision.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Hooks of the Comment - keep a revision for every edit of a comment
+func (Comment) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(recordRevision, ent.OpUpdateOne),
+		// Revisions need the old body, which is only read for single comments
+		hook.If(
+			hook.FixedError(errors.New("comment bodies can only be edited one at a time")),
+			hook.And(hook.HasOp(ent.OpUpdate), hook.HasFields("body")),
+		),
+	}
+}
+
+// Policy of the Comment - members of the workspace discuss its todos under
+// their own name, and only change their own comments, unless they are admins
+func (Comment) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleVIEWER),
+			privacy.AlwaysAllowRule(),
+		},
+		Mutation: privacy.MutationPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleMEMBER),
+			privacy.OnMutationOperation(authorOnly(), ent.OpCreate),
+			allowIfRole(membership.RoleADMIN),
+			privacy.OnMutationOperation(
+				authorsOnly(),
+				ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+			),
+			privacy.AlwaysAllowRule(),
+		},
+	}
+}
+
+// authorOnly makes sure new comments are written by the logged in user,
+// even admins don't comment in the name of others
+func authorOnly() privacy.MutationRule {
+	return privacy.CommentMutationRuleFunc(func(ctx context.Context, m *gen.CommentMutation) error {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return deny(auth.ErrNotLoggedIn)
+		}
+		if author, ok := m.AuthorID(); !ok || author != viewer.ID {
+			return deny(apperror.Forbidden("comments can only be added by the logged in user"))
+		}
+		return privacy.Skip
+	})
+}
+
+// authorsOnly restricts changes of comments to their authors. Single comments
+// fail with FORBIDDEN, bulk changes skip the comments of others.
+func authorsOnly() privacy.MutationRule {
+	return privacy.CommentMutationRuleFunc(func(ctx context.Context, m *gen.CommentMutation) error {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return deny(auth.ErrNotLoggedIn)
+		}
+
+		id, ok := m.ID()
+		if !ok || !m.Op().Is(ent.OpUpdateOne|ent.OpDeleteOne) {
+			m.Where(comment.AuthorID(viewer.ID))
+			return privacy.Allow
+		}
+
+		own, err := m.Client().Comment.Query().Where(comment.ID(id), comment.AuthorID(viewer.ID)).Exist(ctx)
+		if err != nil || own {
+			return cmp.Or(err, privacy.Allow)
+		}
+		// Leave comments that don't exist to the mutation, which fails with NOT_FOUND
+		exists, err := m.Client().Comment.Query().Where(comment.ID(id)).Exist(ctx)
+		if err != nil || !exists {
+			return cmp.Or(err, privacy.Skip)
+		}
+		return deny(apperror.Forbidden("only the author of comment %s can change it", id))
+	})
+}
+
+// recordRevision saves the body a comment had before an edit and flags the
+// comment as edited. Saving the same body again isn't an edit.
+func recordRevision(next ent.Mutator) ent.Mutator {
+	return hook.CommentFunc(func(ctx context.Context, m *gen.CommentMutation) (ent.Value, error) {
+		body, ok := m.Body()
+		if !ok {
+			return next.Mutate(ctx, m)
+		}
+		old, err := m.OldBody(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if old == body {
+			return next.Mutate(ctx, m)
+		}
+
+		id, _ := m.ID()
+		if err := m.Client().CommentRevision.Create().
+			SetCommentID(id).
+			SetBody(old).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		m.SetEdited(true)
+		return next.Mutate(ctx, m)
+	})
+}
+
+// Annotations configures table name
+func (Comment) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "comments"},
+	}
+}
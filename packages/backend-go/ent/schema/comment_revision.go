@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// CommentRevision holds the schema definition for the CommentRevision entity.
+// A revision is the body a comment had before one of its edits.
+type CommentRevision struct {
+	ent.Schema
+}
+
+// Mixin of the CommentRevision - scopes revisions to their workspace
+func (CommentRevision) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the CommentRevision - revisions are never changed once recorded
+func (CommentRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.Text("body").
+			Immutable(),
+		field.UUID("comment_id", uuid.UUID{}).
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// Edges of the CommentRevision - CommentRevision belongs to a workspace and a
+// comment
+func (CommentRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("comment_revisions").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("comment", Comment.Type).
+			Ref("revisions").
+			Field("comment_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Annotations configures table name
+func (CommentRevision) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "comment_revisions"},
+	}
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// DeniedSession holds the schema definition for the DeniedSession entity, an
+// entry of the denylist of sessions that were logged out. Access tokens are
+// verified without looking up their session, so the ones still unexpired
+// when it ends are rejected by this list instead.
+type DeniedSession struct {
+	ent.Schema
+}
+
+// Fields of the DeniedSession
+func (DeniedSession) Fields() []ent.Field {
+	return []ent.Field{
+		// The ID of the session that was logged out
+		field.UUID("id", uuid.UUID{}).
+			Unique().
+			Immutable(),
+		// When the last access token of the session expires, after which the
+		// entry can be removed
+		field.Time("expires_at").
+			Immutable(),
+	}
+}
+
+// Annotations configures table name
+func (DeniedSession) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "session_denylist"},
+	}
+}
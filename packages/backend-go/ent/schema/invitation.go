@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Invitation holds the schema definition for the Invitation entity. An
+// invitation offers a user a role in a workspace, which they only join once
+// they accept it.
+type Invitation struct {
+	ent.Schema
+}
+
+// Mixin of the Invitation - adds the created_at and updated_at timestamps and
+// scopes invitations to the workspace they invite to
+func (Invitation) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the Invitation
+func (Invitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		// The role the user gets on accepting
+		field.Enum("role").
+			Values("OWNER", "ADMIN", "MEMBER", "VIEWER").
+			Default("MEMBER"),
+	}
+}
+
+// Edges of the Invitation - Invitation invites a user to a workspace
+func (Invitation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("invitations").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("user", User.Type).
+			Ref("invitations").
+			Field("user_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the Invitation - users are invited to a workspace once
+func (Invitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("workspace_id", "user_id").
+			Unique(),
+	}
+}
+
+// Annotations configures table name
+func (Invitation) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "invitations"},
+	}
+}
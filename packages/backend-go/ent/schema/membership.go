@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Membership holds the schema definition for the Membership entity. A
+// membership makes a user part of a workspace in one of its roles.
+type Membership struct {
+	ent.Schema
+}
+
+// Mixin of the Membership - adds the created_at and updated_at timestamps and
+// scopes memberships to their workspace
+func (Membership) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the Membership
+func (Membership) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		// What the member may do in the workspace. Single sign-on can
+		// assign it from claims of the identity provider, see auth.RoleRule.
+		field.Enum("role").
+			Values("OWNER", "ADMIN", "MEMBER", "VIEWER").
+			Default("MEMBER"),
+	}
+}
+
+// Edges of the Membership - Membership joins a user and a workspace
+func (Membership) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("memberships").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("user", User.Type).
+			Ref("memberships").
+			Field("user_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the Membership - users join a workspace once
+func (Membership) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("workspace_id", "user_id").
+			Unique(),
+	}
+}
+
+// Annotations configures table name
+func (Membership) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "memberships"},
+	}
+}
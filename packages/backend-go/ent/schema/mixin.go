@@ -0,0 +1,246 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gen "backend-go/ent"
+	"backend-go/ent/hook"
+	"backend-go/ent/intercept"
+	"backend-go/tenant"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"github.com/google/uuid"
+)
+
+// TimeMixin adds created_at and updated_at timestamps - matches the
+// timestamps columns of the TypeScript Drizzle schema
+type TimeMixin struct {
+	mixin.Schema
+}
+
+// Fields of the TimeMixin
+func (TimeMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// softDeleteKey marks contexts that see and hard-delete soft-deleted rows
+type softDeleteKey struct{}
+
+// SkipSoftDelete returns a context in which queries include soft-deleted rows,
+// updates may change them and deletes remove rows for good
+func SkipSoftDelete(parent context.Context) context.Context {
+	return context.WithValue(parent, softDeleteKey{}, true)
+}
+
+// softDeleteSkipped reports whether ctx was returned by SkipSoftDelete
+func softDeleteSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(softDeleteKey{}).(bool)
+	return skip
+}
+
+// SoftDeleteMixin adds a deleted_at timestamp. Deletes set it instead of
+// removing the row, and queries and updates leave rows that have it set alone
+// unless the context comes from SkipSoftDelete.
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+// Fields of the SoftDeleteMixin
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deleted_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Interceptors of the SoftDeleteMixin - hide soft-deleted rows from queries
+func (d SoftDeleteMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if !softDeleteSkipped(ctx) {
+				d.P(q)
+			}
+			return nil
+		}),
+	}
+}
+
+// Hooks of the SoftDeleteMixin - turn deletes into updates of deleted_at
+func (d SoftDeleteMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					if softDeleteSkipped(ctx) {
+						return next.Mutate(ctx, m)
+					}
+					mx, ok := m.(interface {
+						SetOp(ent.Op)
+						Client() *gen.Client
+						SetDeletedAt(time.Time)
+						WhereP(...func(*sql.Selector))
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+
+					d.P(mx)
+					if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+						return next.Mutate(ctx, m)
+					}
+
+					// Run the delete as an update, through every hook again
+					mx.SetOp(ent.OpUpdate)
+					mx.SetDeletedAt(time.Now())
+					return mx.Client().Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+		),
+	}
+}
+
+// P restricts a query or mutation to rows that aren't soft-deleted
+func (SoftDeleteMixin) P(w interface{ WhereP(...func(*sql.Selector)) }) {
+	w.WhereP(sql.FieldIsNull("deleted_at"))
+}
+
+// VersionMixin adds a version number that every update bumps, for clients to
+// detect concurrent edits with
+type VersionMixin struct {
+	mixin.Schema
+}
+
+// Fields of the VersionMixin
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("version").
+			Default(1),
+	}
+}
+
+// Hooks of the VersionMixin - bump the version on every update
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					mx, ok := m.(interface{ AddVersion(int) })
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+					mx.AddVersion(1)
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdate|ent.OpUpdateOne,
+		),
+	}
+}
+
+// WorkspaceMixin adds the workspace_id of workspace-scoped entities. Queries,
+// updates and deletes only reach the rows of the workspace of their context,
+// and creates are put in it. Without a workspace in the context they fail
+// with tenant.ErrNoWorkspace, unless the context comes from tenant.System.
+//
+// Schemas using the mixin declare the workspace edge on workspace_id
+// themselves, as its reference differs per entity.
+type WorkspaceMixin struct {
+	mixin.Schema
+}
+
+// Fields of the WorkspaceMixin
+func (WorkspaceMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("workspace_id", uuid.UUID{}).
+			Immutable(),
+	}
+}
+
+// Interceptors of the WorkspaceMixin - hide the rows of other workspaces
+func (w WorkspaceMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			return w.P(ctx, q)
+		}),
+	}
+}
+
+// Hooks of the WorkspaceMixin - keep writes inside the workspace
+func (w WorkspaceMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					mx, ok := m.(interface {
+						WorkspaceID() (uuid.UUID, bool)
+						SetWorkspaceID(uuid.UUID)
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+					if tenant.IsSystem(ctx) {
+						return next.Mutate(ctx, m)
+					}
+
+					workspaceID, ok := tenant.FromContext(ctx)
+					if !ok {
+						return nil, tenant.ErrNoWorkspace
+					}
+					if id, ok := mx.WorkspaceID(); ok && id != workspaceID {
+						return nil, fmt.Errorf("cannot create %s in workspace %s from workspace %s", m.Type(), id, workspaceID)
+					}
+					mx.SetWorkspaceID(workspaceID)
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpCreate,
+		),
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					mx, ok := m.(interface {
+						WhereP(...func(*sql.Selector))
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+					if err := w.P(ctx, mx); err != nil {
+						return nil, err
+					}
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+		),
+	}
+}
+
+// P restricts a query or mutation to the rows of the workspace of ctx
+func (WorkspaceMixin) P(ctx context.Context, w interface{ WhereP(...func(*sql.Selector)) }) error {
+	if tenant.IsSystem(ctx) {
+		return nil
+	}
+	workspaceID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoWorkspace
+	}
+	w.WhereP(sql.FieldEQ("workspace_id", workspaceID))
+	return nil
+}
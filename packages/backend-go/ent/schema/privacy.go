@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"context"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/ent/privacy"
+	"backend-go/tenant"
+)
+
+// Privacy rules shared by the policies of the schemas. Policies hold every
+// use of the client to the rules of the logged in user and their role, see
+// auth.WithRole, not just GraphQL requests. Background work like the purge
+// job runs in a tenant.System context instead, which every policy allows.
+
+// allowIfSystem allows queries and mutations of System contexts
+func allowIfSystem() privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if tenant.IsSystem(ctx) {
+			return privacy.Allow
+		}
+		return privacy.Skip
+	})
+}
+
+// requireRole denies queries and mutations of contexts that don't act in a
+// workspace with role or a more privileged one
+func requireRole(role membership.Role) privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if _, ok := tenant.FromContext(ctx); !ok {
+			return tenant.ErrNoWorkspace
+		}
+		if err := auth.RequireRole(ctx, role); err != nil {
+			return deny(err)
+		}
+		return privacy.Skip
+	})
+}
+
+// allowIfRole allows queries and mutations of contexts acting with role or a
+// more privileged one
+func allowIfRole(role membership.Role) privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if auth.HasRole(ctx, role) {
+			return privacy.Allow
+		}
+		return privacy.Skip
+	})
+}
+
+// deny returns err as a privacy decision, keeping its code for clients
+func deny(err error) error {
+	if appErr, ok := apperror.As(err); ok {
+		return apperror.Wrap(appErr.Code, privacy.Deny, "%s", appErr.Message)
+	}
+	return privacy.Denyf("%v", err)
+}
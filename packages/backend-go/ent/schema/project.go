@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"cmp"
+	"context"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	gen "backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/ent/privacy"
+	"backend-go/ent/project"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Project holds the schema definition for the Project entity.
+type Project struct {
+	ent.Schema
+}
+
+// Mixin of the Project - adds the created_at and updated_at timestamps and
+// scopes projects to their workspace
+func (Project) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the Project
+func (Project) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.String("name").
+			MaxLen(255).
+			NotEmpty(),
+		field.Text("description").
+			Optional().
+			Nillable(),
+		// Archived projects keep their todos out of the default listings
+		field.Bool("archived").
+			Default(false),
+		// Cleared when the owner is purged, keeping their projects
+		field.UUID("owner_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Project - Project belongs to a workspace and its owner and
+// groups todos, which stay around without a project when it is deleted
+func (Project) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("projects").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.From("owner", User.Type).
+			Ref("projects").
+			Field("owner_id").
+			Unique(),
+		edge.To("todos", Todo.Type),
+	}
+}
+
+// Policy of the Project - members of the workspace create projects they own,
+// and only change and delete the ones they own, unless they are admins
+func (Project) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleVIEWER),
+			privacy.AlwaysAllowRule(),
+		},
+		Mutation: privacy.MutationPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleMEMBER),
+			allowIfRole(membership.RoleADMIN),
+			privacy.OnMutationOperation(ownOnly(), ent.OpCreate),
+			privacy.OnMutationOperation(
+				ownersOnly(),
+				ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+			),
+			privacy.AlwaysAllowRule(),
+		},
+	}
+}
+
+// ownOnly makes sure members create projects owned by themselves, only
+// admins make others the owner
+func ownOnly() privacy.MutationRule {
+	return privacy.ProjectMutationRuleFunc(func(ctx context.Context, m *gen.ProjectMutation) error {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return deny(auth.ErrNotLoggedIn)
+		}
+		if owner, ok := m.OwnerID(); !ok || owner != viewer.ID {
+			return deny(apperror.Forbidden("only admins create projects owned by others"))
+		}
+		return privacy.Skip
+	})
+}
+
+// ownersOnly restricts changes of projects to their owners. Single projects
+// fail with FORBIDDEN, bulk changes skip the projects of others.
+func ownersOnly() privacy.MutationRule {
+	return privacy.ProjectMutationRuleFunc(func(ctx context.Context, m *gen.ProjectMutation) error {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return deny(auth.ErrNotLoggedIn)
+		}
+
+		id, ok := m.ID()
+		if !ok || !m.Op().Is(ent.OpUpdateOne|ent.OpDeleteOne) {
+			m.Where(project.OwnerID(viewer.ID))
+			return privacy.Allow
+		}
+
+		own, err := m.Client().Project.Query().Where(project.ID(id), project.OwnerID(viewer.ID)).Exist(ctx)
+		if err != nil || own {
+			return cmp.Or(err, privacy.Allow)
+		}
+		// Leave projects that don't exist to the mutation, which fails with NOT_FOUND
+		exists, err := m.Client().Project.Query().Where(project.ID(id)).Exist(ctx)
+		if err != nil || !exists {
+			return cmp.Or(err, privacy.Skip)
+		}
+		return deny(apperror.Forbidden("only the owner of project %s can change it", id))
+	})
+}
+
+// Annotations configures table name
+func (Project) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "projects"},
+	}
+}
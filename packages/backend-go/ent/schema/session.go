@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Session holds the schema definition for the Session entity. A session is
+// opened by logging in and keeps the user logged in until it expires or is
+// revoked. Only a hash of its refresh token is stored, its access tokens are
+// signed JWTs.
+type Session struct {
+	ent.Schema
+}
+
+// Mixin of the Session - adds the created_at and updated_at timestamps
+func (Session) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the Session
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.UUID("user_id", uuid.UUID{}).
+			Immutable(),
+		field.String("refresh_token_hash").
+			Unique().
+			Sensitive(),
+		field.Time("refresh_expires_at"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session - Session belongs to a user
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Field("user_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Annotations configures table name
+func (Session) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "sessions"},
+	}
+}
@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// SigningKey holds the schema definition for the SigningKey entity. The
+// newest key signs access tokens until the next one takes over, see
+// auth.Keys. Its ID is the "kid" of the tokens it signs.
+type SigningKey struct {
+	ent.Schema
+}
+
+// Fields of the SigningKey - keys are never changed once generated
+func (SigningKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		// An Ed25519 private key, encrypted by auth.Keys. The public half is
+		// published as JWKS.
+		field.Bytes("private_key").
+			Sensitive().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// Annotations configures table name
+func (SigningKey) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "signing_keys"},
+	}
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"regexp"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Tag holds the schema definition for the Tag entity.
+type Tag struct {
+	ent.Schema
+}
+
+// Mixin of the Tag - adds the created_at and updated_at timestamps and scopes
+// tags to their workspace
+func (Tag) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		WorkspaceMixin{},
+	}
+}
+
+// Fields of the Tag
+func (Tag) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.String("name").
+			MaxLen(50).
+			NotEmpty(),
+		field.String("color").
+			Match(regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)).
+			Default("#9E9E9E"),
+	}
+}
+
+// Edges of the Tag - Tag belongs to a workspace, tags label many todos, and
+// todos carry many tags
+func (Tag) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("tags").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
+		edge.To("todos", Todo.Type).
+			StorageKey(edge.Table("todo_tags"), edge.Columns("tag_id", "todo_id")),
+	}
+}
+
+// Indexes of the Tag - tag names are unique within a workspace
+func (Tag) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("workspace_id", "name").
+			Unique(),
+	}
+}
+
+// Annotations configures table name
+func (Tag) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tags"},
+	}
+}
@@ -1,6 +1,23 @@
 package schema
 
 import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"time"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	gen "backend-go/ent"
+	"backend-go/ent/assignment"
+	"backend-go/ent/hook"
+	"backend-go/ent/membership"
+	"backend-go/ent/predicate"
+	"backend-go/ent/privacy"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
@@ -14,6 +31,18 @@ type Todo struct {
 	ent.Schema
 }
 
+// Mixin of the Todo - adds the created_at and updated_at timestamps, soft
+// deletion, a version for optimistic concurrency control and scopes todos to
+// their workspace
+func (Todo) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		SoftDeleteMixin{},
+		VersionMixin{},
+		WorkspaceMixin{},
+	}
+}
+
 // Fields of the Todo - matches TypeScript Drizzle schema exactly
 func (Todo) Fields() []ent.Field {
 	return []ent.Field{
@@ -26,20 +55,303 @@ func (Todo) Fields() []ent.Field {
 			NotEmpty(),
 		field.Bool("completed").
 			Default(false),
+		// The first assignee, kept for clients from before todos had several,
+		// see syncFirstAssignee
 		field.UUID("user_id", uuid.UUID{}).
 			Optional().
 			Nillable(),
+		field.Time("due_at").
+			Optional().
+			Nillable(),
+		field.Enum("priority").
+			Values("LOW", "MEDIUM", "HIGH", "URGENT").
+			Default("MEDIUM"),
+		field.Time("completed_at").
+			Optional().
+			Nillable(),
+		// Stamped when the todo was announced as due, see package remind, and
+		// cleared when the due date changes
+		field.Time("reminded_at").
+			Optional().
+			Nillable(),
+		field.UUID("parent_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("project_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		// Stamped with the user creating the todo, see stampCreator
+		field.UUID("creator_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Immutable(),
 	}
 }
 
-// Edges of the Todo - Todo belongs to a user
+// Edges of the Todo - Todo belongs to a workspace and a project, was created
+// by a user, is assigned to and watched by users, carries tags, is discussed
+// in comments, which go with it when it is purged, and breaks down into
+// subtasks
 func (Todo) Edges() []ent.Edge {
 	return []ent.Edge{
+		edge.From("workspace", Workspace.Type).
+			Ref("todos").
+			Field("workspace_id").
+			Unique().
+			Required().
+			Immutable(),
 		edge.From("user", User.Type).
 			Ref("todos").
 			Field("user_id").
 			Unique(),
+		edge.From("creator", User.Type).
+			Ref("created_todos").
+			Field("creator_id").
+			Unique().
+			Immutable(),
+		edge.To("assignees", User.Type).
+			Through("assignments", Assignment.Type),
+		edge.To("watchers", User.Type).
+			StorageKey(edge.Table("todo_watchers"), edge.Columns("todo_id", "user_id")),
+		edge.From("project", Project.Type).
+			Ref("todos").
+			Field("project_id").
+			Unique(),
+		edge.From("tags", Tag.Type).
+			Ref("todos"),
+		edge.To("comments", Comment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("subtasks", Todo.Type).
+			From("parent").
+			Field("parent_id").
+			Unique(),
+	}
+}
+
+// Hooks of the Todo - stamp the creator, keep completed_at in line with
+// completed, user_id on the first assignee and reminders on the due date
+func (Todo) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(stampCreator, ent.OpCreate),
+		hook.On(trackCompletion, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(rearmReminder, ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(syncFirstAssignee, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+	}
+}
+
+// Policy of the Todo - members of the workspace see its todos, but only
+// change the ones they created or are assigned to, unless they are admins
+func (Todo) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleVIEWER),
+			privacy.AlwaysAllowRule(),
+		},
+		Mutation: privacy.MutationPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleMEMBER),
+			allowIfRole(membership.RoleADMIN),
+			privacy.OnMutationOperation(
+				editorsOnly(),
+				ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+			),
+			privacy.AlwaysAllowRule(),
+		},
+	}
+}
+
+// editorsOnly restricts changes of todos to the users who may edit them, see
+// editableBy. Single todos fail with FORBIDDEN, bulk changes skip the todos
+// the user can't edit. Users may watch and unwatch any todo themselves.
+func editorsOnly() privacy.MutationRule {
+	return privacy.TodoMutationRuleFunc(func(ctx context.Context, m *gen.TodoMutation) error {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return deny(auth.ErrNotLoggedIn)
+		}
+
+		id, ok := m.ID()
+		if !ok || !m.Op().Is(ent.OpUpdateOne|ent.OpDeleteOne) {
+			m.Where(editableBy(viewer.ID))
+			return privacy.Allow
+		}
+		if watchingOnly(m, viewer.ID) {
+			return privacy.Allow
+		}
+
+		editable, err := m.Client().Todo.Query().Where(todo.ID(id), editableBy(viewer.ID)).Exist(ctx)
+		if err != nil || editable {
+			return cmp.Or(err, privacy.Allow)
+		}
+		// Leave todos that don't exist to the mutation, which fails with NOT_FOUND
+		exists, err := m.Client().Todo.Query().Where(todo.ID(id)).Exist(ctx)
+		if err != nil || !exists {
+			return cmp.Or(err, privacy.Skip)
+		}
+		return deny(apperror.Forbidden("only the creator and the assignees of todo %s can change it", id))
+	})
+}
+
+// editableBy matches the todos the user with id created or is assigned to
+func editableBy(id uuid.UUID) predicate.Todo {
+	return todo.Or(
+		todo.CreatorID(id),
+		todo.UserID(id),
+		todo.HasAssigneesWith(user.ID(id)),
+	)
+}
+
+// watchingOnly reports whether m does nothing but make the user with id
+// watch or unwatch a todo
+func watchingOnly(m *gen.TodoMutation, id uuid.UUID) bool {
+	watchers := append(m.WatchersIDs(), m.RemovedWatchersIDs()...)
+	if !m.Op().Is(ent.OpUpdateOne) || len(watchers) == 0 || len(m.ClearedEdges()) > 0 {
+		return false
+	}
+	for _, f := range m.Fields() {
+		if f != todo.FieldUpdatedAt {
+			return false
+		}
+	}
+	for _, e := range append(m.AddedEdges(), m.RemovedEdges()...) {
+		if e != todo.EdgeWatchers {
+			return false
+		}
+	}
+	for _, watcher := range watchers {
+		if watcher != id {
+			return false
+		}
+	}
+	return true
+}
+
+// stampCreator sets the creator of a todo to the logged in user, unless it
+// was set explicitly. Todos created without one, like those of System
+// contexts, have no creator.
+func stampCreator(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (ent.Value, error) {
+		if _, ok := m.CreatorID(); !ok {
+			if viewer, ok := auth.FromContext(ctx); ok {
+				m.SetCreatorID(viewer.ID)
+			}
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// trackCompletion stamps completed_at when a todo is completed and clears it
+// when the todo is reopened. Single todos are only stamped when completed
+// actually flips; bulk updates stamp every todo they match.
+func trackCompletion(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (ent.Value, error) {
+		completed, ok := m.Completed()
+		if !ok {
+			return next.Mutate(ctx, m)
+		}
+		if m.Op().Is(ent.OpUpdateOne) {
+			old, err := m.OldCompleted(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if old == completed {
+				return next.Mutate(ctx, m)
+			}
+		}
+
+		if completed {
+			m.SetCompletedAt(time.Now())
+		} else {
+			m.ClearCompletedAt()
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// rearmReminder clears reminded_at when the due date of a todo changes, so it
+// is announced again for the new one. Single todos are only rearmed when the
+// due date actually moves; bulk updates rearm every todo they match.
+func rearmReminder(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (ent.Value, error) {
+		dueAt, ok := m.DueAt()
+		if !ok && !m.DueAtCleared() {
+			return next.Mutate(ctx, m)
+		}
+		if m.Op().Is(ent.OpUpdateOne) {
+			old, err := m.OldDueAt(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if (old == nil && !ok) || (old != nil && ok && old.Equal(dueAt)) {
+				return next.Mutate(ctx, m)
+			}
+		}
+
+		m.ClearRemindedAt()
+		return next.Mutate(ctx, m)
+	})
+}
+
+// syncFirstAssignee keeps user_id on the first assignee of a todo. Changing the
+// assignees moves user_id along, while setting user_id, as clients from before
+// todos had several assignees do, makes that user the only one. Bulk updates
+// can't change either, the first assignee differs from todo to todo.
+func syncFirstAssignee(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (ent.Value, error) {
+		assigneesChanged := len(m.AssigneesIDs()) > 0 || len(m.RemovedAssigneesIDs()) > 0 || m.AssigneesCleared()
+		userID, userSet := m.UserID()
+		if m.Op().Is(ent.OpUpdate) {
+			if assigneesChanged || userSet || m.UserIDCleared() {
+				return nil, errors.New("assignees can only be changed one todo at a time")
+			}
+			return next.Mutate(ctx, m)
+		}
+
+		switch {
+		case assigneesChanged:
+			first, err := firstAssignee(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			if first == nil {
+				m.ClearUserID()
+			} else {
+				m.SetUserID(*first)
+			}
+		case userSet:
+			m.ClearAssignees()
+			m.AddAssigneeIDs(userID)
+		case m.UserIDCleared():
+			m.ClearAssignees()
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// firstAssignee returns the user m leaves first in line: the earliest
+// remaining assignee, or else the lowest ID among the ones m adds
+func firstAssignee(ctx context.Context, m *gen.TodoMutation) (*uuid.UUID, error) {
+	if id, ok := m.ID(); ok && m.Op().Is(ent.OpUpdateOne) && !m.AssigneesCleared() {
+		first, err := m.Client().Assignment.Query().
+			Where(assignment.TodoID(id), assignment.UserIDNotIn(m.RemovedAssigneesIDs()...)).
+			Order(assignment.ByCreatedAt(), assignment.ByUserID()).
+			First(ctx)
+		if err == nil {
+			return &first.UserID, nil
+		}
+		if !gen.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	var first *uuid.UUID
+	for _, id := range m.AssigneesIDs() {
+		if first == nil || bytes.Compare(id[:], first[:]) < 0 {
+			first = &id
+		}
 	}
+	return first, nil
 }
 
 // Annotations configures Ent to use existing PostgreSQL table
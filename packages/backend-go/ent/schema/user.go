@@ -1,11 +1,25 @@
 package schema
 
 import (
+	"context"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	gen "backend-go/ent"
+	"backend-go/ent/hook"
+	"backend-go/ent/intercept"
+	"backend-go/ent/membership"
+	"backend-go/ent/privacy"
+	"backend-go/ent/user"
+	"backend-go/tenant"
+
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/google/uuid"
 )
 
@@ -14,6 +28,16 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User - adds the created_at and updated_at timestamps, soft
+// deletion and a version for optimistic concurrency control
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		SoftDeleteMixin{},
+		VersionMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
@@ -22,20 +46,193 @@ func (User) Fields() []ent.Field {
 			Unique().
 			Immutable(),
 		field.String("email").
-			Unique().
 			NotEmpty(),
 		field.String("name").
 			NotEmpty(),
+		// An argon2id hash in PHC string format, see auth.HashPassword. Users
+		// created by admins have none until they set one.
+		field.String("password_hash").
+			Optional().
+			Nillable().
+			Sensitive(),
+		// A hash of the one-time token users without a password set up their
+		// first one with, see auth.IssuePasswordSetup
+		field.String("password_setup_hash").
+			Optional().
+			Nillable().
+			Sensitive(),
+		field.Time("password_setup_expires_at").
+			Optional().
+			Nillable(),
 	}
 }
 
-// Edges of the User - User has many todos
+// Edges of the User - User is the first assignee of todos, creates, is
+// assigned to and watches todos, writes comments, owns projects, joins
+// workspaces, is invited to them and logs in through sessions
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("todos", Todo.Type),
+		edge.To("created_todos", Todo.Type),
+		edge.From("assigned_todos", Todo.Type).
+			Ref("assignees").
+			Through("assignments", Assignment.Type),
+		edge.From("watched_todos", Todo.Type).
+			Ref("watchers"),
+		edge.To("comments", Comment.Type),
+		edge.To("projects", Project.Type),
+		edge.To("memberships", Membership.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("invitations", Invitation.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("sessions", Session.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the User - emails are unique among users that aren't deleted,
+// so a deleted user's email can sign up again
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("email").
+			Unique().
+			Annotations(entsql.IndexWhere("deleted_at IS NULL")),
+	}
+}
+
+// Interceptors of the User - users are shared by workspaces, each of which
+// only sees its members
+func (User) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			return membersOnly(ctx, q)
+		}),
+	}
+}
+
+// Hooks of the User - emails are saved in lower case, users join the
+// workspace they are created in, and only members can be changed
+func (User) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(normalizeEmail, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(joinWorkspace, ent.OpCreate),
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (ent.Value, error) {
+					if err := membersOnly(ctx, m); err != nil {
+						return nil, err
+					}
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+		),
 	}
 }
 
+// Policy of the User - members of a workspace see its members. A user is one
+// identity shared by every workspace they belong to, so only they change it;
+// admins manage memberships instead. Admins still create users, which join
+// their workspace, and restore deleted members.
+func (User) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleVIEWER),
+			privacy.AlwaysAllowRule(),
+		},
+		Mutation: privacy.MutationPolicy{
+			allowIfSystem(),
+			requireRole(membership.RoleVIEWER),
+			privacy.OnMutationOperation(allowIfRole(membership.RoleADMIN), ent.OpCreate),
+			privacy.UserMutationRuleFunc(func(ctx context.Context, m *gen.UserMutation) error {
+				if auth.HasRole(ctx, membership.RoleADMIN) && restoresOnly(m) {
+					return privacy.Allow
+				}
+				return privacy.Skip
+			}),
+			privacy.UserMutationRuleFunc(func(ctx context.Context, m *gen.UserMutation) error {
+				ids, err := m.IDs(ctx)
+				if err == nil && m.Op().Is(ent.OpUpdateOne|ent.OpDeleteOne) {
+					// Users of other workspaces stay not found rather than
+					// forbidden, see membersOnly
+					ids, err = m.Client().User.Query().Where(user.IDIn(ids...)).IDs(ctx)
+				}
+				if err != nil {
+					return err
+				}
+				viewer, ok := auth.FromContext(ctx)
+				for _, id := range ids {
+					if !ok || id != viewer.ID {
+						return deny(apperror.Forbidden("users can only change themselves"))
+					}
+				}
+				return privacy.Allow
+			}),
+		},
+	}
+}
+
+// restoresOnly reports whether m brings back a deleted user without changing
+// anything else about them
+func restoresOnly(m *gen.UserMutation) bool {
+	if !m.Op().Is(ent.OpUpdateOne) || !m.DeletedAtCleared() {
+		return false
+	}
+	_, email := m.Email()
+	_, name := m.Name()
+	_, password := m.PasswordHash()
+	return !email && !name && !password && !m.PasswordHashCleared()
+}
+
+// membersOnly restricts a user query or mutation to the members of the
+// workspace of ctx, see WorkspaceMixin.P
+func membersOnly(ctx context.Context, w interface{ WhereP(...func(*sql.Selector)) }) error {
+	if tenant.IsSystem(ctx) {
+		return nil
+	}
+	workspaceID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoWorkspace
+	}
+	w.WhereP(user.HasMembershipsWith(membership.WorkspaceID(workspaceID)))
+	return nil
+}
+
+// normalizeEmail saves emails the way logins look them up, see
+// auth.NormalizeEmail
+func normalizeEmail(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (ent.Value, error) {
+		if email, ok := m.Email(); ok {
+			m.SetEmail(auth.NormalizeEmail(email))
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// joinWorkspace makes a new user a member of the workspace of ctx. Users
+// created in a System context without a workspace join none.
+func joinWorkspace(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (ent.Value, error) {
+		workspaceID, ok := tenant.FromContext(ctx)
+		if !ok && !tenant.IsSystem(ctx) {
+			return nil, tenant.ErrNoWorkspace
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil || !ok {
+			return v, err
+		}
+		if err := m.Client().Membership.Create().
+			SetWorkspaceID(workspaceID).
+			SetUserID(v.(*gen.User).ID).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
 // Annotations configures table name
 func (User) Annotations() []schema.Annotation {
 	return []schema.Annotation{
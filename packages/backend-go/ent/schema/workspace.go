@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Workspace holds the schema definition for the Workspace entity. Workspaces
+// are the tenants of the API: every other entity but users belongs to one.
+type Workspace struct {
+	ent.Schema
+}
+
+// Mixin of the Workspace - adds the created_at and updated_at timestamps
+func (Workspace) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the Workspace
+func (Workspace) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Unique().
+			Immutable(),
+		field.String("name").
+			MaxLen(255).
+			NotEmpty(),
+	}
+}
+
+// Edges of the Workspace - Workspace has members, invites users and owns the rows of every
+// workspace-scoped entity
+func (Workspace) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("memberships", Membership.Type),
+		edge.To("invitations", Invitation.Type),
+		edge.To("todos", Todo.Type),
+		edge.To("tags", Tag.Type),
+		edge.To("projects", Project.Type),
+		edge.To("comments", Comment.Type),
+		edge.To("comment_revisions", CommentRevision.Type),
+	}
+}
+
+// Annotations configures table name
+func (Workspace) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "workspaces"},
+	}
+}
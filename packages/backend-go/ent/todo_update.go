@@ -3,12 +3,16 @@
 package ent
 
 import (
+	"backend-go/ent/comment"
 	"backend-go/ent/predicate"
+	"backend-go/ent/project"
+	"backend-go/ent/tag"
 	"backend-go/ent/todo"
 	"backend-go/ent/user"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -29,6 +33,53 @@ func (_u *TodoUpdate) Where(ps ...predicate.Todo) *TodoUpdate {
 	return _u
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TodoUpdate) SetUpdatedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *TodoUpdate) SetDeletedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableDeletedAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *TodoUpdate) ClearDeletedAt() *TodoUpdate {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *TodoUpdate) SetVersion(v int) *TodoUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableVersion(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *TodoUpdate) AddVersion(v int) *TodoUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
 // SetTitle sets the "title" field.
 func (_u *TodoUpdate) SetTitle(v string) *TodoUpdate {
 	_u.mutation.SetTitle(v)
@@ -77,11 +128,210 @@ func (_u *TodoUpdate) ClearUserID() *TodoUpdate {
 	return _u
 }
 
+// SetDueAt sets the "due_at" field.
+func (_u *TodoUpdate) SetDueAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableDueAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (_u *TodoUpdate) ClearDueAt() *TodoUpdate {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetPriority sets the "priority" field.
+func (_u *TodoUpdate) SetPriority(v todo.Priority) *TodoUpdate {
+	_u.mutation.SetPriority(v)
+	return _u
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillablePriority(v *todo.Priority) *TodoUpdate {
+	if v != nil {
+		_u.SetPriority(*v)
+	}
+	return _u
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (_u *TodoUpdate) SetCompletedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetCompletedAt(v)
+	return _u
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableCompletedAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetCompletedAt(*v)
+	}
+	return _u
+}
+
+// ClearCompletedAt clears the value of the "completed_at" field.
+func (_u *TodoUpdate) ClearCompletedAt() *TodoUpdate {
+	_u.mutation.ClearCompletedAt()
+	return _u
+}
+
+// SetRemindedAt sets the "reminded_at" field.
+func (_u *TodoUpdate) SetRemindedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetRemindedAt(v)
+	return _u
+}
+
+// SetNillableRemindedAt sets the "reminded_at" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableRemindedAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetRemindedAt(*v)
+	}
+	return _u
+}
+
+// ClearRemindedAt clears the value of the "reminded_at" field.
+func (_u *TodoUpdate) ClearRemindedAt() *TodoUpdate {
+	_u.mutation.ClearRemindedAt()
+	return _u
+}
+
+// SetParentID sets the "parent_id" field.
+func (_u *TodoUpdate) SetParentID(v uuid.UUID) *TodoUpdate {
+	_u.mutation.SetParentID(v)
+	return _u
+}
+
+// SetNillableParentID sets the "parent_id" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableParentID(v *uuid.UUID) *TodoUpdate {
+	if v != nil {
+		_u.SetParentID(*v)
+	}
+	return _u
+}
+
+// ClearParentID clears the value of the "parent_id" field.
+func (_u *TodoUpdate) ClearParentID() *TodoUpdate {
+	_u.mutation.ClearParentID()
+	return _u
+}
+
+// SetProjectID sets the "project_id" field.
+func (_u *TodoUpdate) SetProjectID(v uuid.UUID) *TodoUpdate {
+	_u.mutation.SetProjectID(v)
+	return _u
+}
+
+// SetNillableProjectID sets the "project_id" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableProjectID(v *uuid.UUID) *TodoUpdate {
+	if v != nil {
+		_u.SetProjectID(*v)
+	}
+	return _u
+}
+
+// ClearProjectID clears the value of the "project_id" field.
+func (_u *TodoUpdate) ClearProjectID() *TodoUpdate {
+	_u.mutation.ClearProjectID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *TodoUpdate) SetUser(v *User) *TodoUpdate {
 	return _u.SetUserID(v.ID)
 }
 
+// AddAssigneeIDs adds the "assignees" edge to the User entity by IDs.
+func (_u *TodoUpdate) AddAssigneeIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.AddAssigneeIDs(ids...)
+	return _u
+}
+
+// AddAssignees adds the "assignees" edges to the User entity.
+func (_u *TodoUpdate) AddAssignees(v ...*User) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAssigneeIDs(ids...)
+}
+
+// AddWatcherIDs adds the "watchers" edge to the User entity by IDs.
+func (_u *TodoUpdate) AddWatcherIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.AddWatcherIDs(ids...)
+	return _u
+}
+
+// AddWatchers adds the "watchers" edges to the User entity.
+func (_u *TodoUpdate) AddWatchers(v ...*User) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddWatcherIDs(ids...)
+}
+
+// SetProject sets the "project" edge to the Project entity.
+func (_u *TodoUpdate) SetProject(v *Project) *TodoUpdate {
+	return _u.SetProjectID(v.ID)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *TodoUpdate) AddTagIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *TodoUpdate) AddTags(v ...*Tag) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// AddCommentIDs adds the "comments" edge to the Comment entity by IDs.
+func (_u *TodoUpdate) AddCommentIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.AddCommentIDs(ids...)
+	return _u
+}
+
+// AddComments adds the "comments" edges to the Comment entity.
+func (_u *TodoUpdate) AddComments(v ...*Comment) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCommentIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Todo entity.
+func (_u *TodoUpdate) SetParent(v *Todo) *TodoUpdate {
+	return _u.SetParentID(v.ID)
+}
+
+// AddSubtaskIDs adds the "subtasks" edge to the Todo entity by IDs.
+func (_u *TodoUpdate) AddSubtaskIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.AddSubtaskIDs(ids...)
+	return _u
+}
+
+// AddSubtasks adds the "subtasks" edges to the Todo entity.
+func (_u *TodoUpdate) AddSubtasks(v ...*Todo) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSubtaskIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
 func (_u *TodoUpdate) Mutation() *TodoMutation {
 	return _u.mutation
@@ -93,8 +343,128 @@ func (_u *TodoUpdate) ClearUser() *TodoUpdate {
 	return _u
 }
 
+// ClearAssignees clears all "assignees" edges to the User entity.
+func (_u *TodoUpdate) ClearAssignees() *TodoUpdate {
+	_u.mutation.ClearAssignees()
+	return _u
+}
+
+// RemoveAssigneeIDs removes the "assignees" edge to User entities by IDs.
+func (_u *TodoUpdate) RemoveAssigneeIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.RemoveAssigneeIDs(ids...)
+	return _u
+}
+
+// RemoveAssignees removes "assignees" edges to User entities.
+func (_u *TodoUpdate) RemoveAssignees(v ...*User) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveAssigneeIDs(ids...)
+}
+
+// ClearWatchers clears all "watchers" edges to the User entity.
+func (_u *TodoUpdate) ClearWatchers() *TodoUpdate {
+	_u.mutation.ClearWatchers()
+	return _u
+}
+
+// RemoveWatcherIDs removes the "watchers" edge to User entities by IDs.
+func (_u *TodoUpdate) RemoveWatcherIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.RemoveWatcherIDs(ids...)
+	return _u
+}
+
+// RemoveWatchers removes "watchers" edges to User entities.
+func (_u *TodoUpdate) RemoveWatchers(v ...*User) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveWatcherIDs(ids...)
+}
+
+// ClearProject clears the "project" edge to the Project entity.
+func (_u *TodoUpdate) ClearProject() *TodoUpdate {
+	_u.mutation.ClearProject()
+	return _u
+}
+
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *TodoUpdate) ClearTags() *TodoUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *TodoUpdate) RemoveTagIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
+}
+
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *TodoUpdate) RemoveTags(v ...*Tag) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTagIDs(ids...)
+}
+
+// ClearComments clears all "comments" edges to the Comment entity.
+func (_u *TodoUpdate) ClearComments() *TodoUpdate {
+	_u.mutation.ClearComments()
+	return _u
+}
+
+// RemoveCommentIDs removes the "comments" edge to Comment entities by IDs.
+func (_u *TodoUpdate) RemoveCommentIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.RemoveCommentIDs(ids...)
+	return _u
+}
+
+// RemoveComments removes "comments" edges to Comment entities.
+func (_u *TodoUpdate) RemoveComments(v ...*Comment) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCommentIDs(ids...)
+}
+
+// ClearParent clears the "parent" edge to the Todo entity.
+func (_u *TodoUpdate) ClearParent() *TodoUpdate {
+	_u.mutation.ClearParent()
+	return _u
+}
+
+// ClearSubtasks clears all "subtasks" edges to the Todo entity.
+func (_u *TodoUpdate) ClearSubtasks() *TodoUpdate {
+	_u.mutation.ClearSubtasks()
+	return _u
+}
+
+// RemoveSubtaskIDs removes the "subtasks" edge to Todo entities by IDs.
+func (_u *TodoUpdate) RemoveSubtaskIDs(ids ...uuid.UUID) *TodoUpdate {
+	_u.mutation.RemoveSubtaskIDs(ids...)
+	return _u
+}
+
+// RemoveSubtasks removes "subtasks" edges to Todo entities.
+func (_u *TodoUpdate) RemoveSubtasks(v ...*Todo) *TodoUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSubtaskIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *TodoUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
 }
 
@@ -120,6 +490,18 @@ func (_u *TodoUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (_u *TodoUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if todo.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := todo.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (_u *TodoUpdate) check() error {
 	if v, ok := _u.mutation.Title(); ok {
@@ -127,6 +509,14 @@ func (_u *TodoUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Priority(); ok {
+		if err := todo.PriorityValidator(v); err != nil {
+			return &ValidationError{Name: "priority", err: fmt.Errorf(`ent: validator failed for field "Todo.priority": %w`, err)}
+		}
+	}
+	if _u.mutation.WorkspaceCleared() && len(_u.mutation.WorkspaceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Todo.workspace"`)
+	}
 	return nil
 }
 
@@ -142,12 +532,48 @@ func (_u *TodoUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(todo.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(todo.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.Title(); ok {
 		_spec.SetField(todo.FieldTitle, field.TypeString, value)
 	}
 	if value, ok := _u.mutation.Completed(); ok {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Priority(); ok {
+		_spec.SetField(todo.FieldPriority, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.CompletedAt(); ok {
+		_spec.SetField(todo.FieldCompletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CompletedAtCleared() {
+		_spec.ClearField(todo.FieldCompletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RemindedAt(); ok {
+		_spec.SetField(todo.FieldRemindedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RemindedAtCleared() {
+		_spec.ClearField(todo.FieldRemindedAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -177,90 +603,748 @@ func (_u *TodoUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{todo.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
+	if _u.mutation.AssigneesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
 		}
-		return 0, err
-	}
-	_u.mutation.done = true
-	return _node, nil
-}
-
-// TodoUpdateOne is the builder for updating a single Todo entity.
-type TodoUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *TodoMutation
-}
-
-// SetTitle sets the "title" field.
-func (_u *TodoUpdateOne) SetTitle(v string) *TodoUpdateOne {
-	_u.mutation.SetTitle(v)
-	return _u
-}
-
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableTitle(v *string) *TodoUpdateOne {
-	if v != nil {
-		_u.SetTitle(*v)
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
-// SetCompleted sets the "completed" field.
-func (_u *TodoUpdateOne) SetCompleted(v bool) *TodoUpdateOne {
-	_u.mutation.SetCompleted(v)
-	return _u
-}
-
-// SetNillableCompleted sets the "completed" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableCompleted(v *bool) *TodoUpdateOne {
-	if v != nil {
-		_u.SetCompleted(*v)
+	if nodes := _u.mutation.RemovedAssigneesIDs(); len(nodes) > 0 && !_u.mutation.AssigneesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	return _u
-}
-
+	if nodes := _u.mutation.AssigneesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.WatchersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedWatchersIDs(); len(nodes) > 0 && !_u.mutation.WatchersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.WatchersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ProjectCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ProjectIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCommentsIDs(); len(nodes) > 0 && !_u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CommentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SubtasksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSubtasksIDs(); len(nodes) > 0 && !_u.mutation.SubtasksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SubtasksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{todo.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TodoUpdateOne is the builder for updating a single Todo entity.
+type TodoUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TodoMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TodoUpdateOne) SetUpdatedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *TodoUpdateOne) SetDeletedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableDeletedAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *TodoUpdateOne) ClearDeletedAt() *TodoUpdateOne {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *TodoUpdateOne) SetVersion(v int) *TodoUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableVersion(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *TodoUpdateOne) AddVersion(v int) *TodoUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *TodoUpdateOne) SetTitle(v string) *TodoUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableTitle(v *string) *TodoUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetCompleted sets the "completed" field.
+func (_u *TodoUpdateOne) SetCompleted(v bool) *TodoUpdateOne {
+	_u.mutation.SetCompleted(v)
+	return _u
+}
+
+// SetNillableCompleted sets the "completed" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableCompleted(v *bool) *TodoUpdateOne {
+	if v != nil {
+		_u.SetCompleted(*v)
+	}
+	return _u
+}
+
 // SetUserID sets the "user_id" field.
 func (_u *TodoUpdateOne) SetUserID(v uuid.UUID) *TodoUpdateOne {
 	_u.mutation.SetUserID(v)
 	return _u
 }
 
-// SetNillableUserID sets the "user_id" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableUserID(v *uuid.UUID) *TodoUpdateOne {
-	if v != nil {
-		_u.SetUserID(*v)
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableUserID(v *uuid.UUID) *TodoUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// ClearUserID clears the value of the "user_id" field.
+func (_u *TodoUpdateOne) ClearUserID() *TodoUpdateOne {
+	_u.mutation.ClearUserID()
+	return _u
+}
+
+// SetDueAt sets the "due_at" field.
+func (_u *TodoUpdateOne) SetDueAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableDueAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (_u *TodoUpdateOne) ClearDueAt() *TodoUpdateOne {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetPriority sets the "priority" field.
+func (_u *TodoUpdateOne) SetPriority(v todo.Priority) *TodoUpdateOne {
+	_u.mutation.SetPriority(v)
+	return _u
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillablePriority(v *todo.Priority) *TodoUpdateOne {
+	if v != nil {
+		_u.SetPriority(*v)
+	}
+	return _u
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (_u *TodoUpdateOne) SetCompletedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetCompletedAt(v)
+	return _u
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableCompletedAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetCompletedAt(*v)
+	}
+	return _u
+}
+
+// ClearCompletedAt clears the value of the "completed_at" field.
+func (_u *TodoUpdateOne) ClearCompletedAt() *TodoUpdateOne {
+	_u.mutation.ClearCompletedAt()
+	return _u
+}
+
+// SetRemindedAt sets the "reminded_at" field.
+func (_u *TodoUpdateOne) SetRemindedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetRemindedAt(v)
+	return _u
+}
+
+// SetNillableRemindedAt sets the "reminded_at" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableRemindedAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetRemindedAt(*v)
+	}
+	return _u
+}
+
+// ClearRemindedAt clears the value of the "reminded_at" field.
+func (_u *TodoUpdateOne) ClearRemindedAt() *TodoUpdateOne {
+	_u.mutation.ClearRemindedAt()
+	return _u
+}
+
+// SetParentID sets the "parent_id" field.
+func (_u *TodoUpdateOne) SetParentID(v uuid.UUID) *TodoUpdateOne {
+	_u.mutation.SetParentID(v)
+	return _u
+}
+
+// SetNillableParentID sets the "parent_id" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableParentID(v *uuid.UUID) *TodoUpdateOne {
+	if v != nil {
+		_u.SetParentID(*v)
+	}
+	return _u
+}
+
+// ClearParentID clears the value of the "parent_id" field.
+func (_u *TodoUpdateOne) ClearParentID() *TodoUpdateOne {
+	_u.mutation.ClearParentID()
+	return _u
+}
+
+// SetProjectID sets the "project_id" field.
+func (_u *TodoUpdateOne) SetProjectID(v uuid.UUID) *TodoUpdateOne {
+	_u.mutation.SetProjectID(v)
+	return _u
+}
+
+// SetNillableProjectID sets the "project_id" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableProjectID(v *uuid.UUID) *TodoUpdateOne {
+	if v != nil {
+		_u.SetProjectID(*v)
+	}
+	return _u
+}
+
+// ClearProjectID clears the value of the "project_id" field.
+func (_u *TodoUpdateOne) ClearProjectID() *TodoUpdateOne {
+	_u.mutation.ClearProjectID()
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *TodoUpdateOne) SetUser(v *User) *TodoUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// AddAssigneeIDs adds the "assignees" edge to the User entity by IDs.
+func (_u *TodoUpdateOne) AddAssigneeIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.AddAssigneeIDs(ids...)
+	return _u
+}
+
+// AddAssignees adds the "assignees" edges to the User entity.
+func (_u *TodoUpdateOne) AddAssignees(v ...*User) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAssigneeIDs(ids...)
+}
+
+// AddWatcherIDs adds the "watchers" edge to the User entity by IDs.
+func (_u *TodoUpdateOne) AddWatcherIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.AddWatcherIDs(ids...)
+	return _u
+}
+
+// AddWatchers adds the "watchers" edges to the User entity.
+func (_u *TodoUpdateOne) AddWatchers(v ...*User) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddWatcherIDs(ids...)
+}
+
+// SetProject sets the "project" edge to the Project entity.
+func (_u *TodoUpdateOne) SetProject(v *Project) *TodoUpdateOne {
+	return _u.SetProjectID(v.ID)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *TodoUpdateOne) AddTagIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *TodoUpdateOne) AddTags(v ...*Tag) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// AddCommentIDs adds the "comments" edge to the Comment entity by IDs.
+func (_u *TodoUpdateOne) AddCommentIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.AddCommentIDs(ids...)
+	return _u
+}
+
+// AddComments adds the "comments" edges to the Comment entity.
+func (_u *TodoUpdateOne) AddComments(v ...*Comment) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCommentIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Todo entity.
+func (_u *TodoUpdateOne) SetParent(v *Todo) *TodoUpdateOne {
+	return _u.SetParentID(v.ID)
+}
+
+// AddSubtaskIDs adds the "subtasks" edge to the Todo entity by IDs.
+func (_u *TodoUpdateOne) AddSubtaskIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.AddSubtaskIDs(ids...)
+	return _u
+}
+
+// AddSubtasks adds the "subtasks" edges to the Todo entity.
+func (_u *TodoUpdateOne) AddSubtasks(v ...*Todo) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSubtaskIDs(ids...)
+}
+
+// Mutation returns the TodoMutation object of the builder.
+func (_u *TodoUpdateOne) Mutation() *TodoMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *TodoUpdateOne) ClearUser() *TodoUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// ClearAssignees clears all "assignees" edges to the User entity.
+func (_u *TodoUpdateOne) ClearAssignees() *TodoUpdateOne {
+	_u.mutation.ClearAssignees()
+	return _u
+}
+
+// RemoveAssigneeIDs removes the "assignees" edge to User entities by IDs.
+func (_u *TodoUpdateOne) RemoveAssigneeIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.RemoveAssigneeIDs(ids...)
+	return _u
+}
+
+// RemoveAssignees removes "assignees" edges to User entities.
+func (_u *TodoUpdateOne) RemoveAssignees(v ...*User) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveAssigneeIDs(ids...)
+}
+
+// ClearWatchers clears all "watchers" edges to the User entity.
+func (_u *TodoUpdateOne) ClearWatchers() *TodoUpdateOne {
+	_u.mutation.ClearWatchers()
 	return _u
 }
 
-// ClearUserID clears the value of the "user_id" field.
-func (_u *TodoUpdateOne) ClearUserID() *TodoUpdateOne {
-	_u.mutation.ClearUserID()
+// RemoveWatcherIDs removes the "watchers" edge to User entities by IDs.
+func (_u *TodoUpdateOne) RemoveWatcherIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.RemoveWatcherIDs(ids...)
 	return _u
 }
 
-// SetUser sets the "user" edge to the User entity.
-func (_u *TodoUpdateOne) SetUser(v *User) *TodoUpdateOne {
-	return _u.SetUserID(v.ID)
+// RemoveWatchers removes "watchers" edges to User entities.
+func (_u *TodoUpdateOne) RemoveWatchers(v ...*User) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveWatcherIDs(ids...)
 }
 
-// Mutation returns the TodoMutation object of the builder.
-func (_u *TodoUpdateOne) Mutation() *TodoMutation {
-	return _u.mutation
+// ClearProject clears the "project" edge to the Project entity.
+func (_u *TodoUpdateOne) ClearProject() *TodoUpdateOne {
+	_u.mutation.ClearProject()
+	return _u
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (_u *TodoUpdateOne) ClearUser() *TodoUpdateOne {
-	_u.mutation.ClearUser()
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *TodoUpdateOne) ClearTags() *TodoUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *TodoUpdateOne) RemoveTagIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
+}
+
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *TodoUpdateOne) RemoveTags(v ...*Tag) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTagIDs(ids...)
+}
+
+// ClearComments clears all "comments" edges to the Comment entity.
+func (_u *TodoUpdateOne) ClearComments() *TodoUpdateOne {
+	_u.mutation.ClearComments()
+	return _u
+}
+
+// RemoveCommentIDs removes the "comments" edge to Comment entities by IDs.
+func (_u *TodoUpdateOne) RemoveCommentIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.RemoveCommentIDs(ids...)
+	return _u
+}
+
+// RemoveComments removes "comments" edges to Comment entities.
+func (_u *TodoUpdateOne) RemoveComments(v ...*Comment) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCommentIDs(ids...)
+}
+
+// ClearParent clears the "parent" edge to the Todo entity.
+func (_u *TodoUpdateOne) ClearParent() *TodoUpdateOne {
+	_u.mutation.ClearParent()
+	return _u
+}
+
+// ClearSubtasks clears all "subtasks" edges to the Todo entity.
+func (_u *TodoUpdateOne) ClearSubtasks() *TodoUpdateOne {
+	_u.mutation.ClearSubtasks()
+	return _u
+}
+
+// RemoveSubtaskIDs removes the "subtasks" edge to Todo entities by IDs.
+func (_u *TodoUpdateOne) RemoveSubtaskIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	_u.mutation.RemoveSubtaskIDs(ids...)
 	return _u
 }
 
+// RemoveSubtasks removes "subtasks" edges to Todo entities.
+func (_u *TodoUpdateOne) RemoveSubtasks(v ...*Todo) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSubtaskIDs(ids...)
+}
+
 // Where appends a list predicates to the TodoUpdate builder.
 func (_u *TodoUpdateOne) Where(ps ...predicate.Todo) *TodoUpdateOne {
 	_u.mutation.Where(ps...)
@@ -276,6 +1360,9 @@ func (_u *TodoUpdateOne) Select(field string, fields ...string) *TodoUpdateOne {
 
 // Save executes the query and returns the updated Todo entity.
 func (_u *TodoUpdateOne) Save(ctx context.Context) (*Todo, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
 }
 
@@ -301,6 +1388,18 @@ func (_u *TodoUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (_u *TodoUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if todo.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := todo.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (_u *TodoUpdateOne) check() error {
 	if v, ok := _u.mutation.Title(); ok {
@@ -308,6 +1407,14 @@ func (_u *TodoUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Priority(); ok {
+		if err := todo.PriorityValidator(v); err != nil {
+			return &ValidationError{Name: "priority", err: fmt.Errorf(`ent: validator failed for field "Todo.priority": %w`, err)}
+		}
+	}
+	if _u.mutation.WorkspaceCleared() && len(_u.mutation.WorkspaceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Todo.workspace"`)
+	}
 	return nil
 }
 
@@ -340,12 +1447,48 @@ func (_u *TodoUpdateOne) sqlSave(ctx context.Context) (_node *Todo, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(todo.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(todo.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.Title(); ok {
 		_spec.SetField(todo.FieldTitle, field.TypeString, value)
 	}
 	if value, ok := _u.mutation.Completed(); ok {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Priority(); ok {
+		_spec.SetField(todo.FieldPriority, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.CompletedAt(); ok {
+		_spec.SetField(todo.FieldCompletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CompletedAtCleared() {
+		_spec.ClearField(todo.FieldCompletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RemindedAt(); ok {
+		_spec.SetField(todo.FieldRemindedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RemindedAtCleared() {
+		_spec.ClearField(todo.FieldRemindedAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -375,6 +1518,301 @@ func (_u *TodoUpdateOne) sqlSave(ctx context.Context) (_node *Todo, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.AssigneesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAssigneesIDs(); len(nodes) > 0 && !_u.mutation.AssigneesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AssigneesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.AssigneesTable,
+			Columns: todo.AssigneesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		createE := &AssignmentCreate{config: _u.config, mutation: newAssignmentMutation(_u.config, OpCreate)}
+		createE.defaults()
+		_, specE := createE.createSpec()
+		edge.Target.Fields = specE.Fields
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.WatchersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedWatchersIDs(); len(nodes) > 0 && !_u.mutation.WatchersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.WatchersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.WatchersTable,
+			Columns: todo.WatchersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ProjectCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ProjectIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCommentsIDs(); len(nodes) > 0 && !_u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CommentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SubtasksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSubtasksIDs(); len(nodes) > 0 && !_u.mutation.SubtasksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SubtasksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.SubtasksTable,
+			Columns: []string{todo.SubtasksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Todo{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
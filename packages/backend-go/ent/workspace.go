@@ -0,0 +1,251 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend-go/ent/workspace"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Workspace is the model entity for the Workspace schema.
+type Workspace struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WorkspaceQuery when eager-loading is set.
+	Edges        WorkspaceEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// WorkspaceEdges holds the relations/edges for other nodes in the graph.
+type WorkspaceEdges struct {
+	// Memberships holds the value of the memberships edge.
+	Memberships []*Membership `json:"memberships,omitempty"`
+	// Invitations holds the value of the invitations edge.
+	Invitations []*Invitation `json:"invitations,omitempty"`
+	// Todos holds the value of the todos edge.
+	Todos []*Todo `json:"todos,omitempty"`
+	// Tags holds the value of the tags edge.
+	Tags []*Tag `json:"tags,omitempty"`
+	// Projects holds the value of the projects edge.
+	Projects []*Project `json:"projects,omitempty"`
+	// Comments holds the value of the comments edge.
+	Comments []*Comment `json:"comments,omitempty"`
+	// CommentRevisions holds the value of the comment_revisions edge.
+	CommentRevisions []*CommentRevision `json:"comment_revisions,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [7]bool
+}
+
+// MembershipsOrErr returns the Memberships value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) MembershipsOrErr() ([]*Membership, error) {
+	if e.loadedTypes[0] {
+		return e.Memberships, nil
+	}
+	return nil, &NotLoadedError{edge: "memberships"}
+}
+
+// InvitationsOrErr returns the Invitations value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) InvitationsOrErr() ([]*Invitation, error) {
+	if e.loadedTypes[1] {
+		return e.Invitations, nil
+	}
+	return nil, &NotLoadedError{edge: "invitations"}
+}
+
+// TodosOrErr returns the Todos value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) TodosOrErr() ([]*Todo, error) {
+	if e.loadedTypes[2] {
+		return e.Todos, nil
+	}
+	return nil, &NotLoadedError{edge: "todos"}
+}
+
+// TagsOrErr returns the Tags value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) TagsOrErr() ([]*Tag, error) {
+	if e.loadedTypes[3] {
+		return e.Tags, nil
+	}
+	return nil, &NotLoadedError{edge: "tags"}
+}
+
+// ProjectsOrErr returns the Projects value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) ProjectsOrErr() ([]*Project, error) {
+	if e.loadedTypes[4] {
+		return e.Projects, nil
+	}
+	return nil, &NotLoadedError{edge: "projects"}
+}
+
+// CommentsOrErr returns the Comments value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) CommentsOrErr() ([]*Comment, error) {
+	if e.loadedTypes[5] {
+		return e.Comments, nil
+	}
+	return nil, &NotLoadedError{edge: "comments"}
+}
+
+// CommentRevisionsOrErr returns the CommentRevisions value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkspaceEdges) CommentRevisionsOrErr() ([]*CommentRevision, error) {
+	if e.loadedTypes[6] {
+		return e.CommentRevisions, nil
+	}
+	return nil, &NotLoadedError{edge: "comment_revisions"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Workspace) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case workspace.FieldName:
+			values[i] = new(sql.NullString)
+		case workspace.FieldCreatedAt, workspace.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case workspace.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Workspace fields.
+func (_m *Workspace) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case workspace.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case workspace.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case workspace.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case workspace.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Workspace.
+// This includes values selected through modifiers, order, etc.
+func (_m *Workspace) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryMemberships queries the "memberships" edge of the Workspace entity.
+func (_m *Workspace) QueryMemberships() *MembershipQuery {
+	return NewWorkspaceClient(_m.config).QueryMemberships(_m)
+}
+
+// QueryInvitations queries the "invitations" edge of the Workspace entity.
+func (_m *Workspace) QueryInvitations() *InvitationQuery {
+	return NewWorkspaceClient(_m.config).QueryInvitations(_m)
+}
+
+// QueryTodos queries the "todos" edge of the Workspace entity.
+func (_m *Workspace) QueryTodos() *TodoQuery {
+	return NewWorkspaceClient(_m.config).QueryTodos(_m)
+}
+
+// QueryTags queries the "tags" edge of the Workspace entity.
+func (_m *Workspace) QueryTags() *TagQuery {
+	return NewWorkspaceClient(_m.config).QueryTags(_m)
+}
+
+// QueryProjects queries the "projects" edge of the Workspace entity.
+func (_m *Workspace) QueryProjects() *ProjectQuery {
+	return NewWorkspaceClient(_m.config).QueryProjects(_m)
+}
+
+// QueryComments queries the "comments" edge of the Workspace entity.
+func (_m *Workspace) QueryComments() *CommentQuery {
+	return NewWorkspaceClient(_m.config).QueryComments(_m)
+}
+
+// QueryCommentRevisions queries the "comment_revisions" edge of the Workspace entity.
+func (_m *Workspace) QueryCommentRevisions() *CommentRevisionQuery {
+	return NewWorkspaceClient(_m.config).QueryCommentRevisions(_m)
+}
+
+// Update returns a builder for updating this Workspace.
+// Note that you need to call Workspace.Unwrap() before calling this method if this Workspace
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Workspace) Update() *WorkspaceUpdateOne {
+	return NewWorkspaceClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Workspace entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Workspace) Unwrap() *Workspace {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Workspace is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Workspace) String() string {
+	var builder strings.Builder
+	builder.WriteString("Workspace(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Workspaces is a parsable slice of Workspace.
+type Workspaces []*Workspace
@@ -0,0 +1,172 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/assignment"
+	"backend-go/graph/loader"
+	"backend-go/graph/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AssignTodo is the resolver for the assignTodo field.
+func (r *mutationResolver) AssignTodo(ctx context.Context, todoID uuid.UUID, userIds []uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the users
+	userIDs, err := upstreamUserIDsMapper(ctx, r.client(ctx), userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users already assigned keep their place in line
+	assigned, err := r.client(ctx).Assignment.Query().
+		Where(assignment.TodoID(todoID), assignment.UserIDIn(userIDs...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up assignees: %w", err)
+	}
+	skip := make(map[uuid.UUID]bool, len(userIDs))
+	for _, entAssignment := range assigned {
+		skip[entAssignment.UserID] = true
+	}
+	var newIDs []uuid.UUID
+	for _, id := range userIDs {
+		if !skip[id] {
+			newIDs = append(newIDs, id)
+			skip[id] = true
+		}
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		AddAssigneeIDs(newIDs...).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to assign todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// UnassignTodo is the resolver for the unassignTodo field.
+func (r *mutationResolver) UnassignTodo(ctx context.Context, todoID uuid.UUID, userIds []uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the users
+	userIDs, err := upstreamUserIDsMapper(ctx, r.client(ctx), userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		RemoveAssigneeIDs(userIDs...).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to unassign todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// WatchTodo is the resolver for the watchTodo field.
+func (r *mutationResolver) WatchTodo(ctx context.Context, todoID uuid.UUID, userID uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the user
+	userID, err := upstreamWatcherMapper(ctx, r.client(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		AddWatcherIDs(userID).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to watch todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// UnwatchTodo is the resolver for the unwatchTodo field.
+func (r *mutationResolver) UnwatchTodo(ctx context.Context, todoID uuid.UUID, userID uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the user
+	userID, err := upstreamWatcherMapper(ctx, r.client(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		RemoveWatcherIDs(userID).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to unwatch todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// Assignees is the resolver for the assignees field.
+func (r *todoResolver) Assignees(ctx context.Context, obj *model.Todo) ([]*model.User, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	// Batched with the assignees of every other todo in the response
+	entUsers, err := loader.For(ctx).AssigneesByTodoID.Load(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	users := make([]*model.User, len(entUsers))
+	for i, entUser := range entUsers {
+		users[i] = downstreamUserMapper(entUser)
+	}
+
+	return users, nil
+}
+
+// Watchers is the resolver for the watchers field.
+func (r *todoResolver) Watchers(ctx context.Context, obj *model.Todo) ([]*model.User, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	// Batched with the watchers of every other todo in the response
+	entUsers, err := loader.For(ctx).WatchersByTodoID.Load(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	users := make([]*model.User, len(entUsers))
+	for i, entUser := range entUsers {
+		users[i] = downstreamUserMapper(entUser)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,186 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/graph/model"
+	"backend-go/tenant"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SignUp is the resolver for the signUp field.
+func (r *mutationResolver) SignUp(ctx context.Context, input model.SignUpInput) (*model.AuthPayload, error) {
+	if r.DisablePasswords {
+		return nil, auth.ErrPasswordsDisabled
+	}
+
+	// Use upstream mapper to prepare the creation operation
+	createQuery, err := upstreamSignUpMapper(ctx, r.client(ctx), input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signing up doesn't join the workspace the request acts in
+	entUser, err := createQuery.Save(tenant.Detach(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign up: %w", err)
+	}
+
+	tokens, err := auth.OpenSession(ctx, r.client(ctx), r.Keys, entUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamAuthPayloadMapper(tokens), nil
+}
+
+// Login is the resolver for the login field.
+func (r *mutationResolver) Login(ctx context.Context, input model.LoginInput) (*model.AuthPayload, error) {
+	if r.DisablePasswords {
+		return nil, auth.ErrPasswordsDisabled
+	}
+
+	tokens, err := auth.Login(ctx, r.client(ctx), r.Keys, input.Email, input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamAuthPayloadMapper(tokens), nil
+}
+
+// Logout is the resolver for the logout field.
+func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	sessionID, ok := auth.SessionFromContext(ctx)
+	if !ok {
+		return false, auth.ErrNotLoggedIn
+	}
+
+	if err := auth.RevokeSession(ctx, r.client(ctx), sessionID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RefreshSession is the resolver for the refreshSession field.
+func (r *mutationResolver) RefreshSession(ctx context.Context, refreshToken string) (*model.AuthPayload, error) {
+	// Not in the transaction of the mutation, so a reused refresh token
+	// logs its session out although the mutation fails
+	tokens, err := auth.RefreshSession(ctx, r.Client, r.Keys, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamAuthPayloadMapper(tokens), nil
+}
+
+// ChangePassword is the resolver for the changePassword field.
+func (r *mutationResolver) ChangePassword(ctx context.Context, input model.ChangePasswordInput) (bool, error) {
+	if r.DisablePasswords {
+		return false, auth.ErrPasswordsDisabled
+	}
+
+	viewer, ok := auth.FromContext(ctx)
+	if !ok {
+		return false, auth.ErrNotLoggedIn
+	}
+
+	// Use upstream mapper to prepare the update operation
+	updateQuery, err := upstreamChangePasswordMapper(ctx, r.client(ctx), viewer, input)
+	if err != nil {
+		return false, err
+	}
+
+	// The viewer may not be a member of the workspace the request acts in
+	if err := updateQuery.Exec(tenant.System(ctx)); err != nil {
+		return false, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	// Sessions that may have been opened with the old password end, except
+	// the one changing it
+	sessionID, _ := auth.SessionFromContext(ctx)
+	if err := auth.RevokeOtherSessions(ctx, r.client(ctx), viewer.ID, sessionID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreatePasswordSetup is the resolver for the createPasswordSetup field.
+func (r *mutationResolver) CreatePasswordSetup(ctx context.Context, userID uuid.UUID) (*model.PasswordSetup, error) {
+	if r.DisablePasswords {
+		return nil, auth.ErrPasswordsDisabled
+	}
+
+	// Only members of the workspace are found
+	entUser, err := r.client(ctx).User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("user with id %s not found", userID).
+				WithField("userId", "user does not exist")
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	// Users are shared by every workspace they belong to, and admins only
+	// manage the ones of their own
+	workspaceID, _ := tenant.FromContext(ctx)
+	elsewhere, err := r.client(ctx).Membership.Query().
+		Where(membership.UserID(userID), membership.WorkspaceIDNEQ(workspaceID)).
+		Exist(tenant.System(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up memberships: %w", err)
+	}
+	if elsewhere {
+		return nil, apperror.Forbidden("user with id %s is a member of other workspaces too", userID)
+	}
+
+	token, expiresAt, err := auth.IssuePasswordSetup(ctx, r.client(ctx), entUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamPasswordSetupMapper(token, expiresAt), nil
+}
+
+// SetUpPassword is the resolver for the setUpPassword field.
+func (r *mutationResolver) SetUpPassword(ctx context.Context, input model.SetUpPasswordInput) (*model.AuthPayload, error) {
+	if r.DisablePasswords {
+		return nil, auth.ErrPasswordsDisabled
+	}
+
+	hash, err := upstreamPasswordMapper("input.password", input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := auth.SetUpPassword(ctx, r.client(ctx), r.Keys, input.Token, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamAuthPayloadMapper(tokens), nil
+}
+
+// Viewer is the resolver for the viewer field.
+func (r *queryResolver) Viewer(ctx context.Context) (*model.User, error) {
+	viewer, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(viewer), nil
+}
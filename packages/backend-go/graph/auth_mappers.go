@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/graph/model"
+)
+
+// Auth data mappers for converting between database entities and GraphQL models
+//
+// Naming Convention:
+// - downstream: Database → Frontend (Ent entities → GraphQL models)
+// - upstream: Frontend → Database (GraphQL inputs → Ent operations)
+
+// =============================================================================
+// DOWNSTREAM MAPPERS (Database → Frontend)
+// =============================================================================
+
+// downstreamAuthPayloadMapper converts the tokens of a session to a GraphQL model
+func downstreamAuthPayloadMapper(tokens *auth.Tokens) *model.AuthPayload {
+	return &model.AuthPayload{
+		AccessToken:  tokens.AccessToken,
+		ExpiresAt:    tokens.AccessExpiresAt,
+		RefreshToken: tokens.RefreshToken,
+		User:         downstreamUserMapper(tokens.User),
+	}
+}
+
+// downstreamPasswordSetupMapper converts a password setup token to a GraphQL
+// model
+func downstreamPasswordSetupMapper(token string, expiresAt time.Time) *model.PasswordSetup {
+	return &model.PasswordSetup{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// =============================================================================
+// UPSTREAM MAPPERS (Frontend → Database)
+// =============================================================================
+
+// upstreamSignUpMapper prepares a user creation operation from GraphQL input,
+// storing a hash of the password
+func upstreamSignUpMapper(ctx context.Context, client *ent.Client, input model.SignUpInput) (*ent.UserCreate, error) {
+	hash, err := upstreamPasswordMapper("input.password", input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.User.
+		Create().
+		SetEmail(input.Email).
+		SetName(input.Name).
+		SetPasswordHash(hash), nil
+}
+
+// upstreamChangePasswordMapper prepares the update of the password of viewer
+// from GraphQL input, making sure they know their current one. Users without
+// a password set their first one.
+func upstreamChangePasswordMapper(ctx context.Context, client *ent.Client, viewer *ent.User, input model.ChangePasswordInput) (*ent.UserUpdateOne, error) {
+	ok := viewer.PasswordHash == nil
+	if !ok && input.CurrentPassword != nil {
+		var err error
+		if ok, err = auth.VerifyPassword(*viewer.PasswordHash, *input.CurrentPassword); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, apperror.Unauthenticated("current password is wrong").
+			WithField("input.currentPassword", "password is wrong")
+	}
+
+	hash, err := upstreamPasswordMapper("input.newPassword", input.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.User.UpdateOne(viewer).SetPasswordHash(hash), nil
+}
+
+// upstreamPasswordMapper hashes a password passed as field, making sure it
+// is long enough
+func upstreamPasswordMapper(field, password string) (string, error) {
+	if utf8.RuneCountInString(password) < auth.MinPasswordLength {
+		return "", apperror.InvalidArgument(field, "password needs at least %d characters", auth.MinPasswordLength)
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/comment"
+	"backend-go/ent/todo"
+	"backend-go/graph/model"
+)
+
+// Comment data mappers for converting between database entities and GraphQL models
+//
+// Naming Convention:
+// - downstream: Database → Frontend (Ent entities → GraphQL models)
+// - upstream: Frontend → Database (GraphQL inputs → Ent operations)
+
+// =============================================================================
+// DOWNSTREAM MAPPERS (Database → Frontend)
+// =============================================================================
+
+// downstreamCommentMapper converts an Ent Comment entity to a GraphQL model
+func downstreamCommentMapper(entComment *ent.Comment) *model.Comment {
+	// The author and revisions are resolved lazily by field resolvers
+	return &model.Comment{
+		ID:        model.GlobalID("Comment", entComment.ID),
+		Body:      entComment.Body,
+		Edited:    entComment.Edited,
+		TodoID:    entComment.TodoID,
+		AuthorID:  entComment.AuthorID,
+		CreatedAt: entComment.CreatedAt,
+		UpdatedAt: entComment.UpdatedAt,
+	}
+}
+
+// downstreamCommentRevisionMapper converts an Ent CommentRevision entity to a GraphQL model
+func downstreamCommentRevisionMapper(entRevision *ent.CommentRevision) *model.CommentRevision {
+	return &model.CommentRevision{
+		ID:        entRevision.ID.String(),
+		Body:      entRevision.Body,
+		CreatedAt: entRevision.CreatedAt,
+	}
+}
+
+// downstreamCommentConnectionMapper converts a page of Ent Comment entities to a GraphQL connection
+func downstreamCommentConnectionMapper(page *pageResult[*ent.Comment]) *model.CommentConnection {
+	edges := make([]*model.CommentEdge, len(page.nodes))
+	for i, entComment := range page.nodes {
+		edges[i] = &model.CommentEdge{
+			Node:   downstreamCommentMapper(entComment),
+			Cursor: page.cursors[i],
+		}
+	}
+
+	return &model.CommentConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo,
+		TotalCount: page.totalCount,
+	}
+}
+
+// =============================================================================
+// UPSTREAM MAPPERS (Frontend → Database)
+// =============================================================================
+
+// upstreamAddCommentMapper prepares a comment creation operation from GraphQL
+// input, making sure the todo exists. Comments are written by the logged in
+// user.
+func upstreamAddCommentMapper(ctx context.Context, client *ent.Client, input model.AddCommentInput) (*ent.CommentCreate, error) {
+	viewer, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrNotLoggedIn
+	}
+
+	exists, err := client.Todo.Query().Where(todo.ID(input.TodoID)).Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up todo: %w", err)
+	}
+	if !exists {
+		return nil, apperror.NotFound("todo with id %s not found", input.TodoID).
+			WithField("input.todoId", "todo does not exist")
+	}
+
+	return client.Comment.
+		Create().
+		SetTodoID(input.TodoID).
+		SetAuthorID(viewer.ID).
+		SetBody(input.Body), nil
+}
+
+// upstreamEditCommentMapper prepares a comment update operation from GraphQL input
+func upstreamEditCommentMapper(ctx context.Context, client *ent.Client, input model.EditCommentInput) *ent.CommentUpdateOne {
+	// The previous body is kept as a revision by the comment hooks
+	return client.Comment.
+		UpdateOneID(input.ID).
+		SetBody(input.Body)
+}
+
+// commentPageOrder lists comments oldest first
+var commentPageOrder = newTimePageOrder(comment.FieldCreatedAt, model.OrderDirectionAsc)
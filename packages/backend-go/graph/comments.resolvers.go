@@ -0,0 +1,133 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/comment"
+	"backend-go/graph/generated"
+	"backend-go/graph/loader"
+	"backend-go/graph/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	if obj.AuthorID == nil {
+		return nil, nil
+	}
+
+	// Batched with the authors of every other comment in the response
+	entUser, err := loader.For(ctx).UserByID.Load(ctx, *obj.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if entUser == nil {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(entUser), nil
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *commentResolver) Revisions(ctx context.Context, obj *model.Comment) ([]*model.CommentRevision, error) {
+	commentID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	// Batched with the revisions of every other comment in the response
+	entRevisions, err := loader.For(ctx).RevisionsByCommentID.Load(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	revisions := make([]*model.CommentRevision, len(entRevisions))
+	for i, entRevision := range entRevisions {
+		revisions[i] = downstreamCommentRevisionMapper(entRevision)
+	}
+
+	return revisions, nil
+}
+
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, input model.AddCommentInput) (*model.Comment, error) {
+	// Use upstream mapper to prepare the creation operation
+	createQuery, err := upstreamAddCommentMapper(ctx, r.client(ctx), input)
+	if err != nil {
+		return nil, err
+	}
+
+	entComment, err := createQuery.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamCommentMapper(entComment), nil
+}
+
+// EditComment is the resolver for the editComment field.
+func (r *mutationResolver) EditComment(ctx context.Context, input model.EditCommentInput) (*model.Comment, error) {
+	// Use upstream mapper to prepare the update operation
+	updateQuery := upstreamEditCommentMapper(ctx, r.client(ctx), input)
+
+	entComment, err := updateQuery.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("comment with id %s not found", input.ID)
+		}
+		return nil, fmt.Errorf("failed to edit comment: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamCommentMapper(entComment), nil
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id uuid.UUID) (bool, error) {
+	// The revisions of the comment go with it through their foreign key
+	err := r.client(ctx).Comment.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("comment with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return true, nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *todoResolver) Comments(ctx context.Context, obj *model.Todo, first *int, after *string, last *int, before *string) (*model.CommentConnection, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	page, err := newPageArgs(first, after, last, before, commentPageOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := paginateComments(ctx, r.client(ctx).Comment.Query().Where(comment.TodoID(todoID)), page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to a GraphQL connection
+	return downstreamCommentConnectionMapper(result), nil
+}
+
+// Comment returns generated.CommentResolver implementation.
+func (r *Resolver) Comment() generated.CommentResolver { return &commentResolver{r} }
+
+type commentResolver struct{ *Resolver }
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/privacy"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// presentError adds the code, invalid fields and conflicting state of err to
+// the extensions of the GraphQL error sent to the client. Errors gqlgen already assigned a code
+// to, like query validation failures, are left as they are. Errors that aren't
+// apperror.Errors get the message classifyError chose for them, so database
+// failures don't reach the client verbatim.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if _, ok := gqlErr.Extensions["code"]; ok {
+		return gqlErr
+	}
+
+	appErr := classifyError(ctx, err)
+	if _, ok := apperror.As(err); !ok {
+		gqlErr.Message = appErr.Message
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = make(map[string]any)
+	}
+	gqlErr.Extensions["code"] = appErr.Code
+	if len(appErr.Fields) > 0 {
+		gqlErr.Extensions["fields"] = appErr.Fields
+	}
+	if appErr.Current != nil {
+		gqlErr.Extensions["current"] = appErr.Current
+	}
+
+	return gqlErr
+}
+
+// classifyError finds the apperror.Error describing err, deriving one from
+// ent errors and from input gqlgen failed to decode. Constraint violations and
+// unexpected failures are logged and described by a fixed message, as their
+// text comes from the database.
+func classifyError(ctx context.Context, err error) *apperror.Error {
+	if appErr, ok := apperror.As(err); ok {
+		return appErr
+	}
+
+	var validationErr *ent.ValidationError
+	var gqlErr *gqlerror.Error
+	switch {
+	case ent.IsNotFound(err):
+		return apperror.Wrap(apperror.CodeNotFound, err, "%v", err)
+	case ent.IsConstraintError(err):
+		log.Printf("constraint violation while resolving %v: %v", graphql.GetPath(ctx), err)
+		return apperror.Wrap(apperror.CodeConflict, err, "conflicts with an existing record")
+	case errors.Is(err, privacy.Deny):
+		return apperror.Wrap(apperror.CodeForbidden, err, "%v", err)
+	case errors.As(err, &validationErr):
+		appErr := apperror.Wrap(apperror.CodeInvalidArgument, err, "%v", err)
+		return appErr.WithField(fieldName(validationErr.Name), validationErr.Error())
+	case errors.As(err, &gqlErr) && inputPath(ctx, gqlErr.Path) != "":
+		// gqlgen puts every error on the path of its field. Only those of
+		// arguments it failed to decode reach further, into the input.
+		appErr := apperror.Wrap(apperror.CodeInvalidArgument, err, "%v", err)
+		return appErr.WithField(inputPath(ctx, gqlErr.Path), gqlErr.Message)
+	default:
+		log.Printf("error while resolving %v: %v", graphql.GetPath(ctx), err)
+		return apperror.Wrap(apperror.CodeInternal, err, "internal server error")
+	}
+}
+
+// recoverPanic turns a panic in a resolver into an error. Panics carrying ent
+// errors, like those of the *X query helpers, keep their meaning; anything
+// else is logged and reported as an internal error.
+func recoverPanic(ctx context.Context, p any) error {
+	if err, ok := p.(error); ok {
+		if _, ok := apperror.As(err); ok || ent.IsNotFound(err) || ent.IsConstraintError(err) || ent.IsValidationError(err) {
+			return err
+		}
+	}
+
+	log.Printf("panic while resolving %v: %v\n%s", graphql.GetPath(ctx), p, debug.Stack())
+	return apperror.New(apperror.CodeInternal, "internal server error")
+}
+
+// fieldName converts an ent field name like user_id to its GraphQL name
+func fieldName(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// inputPath formats the path of an invalid argument relative to the field it
+// was passed to, like input.userId
+func inputPath(ctx context.Context, path ast.Path) string {
+	if fieldPath := graphql.GetPath(ctx); len(fieldPath) <= len(path) && fieldPath.String() == path[:len(fieldPath)].String() {
+		path = path[len(fieldPath):]
+	}
+
+	var b strings.Builder
+	for _, element := range path {
+		switch element := element.(type) {
+		case ast.PathName:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(string(element))
+		case ast.PathIndex:
+			fmt.Fprintf(&b, "[%d]", int(element))
+		}
+	}
+	return b.String()
+}
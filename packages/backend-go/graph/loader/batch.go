@@ -0,0 +1,108 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// batchWait is how long a loader collects keys before fetching them.
+	// gqlgen resolves sibling fields concurrently, so a short window is
+	// enough to gather every key requested at one level of the query.
+	batchWait = time.Millisecond
+	// maxBatchSize dispatches a batch early once it holds this many keys
+	maxBatchSize = 100
+)
+
+// FetchFunc loads the values for a batch of keys. Keys missing from the
+// returned map resolve to the zero value of V.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches lookups by key. Concurrent Load calls made within
+// batchWait of each other are resolved by a single FetchFunc call, and each
+// key is fetched at most once for the lifetime of the loader.
+type Loader[K comparable, V any] struct {
+	fetch FetchFunc[K, V]
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+}
+
+// NewLoader creates a loader resolving keys through fetch
+func NewLoader[K comparable, V any](fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch: fetch,
+		cache: make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it is part of
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.cache[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.cache[key] = r
+		l.enqueue(ctx, key, r)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds key to the pending batch, starting a new one if needed.
+// The caller must hold l.mu.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, r *result[V]) {
+	if l.pending == nil {
+		b := &batch[K, V]{}
+		b.timer = time.AfterFunc(batchWait, func() { l.dispatch(ctx, b) })
+		l.pending = b
+	}
+
+	b := l.pending
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, r)
+	if len(b.keys) >= maxBatchSize && b.timer.Stop() {
+		go l.dispatch(ctx, b)
+	}
+}
+
+// dispatch fetches a batch and resolves every waiting Load call
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, b.keys)
+	for i, key := range b.keys {
+		r := b.results[i]
+		if err != nil {
+			r.err = err
+		} else {
+			r.value = values[key]
+		}
+		close(r.done)
+	}
+}
@@ -0,0 +1,246 @@
+// Package loader batches the edge lookups made by GraphQL field resolvers.
+//
+// A fresh set of loaders is attached to the context of every GraphQL response,
+// so results are only cached for a single request and never leak between
+// clients or go stale across subscription events.
+package loader
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/ent"
+	"backend-go/ent/assignment"
+	"backend-go/ent/commentrevision"
+	"backend-go/ent/project"
+	"backend-go/ent/schema"
+	"backend-go/ent/tag"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+
+	"github.com/google/uuid"
+)
+
+// Loaders holds one loader per edge resolved through batching
+type Loaders struct {
+	UserByID             *Loader[uuid.UUID, *ent.User]
+	TodoByID             *Loader[uuid.UUID, *ent.Todo]
+	TodosByUserID        *Loader[uuid.UUID, []*ent.Todo]
+	SubtasksByParentID   *Loader[uuid.UUID, []*ent.Todo]
+	TagsByTodoID         *Loader[uuid.UUID, []*ent.Tag]
+	AssigneesByTodoID    *Loader[uuid.UUID, []*ent.User]
+	WatchersByTodoID     *Loader[uuid.UUID, []*ent.User]
+	RevisionsByCommentID *Loader[uuid.UUID, []*ent.CommentRevision]
+	ProjectByID          *Loader[uuid.UUID, *ent.Project]
+}
+
+// New creates the loaders for a single response, reading through client
+func New(client *ent.Client) *Loaders {
+	return &Loaders{
+		UserByID:             NewLoader(usersByID(client)),
+		TodoByID:             NewLoader(todosByID(client)),
+		TodosByUserID:        NewLoader(todosByUserID(client)),
+		SubtasksByParentID:   NewLoader(subtasksByParentID(client)),
+		TagsByTodoID:         NewLoader(tagsByTodoID(client)),
+		AssigneesByTodoID:    NewLoader(assigneesByTodoID(client)),
+		WatchersByTodoID:     NewLoader(watchersByTodoID(client)),
+		RevisionsByCommentID: NewLoader(revisionsByCommentID(client)),
+		ProjectByID:          NewLoader(projectsByID(client)),
+	}
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying loaders
+func NewContext(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, ctxKey{}, loaders)
+}
+
+// For returns the loaders attached to ctx by NewContext
+func For(ctx context.Context) *Loaders {
+	loaders, ok := ctx.Value(ctxKey{}).(*Loaders)
+	if !ok {
+		panic("loader: no loaders in context")
+	}
+	return loaders
+}
+
+// usersByID loads users by primary key
+func usersByID(client *ent.Client) FetchFunc[uuid.UUID, *ent.User] {
+	return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*ent.User, error) {
+		entUsers, err := client.User.Query().
+			Where(user.IDIn(ids...)).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load users: %w", err)
+		}
+
+		users := make(map[uuid.UUID]*ent.User, len(entUsers))
+		for _, entUser := range entUsers {
+			users[entUser.ID] = entUser
+		}
+		return users, nil
+	}
+}
+
+// todosByID loads todos by primary key
+func todosByID(client *ent.Client) FetchFunc[uuid.UUID, *ent.Todo] {
+	return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*ent.Todo, error) {
+		entTodos, err := client.Todo.Query().
+			Where(todo.IDIn(ids...)).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		todos := make(map[uuid.UUID]*ent.Todo, len(entTodos))
+		for _, entTodo := range entTodos {
+			todos[entTodo.ID] = entTodo
+		}
+		return todos, nil
+	}
+}
+
+// todosByUserID loads the todos of each user, ordered by ID
+func todosByUserID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.Todo] {
+	return func(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]*ent.Todo, error) {
+		entTodos, err := client.Todo.Query().
+			Where(todo.UserIDIn(userIDs...)).
+			Order(todo.ByID()).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		todos := make(map[uuid.UUID][]*ent.Todo, len(userIDs))
+		for _, entTodo := range entTodos {
+			todos[*entTodo.UserID] = append(todos[*entTodo.UserID], entTodo)
+		}
+		return todos, nil
+	}
+}
+
+// tagsByTodoID loads the tags of each todo, ordered by name
+func tagsByTodoID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.Tag] {
+	return func(ctx context.Context, todoIDs []uuid.UUID) (map[uuid.UUID][]*ent.Tag, error) {
+		// Deleted todos listed through includeDeleted carry their tags too
+		entTodos, err := client.Todo.Query().
+			Where(todo.IDIn(todoIDs...)).
+			WithTags(func(q *ent.TagQuery) {
+				q.Order(tag.ByName(), tag.ByID())
+			}).
+			All(schema.SkipSoftDelete(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+
+		tags := make(map[uuid.UUID][]*ent.Tag, len(entTodos))
+		for _, entTodo := range entTodos {
+			tags[entTodo.ID] = entTodo.Edges.Tags
+		}
+		return tags, nil
+	}
+}
+
+// assigneesByTodoID loads the assignees of each todo in the order they were
+// assigned
+func assigneesByTodoID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.User] {
+	return func(ctx context.Context, todoIDs []uuid.UUID) (map[uuid.UUID][]*ent.User, error) {
+		entAssignments, err := client.Assignment.Query().
+			Where(assignment.TodoIDIn(todoIDs...)).
+			WithUser().
+			Order(assignment.ByCreatedAt(), assignment.ByUserID()).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load assignees: %w", err)
+		}
+
+		assignees := make(map[uuid.UUID][]*ent.User, len(todoIDs))
+		for _, entAssignment := range entAssignments {
+			// Deleted users are left out
+			if entAssignment.Edges.User != nil {
+				assignees[entAssignment.TodoID] = append(assignees[entAssignment.TodoID], entAssignment.Edges.User)
+			}
+		}
+		return assignees, nil
+	}
+}
+
+// watchersByTodoID loads the watchers of each todo, ordered by name
+func watchersByTodoID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.User] {
+	return func(ctx context.Context, todoIDs []uuid.UUID) (map[uuid.UUID][]*ent.User, error) {
+		// Deleted todos listed through includeDeleted carry their watchers
+		// too, deleted users don't show up as watchers
+		entTodos, err := client.Todo.Query().
+			Where(todo.IDIn(todoIDs...)).
+			WithWatchers(func(q *ent.UserQuery) {
+				q.Where(user.DeletedAtIsNil()).Order(user.ByName(), user.ByID())
+			}).
+			All(schema.SkipSoftDelete(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load watchers: %w", err)
+		}
+
+		watchers := make(map[uuid.UUID][]*ent.User, len(entTodos))
+		for _, entTodo := range entTodos {
+			watchers[entTodo.ID] = entTodo.Edges.Watchers
+		}
+		return watchers, nil
+	}
+}
+
+// revisionsByCommentID loads the revisions of each comment, oldest first
+func revisionsByCommentID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.CommentRevision] {
+	return func(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]*ent.CommentRevision, error) {
+		entRevisions, err := client.CommentRevision.Query().
+			Where(commentrevision.CommentIDIn(commentIDs...)).
+			Order(commentrevision.ByCreatedAt(), commentrevision.ByID()).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load comment revisions: %w", err)
+		}
+
+		revisions := make(map[uuid.UUID][]*ent.CommentRevision, len(commentIDs))
+		for _, entRevision := range entRevisions {
+			revisions[entRevision.CommentID] = append(revisions[entRevision.CommentID], entRevision)
+		}
+		return revisions, nil
+	}
+}
+
+// subtasksByParentID loads the subtasks of each todo, ordered by ID
+func subtasksByParentID(client *ent.Client) FetchFunc[uuid.UUID, []*ent.Todo] {
+	return func(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID][]*ent.Todo, error) {
+		entTodos, err := client.Todo.Query().
+			Where(todo.ParentIDIn(parentIDs...)).
+			Order(todo.ByID()).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load subtasks: %w", err)
+		}
+
+		subtasks := make(map[uuid.UUID][]*ent.Todo, len(parentIDs))
+		for _, entTodo := range entTodos {
+			subtasks[*entTodo.ParentID] = append(subtasks[*entTodo.ParentID], entTodo)
+		}
+		return subtasks, nil
+	}
+}
+
+// projectsByID loads projects by primary key
+func projectsByID(client *ent.Client) FetchFunc[uuid.UUID, *ent.Project] {
+	return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*ent.Project, error) {
+		entProjects, err := client.Project.Query().
+			Where(project.IDIn(ids...)).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load projects: %w", err)
+		}
+
+		projects := make(map[uuid.UUID]*ent.Project, len(entProjects))
+		for _, entProject := range entProjects {
+			projects[entProject.ID] = entProject
+		}
+		return projects, nil
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidGlobalID is returned for strings that are not global IDs
+var ErrInvalidGlobalID = errors.New("invalid global ID")
+
+// GlobalID returns the Relay global ID of the node of type typename with
+// primary key id: the base64 encoding of "<typename>:<id>", e.g. "Todo:<uuid>"
+func GlobalID(typename string, id uuid.UUID) string {
+	return base64.StdEncoding.EncodeToString([]byte(typename + ":" + id.String()))
+}
+
+// ParseGlobalID returns the type name and primary key a global ID was made of
+func ParseGlobalID(globalID string) (string, uuid.UUID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidGlobalID
+	}
+	typename, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || typename == "" {
+		return "", uuid.Nil, ErrInvalidGlobalID
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidGlobalID
+	}
+	return typename, parsed, nil
+}
+
+// MarshalUUID writes UUIDs in their canonical text form
+func MarshalUUID(id uuid.UUID) graphql.Marshaler {
+	return graphql.MarshalUUID(id)
+}
+
+// UnmarshalUUID reads a UUID, or the global ID of a node, so the id of a node
+// can be passed wherever a UUID is expected. The @nodeId directive makes sure
+// it names a node of the expected type.
+func UnmarshalUUID(v any) (uuid.UUID, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return uuid.Nil, fmt.Errorf("%T is not a uuid", v)
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		if _, nodeID, globalErr := ParseGlobalID(s); globalErr == nil {
+			return nodeID, nil
+		}
+	}
+	return id, err
+}
@@ -2,9 +2,107 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// An object with a globally unique ID. The IDs of todos, users, tags, comments
+// and projects are opaque global IDs naming the type and the UUID of the object,
+// so any of them can be refetched through the node and nodes fields. They can be
+// passed wherever a UUID of a node of the same type is expected too.
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
+// Comments are written by the logged in user.
+type AddCommentInput struct {
+	TodoID uuid.UUID `json:"todoId"`
+	Body   string    `json:"body"`
+}
+
+// The tokens of a session. Send the access token with every request as
+// "Authorization: Bearer <token>" (or as authorization in the connection_init
+// payload of websockets) and trade the refresh token for new tokens with
+// refreshSession once it expired.
+type AuthPayload struct {
+	// A JWT, verifiable with the keys published at /.well-known/jwks.json.
+	AccessToken string `json:"accessToken"`
+	// When the access token stops working.
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken"`
+	User         *User     `json:"user"`
+}
+
+type ChangePasswordInput struct {
+	// Left out by users who don't have a password yet, like those of single sign-on.
+	CurrentPassword *string `json:"currentPassword,omitempty"`
+	// At least 8 characters.
+	NewPassword string `json:"newPassword"`
+}
+
+// A comment in the discussion of a todo.
+type Comment struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+	// Set once the body has been changed, see revisions.
+	Edited    bool       `json:"edited"`
+	TodoID    uuid.UUID  `json:"todoId"`
+	AuthorID  *uuid.UUID `json:"authorId,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+func (Comment) IsNode()            {}
+func (this Comment) GetID() string { return this.ID }
+
+type CommentConnection struct {
+	Edges      []*CommentEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type CommentEdge struct {
+	Node   *Comment `json:"node"`
+	Cursor string   `json:"cursor"`
+}
+
+// The body a comment had until it was edited at createdAt.
+type CommentRevision struct {
+	ID        string    `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateProjectInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	// Defaults to the logged in user, only admins make others the owner.
+	OwnerID *uuid.UUID `json:"ownerId,omitempty"`
+}
+
+type CreateTagInput struct {
+	Name string `json:"name"`
+	// Defaults to #9E9E9E.
+	Color *string `json:"color,omitempty"`
+}
+
 type CreateTodoInput struct {
-	Title  string  `json:"title"`
-	UserID *string `json:"userId,omitempty"`
+	Title  string     `json:"title"`
+	UserID *uuid.UUID `json:"userId,omitempty"`
+	DueAt  *time.Time `json:"dueAt,omitempty"`
+	// Defaults to MEDIUM.
+	Priority *TodoPriority `json:"priority,omitempty"`
+	// Creates the todo as a subtask of this one.
+	ParentID  *uuid.UUID `json:"parentId,omitempty"`
+	ProjectID *uuid.UUID `json:"projectId,omitempty"`
 }
 
 type CreateUserInput struct {
@@ -12,36 +110,584 @@ type CreateUserInput struct {
 	Name  string `json:"name"`
 }
 
+type CreateWorkspaceInput struct {
+	Name string `json:"name"`
+}
+
+type EditCommentInput struct {
+	ID   uuid.UUID `json:"id"`
+	Body string    `json:"body"`
+}
+
+// An offer to join a workspace in a role. Users only become members of a
+// workspace once they accept an invitation to it.
+type Invitation struct {
+	ID            string    `json:"id"`
+	WorkspaceID   uuid.UUID `json:"workspaceId"`
+	WorkspaceName string    `json:"workspaceName"`
+	// The invited user.
+	UserID uuid.UUID `json:"userId"`
+	// The role the user gets on accepting.
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type LoginInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
 type Mutation struct {
 }
 
+// Information about a page of a Relay-style connection.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
+// A one-time token a user without a password sets up their first one with,
+// see setUpPassword. Admins hand it to them, e.g. in a link to the frontend.
+type PasswordSetup struct {
+	Token string `json:"token"`
+	// When the token stops working.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// A list grouping todos. The todos of archived projects are left out of the
+// todos, todosConnection, overdueTodos and Tag.todos listings unless
+// includeArchived is set.
+type Project struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	Archived    bool       `json:"archived"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func (Project) IsNode()            {}
+func (this Project) GetID() string { return this.ID }
+
 type Query struct {
 }
 
+type SetUpPasswordInput struct {
+	Token string `json:"token"`
+	// At least 8 characters.
+	Password string `json:"password"`
+}
+
+type SignUpInput struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	// At least 8 characters.
+	Password string `json:"password"`
+}
+
+// Live todo changes. Passing userId only streams todos assigned to that user,
+// first or not.
+type Subscription struct {
+}
+
+// A label todos can be grouped by. Tag names are unique within a workspace.
+type Tag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// A hex color like #9E9E9E.
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (Tag) IsNode()            {}
+func (this Tag) GetID() string { return this.ID }
+
 type Todo struct {
-	ID        string  `json:"id"`
-	Title     string  `json:"title"`
-	Completed bool    `json:"completed"`
-	User      *User   `json:"user,omitempty"`
-	UserID    *string `json:"userId,omitempty"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Completed bool       `json:"completed"`
+	UserID    *uuid.UUID `json:"userId,omitempty"`
+	// When the todo should be done by. Open todos are announced by todoReminder shortly before.
+	DueAt    *time.Time   `json:"dueAt,omitempty"`
+	Priority TodoPriority `json:"priority"`
+	// Set when the todo was last completed, null while it is open.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Set when todoReminder announced the todo, cleared when the due date changes.
+	RemindedAt *time.Time `json:"remindedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	// Set while the todo is in the trash, see includeDeleted.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Bumped on every change. Pass it back as expectedVersion to detect concurrent edits.
+	Version   int        `json:"version"`
+	ParentID  *uuid.UUID `json:"parentId,omitempty"`
+	ProjectID *uuid.UUID `json:"projectId,omitempty"`
+}
+
+func (Todo) IsNode()            {}
+func (this Todo) GetID() string { return this.ID }
+
+type TodoConnection struct {
+	Edges      []*TodoEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type TodoEdge struct {
+	Node   *Todo  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type TodoOrder struct {
+	Field     TodoOrderField `json:"field"`
+	Direction OrderDirection `json:"direction"`
+}
+
+// Filters for todo lists. Every field that is set must match.
+type TodoWhereInput struct {
+	TitleContains *string        `json:"titleContains,omitempty"`
+	Completed     *bool          `json:"completed,omitempty"`
+	UserIDIn      []uuid.UUID    `json:"userIdIn,omitempty"`
+	UserIDIsNull  *bool          `json:"userIdIsNull,omitempty"`
+	DueBefore     *time.Time     `json:"dueBefore,omitempty"`
+	DueAfter      *time.Time     `json:"dueAfter,omitempty"`
+	HasDueDate    *bool          `json:"hasDueDate,omitempty"`
+	PriorityIn    []TodoPriority `json:"priorityIn,omitempty"`
+	// False lists top-level todos only, true subtasks only.
+	HasParent       *bool      `json:"hasParent,omitempty"`
+	CompletedBefore *time.Time `json:"completedBefore,omitempty"`
+	CompletedAfter  *time.Time `json:"completedAfter,omitempty"`
+	// Only todos changed after this point in time, for incremental sync.
+	UpdatedAfter *time.Time `json:"updatedAfter,omitempty"`
+	// Also return deleted todos that haven't been purged yet. Meant for admin tools.
+	IncludeDeleted *bool `json:"includeDeleted,omitempty"`
+	// Only todos this user is assigned to, first or not.
+	AssigneeID *uuid.UUID `json:"assigneeId,omitempty"`
+	// True lists the todos the logged in user is assigned to, false the others.
+	AssignedToMe *bool `json:"assignedToMe,omitempty"`
+	// Only todos this user watches.
+	WatcherID *uuid.UUID `json:"watcherId,omitempty"`
+	// False lists todos nobody is assigned to, true todos with assignees.
+	HasAssignees *bool       `json:"hasAssignees,omitempty"`
+	ProjectIDIn  []uuid.UUID `json:"projectIdIn,omitempty"`
+	// Also return the todos of archived projects.
+	IncludeArchived *bool `json:"includeArchived,omitempty"`
+	// Only todos carrying at least one of these tags.
+	HasAnyTag []uuid.UUID `json:"hasAnyTag,omitempty"`
+	// Only todos carrying every one of these tags.
+	HasAllTags []uuid.UUID `json:"hasAllTags,omitempty"`
+}
+
+type UpdateProjectInput struct {
+	ID   uuid.UUID `json:"id"`
+	Name *string   `json:"name,omitempty"`
+	// Leave out to keep the description, pass null to clear it.
+	Description graphql.Omittable[*string] `json:"description,omitempty"`
+	Archived    *bool                      `json:"archived,omitempty"`
+}
+
+type UpdateTagInput struct {
+	ID    uuid.UUID `json:"id"`
+	Name  *string   `json:"name,omitempty"`
+	Color *string   `json:"color,omitempty"`
 }
 
 type UpdateTodoInput struct {
-	ID     string  `json:"id"`
-	Title  *string `json:"title,omitempty"`
-	Done   *bool   `json:"done,omitempty"`
-	UserID *string `json:"userId,omitempty"`
+	ID    uuid.UUID `json:"id"`
+	Title *string   `json:"title,omitempty"`
+	Done  *bool     `json:"done,omitempty"`
+	// Leave out to keep the current assignees, pass a user to make them the only
+	// assignee or null to unassign everyone.
+	UserID graphql.Omittable[*uuid.UUID] `json:"userId,omitempty"`
+	// Leave out to keep the due date, pass null to clear it.
+	DueAt    graphql.Omittable[*time.Time] `json:"dueAt,omitempty"`
+	Priority *TodoPriority                 `json:"priority,omitempty"`
+	// Leave out to keep the parent, pass null to make the todo top-level. Fails
+	// with INVALID_ARGUMENT when the todo would end up among its own subtasks.
+	ParentID graphql.Omittable[*uuid.UUID] `json:"parentId,omitempty"`
+	// Along with done: true, also completes every subtask below the todo.
+	CompleteSubtasks *bool `json:"completeSubtasks,omitempty"`
+	// Fails the update with CONFLICT when the todo is at another version, i.e.
+	// someone else changed it since it was read. The error carries the current
+	// todo in extensions.current.
+	ExpectedVersion *int `json:"expectedVersion,omitempty"`
+	// Leave out to keep the project, pass null to take the todo out of it.
+	ProjectID graphql.Omittable[*uuid.UUID] `json:"projectId,omitempty"`
 }
 
 type UpdateUserInput struct {
-	ID    string  `json:"id"`
-	Email *string `json:"email,omitempty"`
-	Name  *string `json:"name,omitempty"`
+	ID    uuid.UUID `json:"id"`
+	Email *string   `json:"email,omitempty"`
+	Name  *string   `json:"name,omitempty"`
+	// Fails the update with CONFLICT when the user is at another version, i.e.
+	// someone else changed it since it was read. The error carries the current
+	// user in extensions.current.
+	ExpectedVersion *int `json:"expectedVersion,omitempty"`
 }
 
 type User struct {
-	ID    string  `json:"id"`
-	Email string  `json:"email"`
-	Name  string  `json:"name"`
-	Todos []*Todo `json:"todos"`
+	ID string `json:"id"`
+	// Null for viewers, see Role.
+	Email     *string   `json:"email,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Set while the user is in the trash, see includeDeleted.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Bumped on every change. Pass it back as expectedVersion to detect concurrent edits.
+	Version int `json:"version"`
+}
+
+func (User) IsNode()            {}
+func (this User) GetID() string { return this.ID }
+
+type UserConnection struct {
+	Edges      []*UserEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type UserEdge struct {
+	Node   *User  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type UserOrder struct {
+	Field     UserOrderField `json:"field"`
+	Direction OrderDirection `json:"direction"`
+}
+
+// Filters for user lists. Every field that is set must match.
+type UserWhereInput struct {
+	Email         *string `json:"email,omitempty"`
+	EmailContains *string `json:"emailContains,omitempty"`
+	NameContains  *string `json:"nameContains,omitempty"`
+	// Whether the user is the first assignee of any todo.
+	HasTodos *bool `json:"hasTodos,omitempty"`
+	// Only users changed after this point in time, for incremental sync.
+	UpdatedAfter *time.Time `json:"updatedAfter,omitempty"`
+	// Also return deleted users that haven't been purged yet. Meant for admin tools.
+	IncludeDeleted *bool `json:"includeDeleted,omitempty"`
+}
+
+// A tenant of the API. Requests act in the workspace selected by the
+// X-Workspace-ID header (or the workspaceId query parameter for websockets) and
+// only see and change its todos, tags, projects and comments, and the users that
+// are members of it.
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Sort direction for list and connection orderings.
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// What a member may do in the current workspace. Every role may do what the
+// roles below it may.
+type Role string
+
+const (
+	// Manages the workspace, including who else owns it.
+	RoleOwner Role = "OWNER"
+	// Manages users and members.
+	RoleAdmin Role = "ADMIN"
+	// Works on todos, tags, projects and comments.
+	RoleMember Role = "MEMBER"
+	// Reads the workspace, except for the email addresses of users.
+	RoleViewer Role = "VIEWER"
+)
+
+var AllRole = []Role{
+	RoleOwner,
+	RoleAdmin,
+	RoleMember,
+	RoleViewer,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Todos without a due or completion date sort after all others in ascending
+// order. PRIORITY sorts from LOW to URGENT.
+type TodoOrderField string
+
+const (
+	TodoOrderFieldTitle       TodoOrderField = "TITLE"
+	TodoOrderFieldCompleted   TodoOrderField = "COMPLETED"
+	TodoOrderFieldCreatedAt   TodoOrderField = "CREATED_AT"
+	TodoOrderFieldUpdatedAt   TodoOrderField = "UPDATED_AT"
+	TodoOrderFieldDueAt       TodoOrderField = "DUE_AT"
+	TodoOrderFieldPriority    TodoOrderField = "PRIORITY"
+	TodoOrderFieldCompletedAt TodoOrderField = "COMPLETED_AT"
+)
+
+var AllTodoOrderField = []TodoOrderField{
+	TodoOrderFieldTitle,
+	TodoOrderFieldCompleted,
+	TodoOrderFieldCreatedAt,
+	TodoOrderFieldUpdatedAt,
+	TodoOrderFieldDueAt,
+	TodoOrderFieldPriority,
+	TodoOrderFieldCompletedAt,
+}
+
+func (e TodoOrderField) IsValid() bool {
+	switch e {
+	case TodoOrderFieldTitle, TodoOrderFieldCompleted, TodoOrderFieldCreatedAt, TodoOrderFieldUpdatedAt, TodoOrderFieldDueAt, TodoOrderFieldPriority, TodoOrderFieldCompletedAt:
+		return true
+	}
+	return false
+}
+
+func (e TodoOrderField) String() string {
+	return string(e)
+}
+
+func (e *TodoOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TodoOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TodoOrderField", str)
+	}
+	return nil
+}
+
+func (e TodoOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TodoOrderField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TodoOrderField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TodoPriority string
+
+const (
+	TodoPriorityLow    TodoPriority = "LOW"
+	TodoPriorityMedium TodoPriority = "MEDIUM"
+	TodoPriorityHigh   TodoPriority = "HIGH"
+	TodoPriorityUrgent TodoPriority = "URGENT"
+)
+
+var AllTodoPriority = []TodoPriority{
+	TodoPriorityLow,
+	TodoPriorityMedium,
+	TodoPriorityHigh,
+	TodoPriorityUrgent,
+}
+
+func (e TodoPriority) IsValid() bool {
+	switch e {
+	case TodoPriorityLow, TodoPriorityMedium, TodoPriorityHigh, TodoPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+func (e TodoPriority) String() string {
+	return string(e)
+}
+
+func (e *TodoPriority) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TodoPriority(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TodoPriority", str)
+	}
+	return nil
+}
+
+func (e TodoPriority) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TodoPriority) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TodoPriority) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type UserOrderField string
+
+const (
+	// Needs the MEMBER role.
+	UserOrderFieldEmail     UserOrderField = "EMAIL"
+	UserOrderFieldName      UserOrderField = "NAME"
+	UserOrderFieldCreatedAt UserOrderField = "CREATED_AT"
+	UserOrderFieldUpdatedAt UserOrderField = "UPDATED_AT"
+)
+
+var AllUserOrderField = []UserOrderField{
+	UserOrderFieldEmail,
+	UserOrderFieldName,
+	UserOrderFieldCreatedAt,
+	UserOrderFieldUpdatedAt,
+}
+
+func (e UserOrderField) IsValid() bool {
+	switch e {
+	case UserOrderFieldEmail, UserOrderFieldName, UserOrderFieldCreatedAt, UserOrderFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e UserOrderField) String() string {
+	return string(e)
+}
+
+func (e *UserOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserOrderField", str)
+	}
+	return nil
+}
+
+func (e UserOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserOrderField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserOrderField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
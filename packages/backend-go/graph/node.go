@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/comment"
+	"backend-go/ent/project"
+	"backend-go/ent/tag"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"backend-go/graph/model"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// Node lookups backing the Relay node and nodes root fields.
+//
+// Nodes are identified by global IDs, the base64 encoding of "<type>:<uuid>"
+// (see model.GlobalID), so a lookup knows the table of every ID up front. It
+// costs one query per node type among the requested IDs, however many IDs
+// that are.
+
+// maxNodes caps the number of IDs accepted by a single nodes lookup
+const maxNodes = 100
+
+// nodeRef is a parsed global ID
+type nodeRef struct {
+	typename string
+	id       uuid.UUID
+}
+
+// nodeLoaders fetch the nodes of one type by primary key
+var nodeLoaders = map[string]func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error){
+	"Todo": func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error) {
+		entTodos, err := client.Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query todos: %w", err)
+		}
+		nodes := make(map[uuid.UUID]model.Node, len(entTodos))
+		for _, entTodo := range entTodos {
+			nodes[entTodo.ID] = downstreamTodoMapper(entTodo)
+		}
+		return nodes, nil
+	},
+	"User": func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error) {
+		entUsers, err := client.User.Query().Where(user.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query users: %w", err)
+		}
+		nodes := make(map[uuid.UUID]model.Node, len(entUsers))
+		for _, entUser := range entUsers {
+			nodes[entUser.ID] = downstreamUserMapper(entUser)
+		}
+		return nodes, nil
+	},
+	"Tag": func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error) {
+		entTags, err := client.Tag.Query().Where(tag.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tags: %w", err)
+		}
+		nodes := make(map[uuid.UUID]model.Node, len(entTags))
+		for _, entTag := range entTags {
+			nodes[entTag.ID] = downstreamTagMapper(entTag)
+		}
+		return nodes, nil
+	},
+	"Comment": func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error) {
+		entComments, err := client.Comment.Query().Where(comment.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query comments: %w", err)
+		}
+		nodes := make(map[uuid.UUID]model.Node, len(entComments))
+		for _, entComment := range entComments {
+			nodes[entComment.ID] = downstreamCommentMapper(entComment)
+		}
+		return nodes, nil
+	},
+	"Project": func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]model.Node, error) {
+		entProjects, err := client.Project.Query().Where(project.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query projects: %w", err)
+		}
+		nodes := make(map[uuid.UUID]model.Node, len(entProjects))
+		for _, entProject := range entProjects {
+			nodes[entProject.ID] = downstreamProjectMapper(entProject)
+		}
+		return nodes, nil
+	},
+}
+
+// nodeID returns the primary key a global ID refers to, for resolvers that
+// look up the relations of a node
+func nodeID(globalID string) (uuid.UUID, error) {
+	_, id, err := model.ParseGlobalID(globalID)
+	return id, err
+}
+
+// typedNodeID implements the @nodeId directive, see node.graphqls. The UUIDs
+// gqlgen decoded no longer tell which type of global ID they came from, so
+// the raw values of the argument or input field are checked instead.
+func typedNodeID(ctx context.Context, obj any, next graphql.Resolver, typeArg string) (any, error) {
+	pathCtx := graphql.GetPathContext(ctx)
+	raw, ok := obj.(map[string]any)
+	if !ok || pathCtx == nil || pathCtx.Field == nil {
+		return next(ctx)
+	}
+
+	values, ok := raw[*pathCtx.Field].([]any)
+	if !ok {
+		values = []any{raw[*pathCtx.Field]}
+	}
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if typename, _, err := model.ParseGlobalID(s); err == nil && typename != typeArg {
+			return nil, fmt.Errorf("%s is the ID of a %s, not of a %s", s, typename, typeArg)
+		}
+	}
+	return next(ctx)
+}
+
+// parseNodeIDs parses the global IDs a client passed in field
+func parseNodeIDs(field string, ids []string) ([]nodeRef, error) {
+	if len(ids) > maxNodes {
+		return nil, apperror.InvalidArgument(field, "cannot fetch more than %d nodes at once", maxNodes)
+	}
+
+	refs := make([]nodeRef, len(ids))
+	for i, id := range ids {
+		typename, nodeID, err := model.ParseGlobalID(id)
+		if err != nil {
+			return nil, apperror.InvalidArgument(field, "invalid node ID %q", id)
+		}
+		refs[i] = nodeRef{typename: typename, id: nodeID}
+	}
+	return refs, nil
+}
+
+// loadNodes resolves IDs to GraphQL nodes in the order they were requested.
+// IDs that do not belong to any entity, or to a type without nodes, resolve
+// to nil.
+func loadNodes(ctx context.Context, client *ent.Client, refs []nodeRef) ([]model.Node, error) {
+	byType := make(map[string][]uuid.UUID)
+	for _, ref := range refs {
+		byType[ref.typename] = append(byType[ref.typename], ref.id)
+	}
+
+	found := make(map[string]map[uuid.UUID]model.Node, len(byType))
+	for typename, ids := range byType {
+		load, ok := nodeLoaders[typename]
+		if !ok {
+			continue
+		}
+		nodes, err := load(ctx, client, ids)
+		if err != nil {
+			return nil, err
+		}
+		found[typename] = nodes
+	}
+
+	nodes := make([]model.Node, len(refs))
+	for i, ref := range refs {
+		nodes[i] = found[ref.typename][ref.id]
+	}
+	return nodes, nil
+}
@@ -0,0 +1,35 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/graph/model"
+	"context"
+)
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	nodeIDs, err := parseNodeIDs("id", []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := loadNodes(ctx, r.client(ctx), nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes[0], nil
+}
+
+// Nodes is the resolver for the nodes field.
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]model.Node, error) {
+	nodeIDs, err := parseNodeIDs("ids", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadNodes(ctx, r.client(ctx), nodeIDs)
+}
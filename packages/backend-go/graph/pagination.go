@@ -0,0 +1,430 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/comment"
+	"backend-go/ent/predicate"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"backend-go/graph/model"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Relay-style cursor pagination shared by the *Connection resolvers.
+//
+// Pages are read with keyset pagination: a cursor holds the sort value and ID
+// of the row it points at, and the next page continues strictly after (or
+// before) that position. Rows with equal sort values are ordered by ID, so
+// every row has a unique position. Unlike offsets, cursors stay valid while
+// rows are inserted or deleted elsewhere in the table.
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageOrder is the ordering a list or connection is read in. An empty column
+// orders by ID only.
+type pageOrder struct {
+	column string
+	desc   bool
+
+	// timestamp marks time columns, whose cursor values are decoded back
+	// into time.Time before being compared
+	timestamp bool
+
+	// nullable marks optional time columns. Keyset comparisons don't work
+	// with NULL, so rows without a value sort as if they held farFuture.
+	nullable bool
+
+	// ranks orders an enum column by the position of its values rather than
+	// alphabetically
+	ranks []string
+}
+
+// farFuture stands in for missing values of nullable time columns
+var farFuture = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// newPageOrder converts a GraphQL order direction and the column it applies to
+func newPageOrder(column string, direction model.OrderDirection) pageOrder {
+	return pageOrder{column: column, desc: direction == model.OrderDirectionDesc}
+}
+
+// newTimePageOrder is newPageOrder for a time column
+func newTimePageOrder(column string, direction model.OrderDirection) pageOrder {
+	order := newPageOrder(column, direction)
+	order.timestamp = true
+	return order
+}
+
+// newNullableTimePageOrder is newPageOrder for an optional time column
+func newNullableTimePageOrder(column string, direction model.OrderDirection) pageOrder {
+	order := newTimePageOrder(column, direction)
+	order.nullable = true
+	return order
+}
+
+// newRankedPageOrder is newPageOrder for an enum column whose values are
+// listed in ranks from lowest to highest
+func newRankedPageOrder(column string, ranks []string, direction model.OrderDirection) pageOrder {
+	order := newPageOrder(column, direction)
+	order.ranks = ranks
+	return order
+}
+
+// key writes the expression rows are sorted by
+func (o pageOrder) key(s *sql.Selector) func(*sql.Builder) {
+	column := s.C(o.column)
+	return func(b *sql.Builder) {
+		switch {
+		case o.ranks != nil:
+			b.WriteString("CASE ").Ident(column)
+			for i, value := range o.ranks {
+				b.WriteString(" WHEN ").Arg(value).WriteString(fmt.Sprintf(" THEN %d", i))
+			}
+			b.WriteString(" END")
+		case o.nullable:
+			b.WriteString("COALESCE(").Ident(column).Comma().Arg(farFuture).WriteString(")")
+		default:
+			b.Ident(column)
+		}
+	}
+}
+
+// apply sorts rows by the order column and ID, reversed when reverse is set
+func (o pageOrder) apply(reverse bool) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		term, direction := sql.Asc, " ASC"
+		if o.desc != reverse {
+			term, direction = sql.Desc, " DESC"
+		}
+		if o.column != "" {
+			// Unlike OrderExprFunc, ExprFunc keeps the arguments of the key
+			key := o.key(s)
+			s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+				key(b)
+				b.WriteString(direction)
+			}))
+		}
+		s.OrderBy(term(s.C("id")))
+	}
+}
+
+// value returns the sort value of a row whose order column holds v
+func (o pageOrder) value(v any) any {
+	switch v := v.(type) {
+	case *time.Time:
+		if v == nil {
+			return farFuture
+		}
+		return *v
+	case fmt.Stringer:
+		if o.ranks != nil {
+			return slices.Index(o.ranks, v.String())
+		}
+	}
+	return v
+}
+
+// pageCursor is the decoded form of an opaque connection cursor
+type pageCursor struct {
+	ID    uuid.UUID `json:"id"`
+	Field string    `json:"field,omitempty"`
+	Value any       `json:"value"`
+}
+
+// encodeCursor turns a cursor into the opaque string handed to clients
+func encodeCursor(c pageCursor) string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		panic(fmt.Sprintf("encode cursor: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a cursor previously produced by encodeCursor
+func decodeCursor(s string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", s)
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", s)
+	}
+	return &c, nil
+}
+
+// pageArgs holds validated connection arguments
+type pageArgs struct {
+	size     int
+	backward bool
+	order    pageOrder
+	after    *pageCursor
+	before   *pageCursor
+}
+
+// newPageArgs validates the first/after/last/before connection arguments
+// against the order the connection is read in
+func newPageArgs(first *int, after *string, last *int, before *string, order pageOrder) (*pageArgs, error) {
+	if first != nil && last != nil {
+		return nil, apperror.InvalidArgument("last", "passing both first and last is not supported")
+	}
+
+	page := &pageArgs{size: defaultPageSize, order: order}
+	switch {
+	case first != nil:
+		if *first < 0 || *first > maxPageSize {
+			return nil, apperror.InvalidArgument("first", "first must be between 0 and %d", maxPageSize)
+		}
+		page.size = *first
+	case last != nil:
+		if *last < 0 || *last > maxPageSize {
+			return nil, apperror.InvalidArgument("last", "last must be between 0 and %d", maxPageSize)
+		}
+		page.size = *last
+		page.backward = true
+	}
+
+	var err error
+	if after != nil {
+		if page.after, err = page.decodeCursor("after", *after); err != nil {
+			return nil, err
+		}
+	}
+	if before != nil {
+		if page.before, err = page.decodeCursor("before", *before); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// decodeCursor decodes the cursor passed in field and makes sure it was
+// issued for the same order
+func (p *pageArgs) decodeCursor(field, s string) (*pageCursor, error) {
+	c, err := decodeCursor(s)
+	if err != nil {
+		return nil, apperror.InvalidArgument(field, "%v", err)
+	}
+	if c.Field != p.order.column {
+		return nil, apperror.InvalidArgument(field, "cursor %q does not match the requested order", s)
+	}
+	switch {
+	case p.order.timestamp:
+		// Times travel as RFC 3339 strings in the cursor JSON
+		value, _ := c.Value.(string)
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, apperror.InvalidArgument(field, "invalid cursor %q", s)
+		}
+		c.Value = t
+	case p.order.ranks != nil:
+		// Ranks travel as JSON numbers
+		rank, ok := c.Value.(float64)
+		if !ok {
+			return nil, apperror.InvalidArgument(field, "invalid cursor %q", s)
+		}
+		c.Value = int(rank)
+	}
+	return c, nil
+}
+
+// predicate restricts a query to the rows between the after and before cursors
+func (p *pageArgs) predicate() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p.after != nil {
+			s.Where(p.past(s, p.after, !p.order.desc))
+		}
+		if p.before != nil {
+			s.Where(p.past(s, p.before, p.order.desc))
+		}
+	}
+}
+
+// past matches the rows positioned above (or below) the cursor in the order
+func (p *pageArgs) past(s *sql.Selector, c *pageCursor, above bool) *sql.Predicate {
+	cmp, op := sql.LT, " < "
+	if above {
+		cmp, op = sql.GT, " > "
+	}
+	if p.order.column == "" {
+		return cmp(s.C("id"), c.ID)
+	}
+
+	key := p.order.key(s)
+	compare := func(op string) *sql.Predicate {
+		return sql.P(func(b *sql.Builder) {
+			key(b)
+			b.WriteString(op).Arg(c.Value)
+		})
+	}
+	return sql.Or(
+		compare(op),
+		sql.And(
+			compare(" = "),
+			cmp(s.C("id"), c.ID),
+		),
+	)
+}
+
+// orderBy sorts rows in the direction the page is read in
+func (p *pageArgs) orderBy() func(*sql.Selector) {
+	return p.order.apply(p.backward)
+}
+
+// limit fetches one extra row so we can tell whether another page follows
+func (p *pageArgs) limit() int {
+	return p.size + 1
+}
+
+// pageResult is a single page of entities ready to be mapped to a connection
+type pageResult[T any] struct {
+	nodes      []T
+	cursors    []string
+	pageInfo   *model.PageInfo
+	totalCount int
+}
+
+// newPageResult trims the extra lookahead row, restores ascending order for
+// backward pages and computes cursors and page info
+func newPageResult[T any](p *pageArgs, nodes []T, totalCount int, cursorOf func(T) pageCursor) *pageResult[T] {
+	hasMore := len(nodes) > p.size
+	if hasMore {
+		nodes = nodes[:p.size]
+	}
+	if p.backward {
+		for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+			nodes[i], nodes[j] = nodes[j], nodes[i]
+		}
+	}
+
+	result := &pageResult[T]{
+		nodes:      nodes,
+		cursors:    make([]string, len(nodes)),
+		pageInfo:   &model.PageInfo{},
+		totalCount: totalCount,
+	}
+	for i, node := range nodes {
+		result.cursors[i] = encodeCursor(cursorOf(node))
+	}
+	if len(nodes) > 0 {
+		result.pageInfo.StartCursor = &result.cursors[0]
+		result.pageInfo.EndCursor = &result.cursors[len(nodes)-1]
+	}
+	if p.backward {
+		result.pageInfo.HasPreviousPage = hasMore
+		result.pageInfo.HasNextPage = p.before != nil
+	} else {
+		result.pageInfo.HasNextPage = hasMore
+		result.pageInfo.HasPreviousPage = p.after != nil
+	}
+
+	return result
+}
+
+// paginateTodos reads one page of the todos matched by query
+func paginateTodos(ctx context.Context, query *ent.TodoQuery, p *pageArgs) (*pageResult[*ent.Todo], error) {
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos: %w", err)
+	}
+
+	entTodos, err := query.
+		Where(predicate.Todo(p.predicate())).
+		Order(todo.OrderOption(p.orderBy())).
+		Limit(p.limit()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todos: %w", err)
+	}
+
+	return newPageResult(p, entTodos, totalCount, func(t *ent.Todo) pageCursor {
+		c := pageCursor{ID: t.ID, Field: p.order.column}
+		switch p.order.column {
+		case todo.FieldTitle:
+			c.Value = t.Title
+		case todo.FieldCompleted:
+			c.Value = t.Completed
+		case todo.FieldCreatedAt:
+			c.Value = t.CreatedAt
+		case todo.FieldUpdatedAt:
+			c.Value = t.UpdatedAt
+		case todo.FieldDueAt:
+			c.Value = p.order.value(t.DueAt)
+		case todo.FieldPriority:
+			c.Value = p.order.value(t.Priority)
+		case todo.FieldCompletedAt:
+			c.Value = p.order.value(t.CompletedAt)
+		}
+		return c
+	}), nil
+}
+
+// paginateUsers reads one page of the users matched by query
+func paginateUsers(ctx context.Context, query *ent.UserQuery, p *pageArgs) (*pageResult[*ent.User], error) {
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	entUsers, err := query.
+		Where(predicate.User(p.predicate())).
+		Order(user.OrderOption(p.orderBy())).
+		Limit(p.limit()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+
+	return newPageResult(p, entUsers, totalCount, func(u *ent.User) pageCursor {
+		c := pageCursor{ID: u.ID, Field: p.order.column}
+		switch p.order.column {
+		case user.FieldEmail:
+			c.Value = u.Email
+		case user.FieldName:
+			c.Value = u.Name
+		case user.FieldCreatedAt:
+			c.Value = u.CreatedAt
+		case user.FieldUpdatedAt:
+			c.Value = u.UpdatedAt
+		}
+		return c
+	}), nil
+}
+
+// paginateComments reads one page of the comments matched by query
+func paginateComments(ctx context.Context, query *ent.CommentQuery, p *pageArgs) (*pageResult[*ent.Comment], error) {
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	entComments, err := query.
+		Where(predicate.Comment(p.predicate())).
+		Order(comment.OrderOption(p.orderBy())).
+		Limit(p.limit()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+
+	return newPageResult(p, entComments, totalCount, func(c *ent.Comment) pageCursor {
+		cursor := pageCursor{ID: c.ID, Field: p.order.column}
+		if p.order.column == comment.FieldCreatedAt {
+			cursor.Value = c.CreatedAt
+		}
+		return cursor
+	}), nil
+}
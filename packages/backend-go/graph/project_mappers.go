@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/project"
+	"backend-go/ent/user"
+	"backend-go/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// Project data mappers for converting between database entities and GraphQL models
+//
+// Naming Convention:
+// - downstream: Database → Frontend (Ent entities → GraphQL models)
+// - upstream: Frontend → Database (GraphQL inputs → Ent operations)
+
+// =============================================================================
+// DOWNSTREAM MAPPERS (Database → Frontend)
+// =============================================================================
+
+// downstreamProjectMapper converts an Ent Project entity to a GraphQL model
+func downstreamProjectMapper(entProject *ent.Project) *model.Project {
+	// The owner and todos are resolved lazily by field resolvers
+	return &model.Project{
+		ID:          model.GlobalID("Project", entProject.ID),
+		Name:        entProject.Name,
+		Description: entProject.Description,
+		Archived:    entProject.Archived,
+		OwnerID:     entProject.OwnerID,
+		CreatedAt:   entProject.CreatedAt,
+		UpdatedAt:   entProject.UpdatedAt,
+	}
+}
+
+// =============================================================================
+// UPSTREAM MAPPERS (Frontend → Database)
+// =============================================================================
+
+// upstreamCreateProjectMapper prepares a project creation operation from
+// GraphQL input, making sure the owner exists. Projects are owned by the
+// logged in user unless the input names another owner.
+func upstreamCreateProjectMapper(ctx context.Context, client *ent.Client, input model.CreateProjectInput) (*ent.ProjectCreate, error) {
+	ownerID := input.OwnerID
+	if ownerID == nil {
+		viewer, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, auth.ErrNotLoggedIn
+		}
+		ownerID = &viewer.ID
+	}
+
+	exists, err := client.User.Query().Where(user.ID(*ownerID)).Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if !exists {
+		return nil, apperror.NotFound("user with id %s not found", *ownerID).
+			WithField("input.ownerId", "user does not exist")
+	}
+
+	return client.Project.
+		Create().
+		SetName(input.Name).
+		SetNillableDescription(input.Description).
+		SetOwnerID(*ownerID), nil
+}
+
+// upstreamUpdateProjectMapper prepares a project update operation from GraphQL input
+func upstreamUpdateProjectMapper(ctx context.Context, client *ent.Client, input model.UpdateProjectInput) *ent.ProjectUpdateOne {
+	updateQuery := client.Project.UpdateOneID(input.ID)
+
+	if input.Name != nil {
+		updateQuery = updateQuery.SetName(*input.Name)
+	}
+	// An explicit null clears the description, leaving it out keeps it
+	if value, ok := input.Description.ValueOK(); ok {
+		if value == nil {
+			updateQuery = updateQuery.ClearDescription()
+		} else {
+			updateQuery = updateQuery.SetDescription(*value)
+		}
+	}
+	if input.Archived != nil {
+		updateQuery = updateQuery.SetArchived(*input.Archived)
+	}
+
+	return updateQuery
+}
+
+// upstreamProjectMapper makes sure the project passed as input.projectId of a
+// todo mutation exists
+func upstreamProjectMapper(ctx context.Context, client *ent.Client, projectID uuid.UUID) (uuid.UUID, error) {
+	exists, err := client.Project.Query().Where(project.ID(projectID)).Exist(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up project: %w", err)
+	}
+	if !exists {
+		return uuid.Nil, apperror.NotFound("project with id %s not found", projectID).
+			WithField("input.projectId", "project does not exist")
+	}
+
+	return projectID, nil
+}
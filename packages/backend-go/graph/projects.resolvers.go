@@ -0,0 +1,176 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/project"
+	"backend-go/ent/todo"
+	"backend-go/graph/generated"
+	"backend-go/graph/loader"
+	"backend-go/graph/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreateProject is the resolver for the createProject field.
+func (r *mutationResolver) CreateProject(ctx context.Context, input model.CreateProjectInput) (*model.Project, error) {
+	// Use upstream mapper to prepare the creation operation
+	createQuery, err := upstreamCreateProjectMapper(ctx, r.client(ctx), input)
+	if err != nil {
+		return nil, err
+	}
+
+	entProject, err := createQuery.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamProjectMapper(entProject), nil
+}
+
+// UpdateProject is the resolver for the updateProject field.
+func (r *mutationResolver) UpdateProject(ctx context.Context, input model.UpdateProjectInput) (*model.Project, error) {
+	// Use upstream mapper to prepare the update operation
+	updateQuery := upstreamUpdateProjectMapper(ctx, r.client(ctx), input)
+
+	entProject, err := updateQuery.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("project with id %s not found", input.ID)
+		}
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamProjectMapper(entProject), nil
+}
+
+// DeleteProject is the resolver for the deleteProject field.
+func (r *mutationResolver) DeleteProject(ctx context.Context, id uuid.UUID) (bool, error) {
+	// The foreign key takes the todos of the project out of it
+	err := r.client(ctx).Project.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("project with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return true, nil
+}
+
+// Owner is the resolver for the owner field.
+func (r *projectResolver) Owner(ctx context.Context, obj *model.Project) (*model.User, error) {
+	if obj.OwnerID == nil {
+		return nil, nil
+	}
+
+	// Batched with the owners of every other project in the response
+	entUser, err := loader.For(ctx).UserByID.Load(ctx, *obj.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if entUser == nil {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(entUser), nil
+}
+
+// Todos is the resolver for the todos field.
+func (r *projectResolver) Todos(ctx context.Context, obj *model.Project, first *int, after *string, last *int, before *string, where *model.TodoWhereInput, orderBy *model.TodoOrder) (*model.TodoConnection, error) {
+	projectID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	page, err := newPageArgs(first, after, last, before, upstreamTodoOrderMapper(orderBy))
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike the default listings, todos are listed even if the project is archived
+	query := r.client(ctx).Todo.Query().
+		Where(todo.ProjectID(projectID)).
+		Where(upstreamTodoWhereMapper(ctx, where)...)
+	result, err := paginateTodos(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to a GraphQL connection
+	return downstreamTodoConnectionMapper(result), nil
+}
+
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, id uuid.UUID) (*model.Project, error) {
+	entProject, err := r.client(ctx).Project.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query project: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamProjectMapper(entProject), nil
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	query := r.client(ctx).Project.Query()
+	if !includeArchived {
+		query = query.Where(project.Archived(false))
+	}
+
+	entProjects, err := query.
+		Order(project.ByName(), project.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	projects := make([]*model.Project, len(entProjects))
+	for i, entProject := range entProjects {
+		projects[i] = downstreamProjectMapper(entProject)
+	}
+
+	return projects, nil
+}
+
+// Project is the resolver for the project field.
+func (r *todoResolver) Project(ctx context.Context, obj *model.Todo) (*model.Project, error) {
+	if obj.ProjectID == nil {
+		return nil, nil
+	}
+
+	// Batched with the projects of every other todo in the response
+	entProject, err := loader.For(ctx).ProjectByID.Load(ctx, *obj.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if entProject == nil {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamProjectMapper(entProject), nil
+}
+
+// Project returns generated.ProjectResolver implementation.
+func (r *Resolver) Project() generated.ProjectResolver { return &projectResolver{r} }
+
+type projectResolver struct{ *Resolver }
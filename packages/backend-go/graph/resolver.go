@@ -1,6 +1,12 @@
 package graph
 
-import "backend-go/ent"
+import (
+	"time"
+
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/pubsub"
+)
 
 // This file will not be regenerated automatically.
 //
@@ -8,4 +14,14 @@ import "backend-go/ent"
 
 type Resolver struct {
 	Client *ent.Client
+	// Broker streams the changes recorded by the database to subscriptions
+	Broker pubsub.Broker
+	// Keys sign the access tokens of sessions
+	Keys *auth.Keys
+	// DisablePasswords turns signing up and logging in with a password off,
+	// for servers where users log in with single sign-on
+	DisablePasswords bool
+	// SessionCheckInterval is how often websocket connections check that
+	// their session is still valid, auth.SessionCheckInterval if zero
+	SessionCheckInterval time.Duration
 }
@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/graph/model"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// hasRole implements the @hasRole directive, see roles.graphqls
+func hasRole(ctx context.Context, obj any, next graphql.Resolver, role model.Role) (any, error) {
+	if u, ok := obj.(*model.User); ok && isViewer(ctx, u) {
+		return next(ctx)
+	}
+	if err := auth.RequireRole(ctx, upstreamRoleMapper(role)); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// loggedIn implements the @loggedIn directive, see roles.graphqls
+func loggedIn(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil, auth.ErrNotLoggedIn
+	}
+	return next(ctx)
+}
+
+// isViewer reports whether u is the logged in user, or the one an
+// AuthPayload just logged in
+func isViewer(ctx context.Context, u *model.User) bool {
+	if viewer, ok := auth.FromContext(ctx); ok && model.GlobalID("User", viewer.ID) == u.ID {
+		return true
+	}
+	fc := graphql.GetFieldContext(ctx)
+	return fc != nil && fc.Parent != nil && fc.Parent.Object == "AuthPayload"
+}
+
+// requireGrant fails unless the request may change a membership from role
+// from to role to. Only owners make or unmake owners.
+func requireGrant(ctx context.Context, from, to membership.Role) error {
+	if from == membership.RoleOWNER || to == membership.RoleOWNER {
+		return auth.RequireRole(ctx, membership.RoleOWNER)
+	}
+	return nil
+}
+
+// requireOtherOwner fails with CONFLICT unless the workspace has an owner
+// besides the user with userID, so it never loses its last one
+func requireOtherOwner(ctx context.Context, client *ent.Client, userID uuid.UUID) error {
+	others, err := client.Membership.Query().
+		Where(membership.RoleEQ(membership.RoleOWNER), membership.UserIDNEQ(userID)).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up owners: %w", err)
+	}
+	if !others {
+		return apperror.Conflict("user with id %s is the last owner of the workspace", userID)
+	}
+	return nil
+}
+
+// requireUserOrder fails unless the request may sort users by order. Sorting
+// by email would reveal the addresses User.email hides from viewers through
+// the order and the cursors.
+func requireUserOrder(ctx context.Context, order *model.UserOrder) error {
+	if order != nil && order.Field == model.UserOrderFieldEmail {
+		return auth.RequireRole(ctx, membership.RoleMEMBER)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend-go/auth"
+	"backend-go/graph/generated"
+	"backend-go/graph/loader"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// NewServer creates the GraphQL handler for the schema implemented by resolver
+func NewServer(resolver *Resolver) *handler.Server {
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: resolver,
+		Directives: generated.DirectiveRoot{
+			HasRole:  hasRole,
+			LoggedIn: loggedIn,
+			NodeId:   typedNodeID,
+		},
+	}))
+
+	// Subscriptions are served over websockets (graphql-ws and graphql-transport-ws)
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			// The frontend dev servers run on other origins than the API
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		// Browsers can't set headers on websockets, so clients send their
+		// access token in the connection_init payload instead. Connections
+		// close when the token expires or its session ends, and clients
+		// reconnect with a fresh one.
+		InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+			token, ok := strings.CutPrefix(payload.Authorization(), "Bearer ")
+			if !ok {
+				return ctx, &payload, nil
+			}
+			interval := resolver.SessionCheckInterval
+			if interval == 0 {
+				interval = auth.SessionCheckInterval
+			}
+			ctx = transport.AppendCloseReason(ctx, "session expired, reconnect with a new access token")
+			ctx, err := auth.Watch(ctx, resolver.Client, resolver.Keys, token, interval)
+			return ctx, &payload, err
+		},
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	// Errors carry a machine readable code in their extensions, see apperror
+	srv.SetErrorPresenter(presentError)
+	srv.SetRecoverFunc(recoverPanic)
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+
+	// Mutations run in a transaction, see Transactioner
+	srv.Use(Transactioner{Client: resolver.Client})
+
+	// Every response gets its own loaders so batching and caching never span
+	// requests. Each subscription event is a separate response. Loaders read
+	// through the transaction of a mutation to see its uncommitted writes.
+	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		return next(loader.NewContext(ctx, loader.New(resolver.client(ctx))))
+	})
+
+	return srv
+}
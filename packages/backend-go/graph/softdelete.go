@@ -0,0 +1,16 @@
+package graph
+
+import (
+	"context"
+
+	"backend-go/ent/schema"
+)
+
+// withDeleted returns the context to query with, which also sees soft-deleted
+// rows when include is set
+func withDeleted(ctx context.Context, include *bool) context.Context {
+	if include != nil && *include {
+		return schema.SkipSoftDelete(ctx)
+	}
+	return ctx
+}
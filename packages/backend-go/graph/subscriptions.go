@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"backend-go/ent"
+	"backend-go/ent/assignment"
+	"backend-go/graph/model"
+	"backend-go/pubsub"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+)
+
+// subscribeTodos streams the todos of the workspace of ctx changed by op until
+// ctx is done. When userID is set, only todos that user is one of the
+// assignees of are streamed.
+func (r *Resolver) subscribeTodos(ctx context.Context, op pubsub.Op, userID *uuid.UUID) (<-chan *model.Todo, error) {
+	// Events come from every workspace, the feed doesn't go through ent
+	workspaceID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoWorkspace
+	}
+
+	events, err := r.Broker.Subscribe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to todo events: %w", err)
+	}
+
+	todos := make(chan *model.Todo)
+	go func() {
+		defer close(todos)
+		for event := range events {
+			if event.Todo == nil || event.Op != op || event.Todo.WorkspaceID != workspaceID {
+				continue
+			}
+			if userID != nil && !r.isAssigned(ctx, event.Todo, *userID) {
+				continue
+			}
+
+			select {
+			case todos <- downstreamTodoMapper(event.Todo):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return todos, nil
+}
+
+// isAssigned reports whether userID is one of the assignees of entTodo. Events
+// only carry the todo row, so the assignees are looked up; purged todos are
+// left with their first assignee.
+func (r *Resolver) isAssigned(ctx context.Context, entTodo *ent.Todo, userID uuid.UUID) bool {
+	if entTodo.UserID != nil && *entTodo.UserID == userID {
+		return true
+	}
+
+	assigned, err := r.Client.Assignment.Query().
+		Where(assignment.TodoID(entTodo.ID), assignment.UserID(userID)).
+		Exist(ctx)
+	if err != nil {
+		log.Printf("failed to look up assignees of todo %s: %v", entTodo.ID, err)
+		return false
+	}
+	return assigned
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/todo"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+)
+
+// completeSubtasks completes the open todos below the todo with id, however
+// deeply nested. It fails with FORBIDDEN unless the viewer may edit every one
+// of them, so either all of them are completed or none.
+func completeSubtasks(ctx context.Context, client *ent.Client, id uuid.UUID) error {
+	// Walk down one level of subtasks per query
+	seen := map[uuid.UUID]bool{id: true}
+	var openIDs []uuid.UUID
+	for level := []uuid.UUID{id}; len(level) > 0; {
+		subtasks, err := client.Todo.Query().
+			Where(todo.ParentIDIn(level...)).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query subtasks: %w", err)
+		}
+
+		level = level[:0]
+		for _, subtask := range subtasks {
+			if seen[subtask.ID] {
+				continue
+			}
+			seen[subtask.ID] = true
+			level = append(level, subtask.ID)
+			if !subtask.Completed {
+				openIDs = append(openIDs, subtask.ID)
+			}
+		}
+	}
+	if len(openIDs) == 0 {
+		return nil
+	}
+
+	// The privacy policy leaves out the subtasks the viewer can't edit
+	n, err := client.Todo.Update().
+		Where(todo.IDIn(openIDs...)).
+		SetCompleted(true).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to complete subtasks: %w", err)
+	}
+	if n < len(openIDs) {
+		return apperror.Forbidden("not allowed to complete every subtask")
+	}
+	return nil
+}
+
+// lockHierarchy serializes changes to the subtask hierarchy of the workspace
+// of ctx until the transaction of the request ends. Cycle checks read the
+// ancestors of a todo before it moves, so two concurrent moves could each
+// pass them and form a cycle together. Updating the workspace row locks it,
+// on every database.
+func lockHierarchy(ctx context.Context, client *ent.Client) error {
+	workspaceID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrNoWorkspace
+	}
+
+	err := client.Workspace.UpdateOneID(workspaceID).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to lock subtasks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/tag"
+	"backend-go/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// Tag data mappers for converting between database entities and GraphQL models
+//
+// Naming Convention:
+// - downstream: Database → Frontend (Ent entities → GraphQL models)
+// - upstream: Frontend → Database (GraphQL inputs → Ent operations)
+
+// =============================================================================
+// DOWNSTREAM MAPPERS (Database → Frontend)
+// =============================================================================
+
+// downstreamTagMapper converts an Ent Tag entity to a GraphQL model
+func downstreamTagMapper(entTag *ent.Tag) *model.Tag {
+	// Todos are resolved lazily by the Tag.todos field resolver
+	return &model.Tag{
+		ID:        model.GlobalID("Tag", entTag.ID),
+		Name:      entTag.Name,
+		Color:     entTag.Color,
+		CreatedAt: entTag.CreatedAt,
+		UpdatedAt: entTag.UpdatedAt,
+	}
+}
+
+// =============================================================================
+// UPSTREAM MAPPERS (Frontend → Database)
+// =============================================================================
+
+// upstreamCreateTagMapper prepares a tag creation operation from GraphQL input
+func upstreamCreateTagMapper(ctx context.Context, client *ent.Client, input model.CreateTagInput) *ent.TagCreate {
+	createQuery := client.Tag.
+		Create().
+		SetName(input.Name)
+
+	if input.Color != nil {
+		createQuery = createQuery.SetColor(*input.Color)
+	}
+
+	return createQuery
+}
+
+// upstreamUpdateTagMapper prepares a tag update operation from GraphQL input
+func upstreamUpdateTagMapper(ctx context.Context, client *ent.Client, input model.UpdateTagInput) *ent.TagUpdateOne {
+	updateQuery := client.Tag.UpdateOneID(input.ID)
+
+	if input.Name != nil {
+		updateQuery = updateQuery.SetName(*input.Name)
+	}
+	if input.Color != nil {
+		updateQuery = updateQuery.SetColor(*input.Color)
+	}
+
+	return updateQuery
+}
+
+// upstreamTagIDsMapper makes sure every tag passed as tagIds of a todo
+// mutation exists
+func upstreamTagIDsMapper(ctx context.Context, client *ent.Client, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	existing, err := client.Tag.Query().Where(tag.IDIn(tagIDs...)).IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up tags: %w", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	for i, id := range tagIDs {
+		if !found[id] {
+			return nil, apperror.NotFound("tag with id %s not found", id).
+				WithField(fmt.Sprintf("tagIds[%d]", i), "tag does not exist")
+		}
+	}
+
+	return tagIDs, nil
+}
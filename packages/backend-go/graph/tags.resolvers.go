@@ -0,0 +1,183 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/ent"
+	"backend-go/ent/tag"
+	"backend-go/ent/todo"
+	"backend-go/graph/generated"
+	"backend-go/graph/loader"
+	"backend-go/graph/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreateTag is the resolver for the createTag field.
+func (r *mutationResolver) CreateTag(ctx context.Context, input model.CreateTagInput) (*model.Tag, error) {
+	// Use upstream mapper to prepare the creation operation
+	createQuery := upstreamCreateTagMapper(ctx, r.client(ctx), input)
+
+	entTag, err := createQuery.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTagMapper(entTag), nil
+}
+
+// UpdateTag is the resolver for the updateTag field.
+func (r *mutationResolver) UpdateTag(ctx context.Context, input model.UpdateTagInput) (*model.Tag, error) {
+	// Use upstream mapper to prepare the update operation
+	updateQuery := upstreamUpdateTagMapper(ctx, r.client(ctx), input)
+
+	entTag, err := updateQuery.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("tag with id %s not found", input.ID)
+		}
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTagMapper(entTag), nil
+}
+
+// DeleteTag is the resolver for the deleteTag field.
+func (r *mutationResolver) DeleteTag(ctx context.Context, id uuid.UUID) (bool, error) {
+	// The todo_tags rows of the tag go with it through their foreign key
+	err := r.client(ctx).Tag.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("tag with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return true, nil
+}
+
+// AddTagsToTodo is the resolver for the addTagsToTodo field.
+func (r *mutationResolver) AddTagsToTodo(ctx context.Context, todoID uuid.UUID, tagIds []uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the tags
+	tagIDs, err := upstreamTagIDsMapper(ctx, r.client(ctx), tagIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		AddTagIDs(tagIDs...).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to tag todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// RemoveTagsFromTodo is the resolver for the removeTagsFromTodo field.
+func (r *mutationResolver) RemoveTagsFromTodo(ctx context.Context, todoID uuid.UUID, tagIds []uuid.UUID) (*model.Todo, error) {
+	// Use upstream mapper to check the tags
+	tagIDs, err := upstreamTagIDsMapper(ctx, r.client(ctx), tagIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updating the todo bumps its version and announces the change
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(todoID).
+		RemoveTagIDs(tagIDs...).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("todo with id %s not found", todoID)
+		}
+		return nil, fmt.Errorf("failed to untag todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context) ([]*model.Tag, error) {
+	entTags, err := r.client(ctx).Tag.Query().
+		Order(tag.ByName(), tag.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	tags := make([]*model.Tag, len(entTags))
+	for i, entTag := range entTags {
+		tags[i] = downstreamTagMapper(entTag)
+	}
+
+	return tags, nil
+}
+
+// Todos is the resolver for the todos field.
+func (r *tagResolver) Todos(ctx context.Context, obj *model.Tag, first *int, after *string, last *int, before *string, where *model.TodoWhereInput, orderBy *model.TodoOrder) (*model.TodoConnection, error) {
+	tagID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag ID: %w", err)
+	}
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	page, err := newPageArgs(first, after, last, before, upstreamTodoOrderMapper(orderBy))
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.client(ctx).Todo.Query().
+		Where(todo.HasTagsWith(tag.ID(tagID))).
+		Where(upstreamTodoListingMapper(ctx, where)...)
+	result, err := paginateTodos(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to a GraphQL connection
+	return downstreamTodoConnectionMapper(result), nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *todoResolver) Tags(ctx context.Context, obj *model.Todo) ([]*model.Tag, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	// Batched with the tags of every other todo in the response
+	entTags, err := loader.For(ctx).TagsByTodoID.Load(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	tags := make([]*model.Tag, len(entTags))
+	for i, entTag := range entTags {
+		tags[i] = downstreamTagMapper(entTag)
+	}
+
+	return tags, nil
+}
+
+// Tag returns generated.TagResolver implementation.
+func (r *Resolver) Tag() generated.TagResolver { return &tagResolver{r} }
+
+type tagResolver struct{ *Resolver }
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/ent/todo"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssigneesAndWatchers(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	alice := client.User.Create().SetEmail("alice@example.com").SetName("Alice").SaveX(ctx)
+	bob := client.User.Create().SetEmail("bob@example.com").SetName("Bob").SaveX(ctx)
+	carol := client.User.Create().SetEmail("carol@example.com").SetName("Carol").SaveX(ctx)
+	review := client.Todo.Create().SetTitle("Pair on review").SaveX(ctx)
+	client.Todo.Create().SetTitle("Nobody's chore").SaveX(ctx)
+
+	todoQuery := `
+		query Todo($id: ID!) {
+			node(id: $id) {
+				... on Todo {
+					userId
+					user { name }
+					assignees { name }
+					watchers { name }
+				}
+			}
+		}
+	`
+
+	// names returns the names of a list of users in a response
+	names := func(users interface{}) []string {
+		result := []string{}
+		for _, u := range users.([]interface{}) {
+			result = append(result, u.(map[string]interface{})["name"].(string))
+		}
+		return result
+	}
+
+	// fetch returns the assignee view of the review todo
+	fetch := func(t *testing.T) map[string]interface{} {
+		resp := testutil.ExecuteGraphQL(t, client, todoQuery, map[string]interface{}{"id": model.GlobalID("Todo", review.ID)})
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["node"].(map[string]interface{})
+	}
+
+	// mutate runs a mutation on the review todo that is expected to succeed
+	mutate := func(t *testing.T, query string, variables map[string]interface{}) {
+		variables["todoId"] = review.ID.String()
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+	}
+
+	assign := `
+		mutation Assign($todoId: UUID!, $userIds: [UUID!]!) {
+			assignTodo(todoId: $todoId, userIds: $userIds) { id }
+		}
+	`
+	unassign := `
+		mutation Unassign($todoId: UUID!, $userIds: [UUID!]!) {
+			unassignTodo(todoId: $todoId, userIds: $userIds) { id }
+		}
+	`
+
+	t.Run("assigns users in order", func(t *testing.T) {
+		mutate(t, assign, map[string]interface{}{"userIds": []string{alice.ID.String()}})
+		mutate(t, assign, map[string]interface{}{"userIds": []string{bob.ID.String(), alice.ID.String()}})
+		mutate(t, assign, map[string]interface{}{"userIds": []string{carol.ID.String()}})
+
+		data := fetch(t)
+		assert.Equal(t, []string{"Alice", "Bob", "Carol"}, names(data["assignees"]))
+		assert.Equal(t, alice.ID.String(), data["userId"])
+		assert.Equal(t, "Alice", data["user"].(map[string]interface{})["name"])
+	})
+
+	t.Run("unassigning the first assignee moves user along", func(t *testing.T) {
+		mutate(t, unassign, map[string]interface{}{"userIds": []string{alice.ID.String()}})
+
+		data := fetch(t)
+		assert.Equal(t, []string{"Bob", "Carol"}, names(data["assignees"]))
+		assert.Equal(t, bob.ID.String(), data["userId"])
+	})
+
+	t.Run("filters by assignee", func(t *testing.T) {
+		titles := func(where map[string]interface{}) []interface{} {
+			resp := testutil.ExecuteGraphQL(t, client, `
+				query Todos($where: TodoWhereInput) {
+					todos(where: $where) { title }
+				}
+			`, map[string]interface{}{"where": where})
+			require.Empty(t, resp.Errors)
+			return resp.Data.(map[string]interface{})["todos"].([]interface{})
+		}
+
+		assert.Len(t, titles(map[string]interface{}{"assigneeId": carol.ID.String()}), 1)
+		assert.Empty(t, titles(map[string]interface{}{"assigneeId": alice.ID.String()}))
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Nobody's chore"}},
+			titles(map[string]interface{}{"hasAssignees": false}))
+		// The deprecated filter only matches the first assignee
+		assert.Empty(t, titles(map[string]interface{}{"userIdIn": []string{carol.ID.String()}}))
+	})
+
+	t.Run("filters by the todos assigned to the viewer", func(t *testing.T) {
+		srv := testutil.CreateGraphQLServer(client)
+		query := `
+			query Todos($where: TodoWhereInput) {
+				todos(where: $where) { title }
+			}
+		`
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, testutil.ContextAs(client, carol), query,
+			map[string]interface{}{"where": map[string]interface{}{"assignedToMe": true}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Pair on review"}}, resp.Data.(map[string]interface{})["todos"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, testutil.ContextAs(client, carol), query,
+			map[string]interface{}{"where": map[string]interface{}{"assignedToMe": false}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Nobody's chore"}}, resp.Data.(map[string]interface{})["todos"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, testutil.ContextAs(client, alice), query,
+			map[string]interface{}{"where": map[string]interface{}{"assignedToMe": true}})
+		require.Empty(t, resp.Errors)
+		assert.Empty(t, resp.Data.(map[string]interface{})["todos"])
+
+		// Anonymous requests have no todos of their own
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"where": map[string]interface{}{"assignedToMe": true}})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("userId makes a user the only assignee", func(t *testing.T) {
+		mutate(t, `
+			mutation Update($todoId: UUID!, $userId: UUID) {
+				updateTodo(input: {id: $todoId, userId: $userId}) { id }
+			}
+		`, map[string]interface{}{"userId": alice.ID.String()})
+		data := fetch(t)
+		assert.Equal(t, []string{"Alice"}, names(data["assignees"]))
+		assert.Equal(t, alice.ID.String(), data["userId"])
+
+		mutate(t, `
+			mutation Update($todoId: UUID!) {
+				updateTodo(input: {id: $todoId, userId: null}) { id }
+			}
+		`, map[string]interface{}{})
+		data = fetch(t)
+		assert.Empty(t, data["assignees"])
+		assert.Nil(t, data["userId"])
+	})
+
+	t.Run("creating with userId assigns the user", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation Create($userId: UUID) {
+				createTodo(input: {title: "Legacy client", userId: $userId}) {
+					assignees { name }
+				}
+			}
+		`, map[string]interface{}{"userId": bob.ID.String()})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []string{"Bob"}, names(resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})["assignees"]))
+	})
+
+	t.Run("unknown users are NOT_FOUND", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, assign, map[string]interface{}{
+			"todoId":  review.ID.String(),
+			"userIds": []string{alice.ID.String(), uuid.New().String()},
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "userIds[1]", resp.Errors[0].Extensions["fields"].([]interface{})[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("watches and unwatches", func(t *testing.T) {
+		watch := `
+			mutation Watch($todoId: UUID!, $userId: UUID!) {
+				watchTodo(todoId: $todoId, userId: $userId) { id }
+			}
+		`
+		mutate(t, watch, map[string]interface{}{"userId": carol.ID.String()})
+		mutate(t, watch, map[string]interface{}{"userId": bob.ID.String()})
+		mutate(t, watch, map[string]interface{}{"userId": bob.ID.String()})
+		assert.Equal(t, []string{"Bob", "Carol"}, names(fetch(t)["watchers"]))
+
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query Todos($watcherId: UUID) {
+				todos(where: {watcherId: $watcherId}) { title }
+			}
+		`, map[string]interface{}{"watcherId": bob.ID.String()})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Pair on review"}}, resp.Data.(map[string]interface{})["todos"])
+
+		mutate(t, `
+			mutation Unwatch($todoId: UUID!, $userId: UUID!) {
+				unwatchTodo(todoId: $todoId, userId: $userId) { id }
+			}
+		`, map[string]interface{}{"userId": bob.ID.String()})
+		assert.Equal(t, []string{"Carol"}, names(fetch(t)["watchers"]))
+	})
+
+	t.Run("bulk updates can't change assignees", func(t *testing.T) {
+		_, err := client.Todo.Update().Where(todo.ID(review.ID)).SetUserID(alice.ID).Save(ctx)
+		assert.Error(t, err)
+	})
+}
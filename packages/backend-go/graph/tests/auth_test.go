@@ -0,0 +1,563 @@
+package tests
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/deniedsession"
+	"backend-go/ent/hook"
+	"backend-go/ent/membership"
+	"backend-go/ent/user"
+	"backend-go/graph/tests/testutil"
+	"backend-go/tenant"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordAuth(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	srv := testutil.CreateGraphQLServer(client)
+	ctx := testutil.Context(client)
+	keys := testutil.Keys(client)
+
+	// as returns a context acting as the user of accessToken
+	as := func(t *testing.T, accessToken string) context.Context {
+		authCtx, err := auth.Authenticate(ctx, client, keys, accessToken)
+		require.NoError(t, err)
+		return authCtx
+	}
+
+	// payload returns the AuthPayload of a response to a mutation
+	payload := func(t *testing.T, resp *testutil.GraphQLResponse, field string) map[string]interface{} {
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})[field].(map[string]interface{})
+	}
+
+	login := `
+		mutation Login($email: String!, $password: String!) {
+			login(input: {email: $email, password: $password}) {
+				accessToken
+				refreshToken
+				user { email }
+			}
+		}
+	`
+
+	var accessToken, refreshToken string
+
+	t.Run("signs up", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `
+			mutation {
+				signUp(input: {email: "ada@example.com", name: "Ada", password: "correct horse"}) {
+					accessToken
+					refreshToken
+					expiresAt
+					user { email }
+				}
+			}
+		`, nil)
+		data := payload(t, resp, "signUp")
+		assert.Equal(t, "ada@example.com", data["user"].(map[string]interface{})["email"])
+		assert.NotEmpty(t, data["accessToken"])
+		assert.NotEmpty(t, data["expiresAt"])
+
+		// Only a hash of the password is stored
+		ada := client.User.Query().Where(user.Email("ada@example.com")).OnlyX(tenant.System(ctx))
+		require.NotNil(t, ada.PasswordHash)
+		assert.True(t, strings.HasPrefix(*ada.PasswordHash, "$argon2id$"))
+
+		// Signing up joins no workspace
+		assert.False(t, client.Membership.Query().Where(membership.UserID(ada.ID)).ExistX(ctx))
+		client.Membership.Create().SetUserID(ada.ID).ExecX(ctx)
+	})
+
+	t.Run("rejects short passwords", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `
+			mutation {
+				signUp(input: {email: "bob@example.com", name: "Bob", password: "short"}) { accessToken }
+			}
+		`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("stores emails in lower case", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `
+			mutation {
+				signUp(input: {email: "Ada@Example.com", name: "Ada", password: "correct horse"}) { accessToken }
+			}
+		`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `
+			mutation {
+				createUser(input: {email: "Grace@Example.com", name: "Grace"}) { email }
+			}
+		`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, "grace@example.com", resp.Data.(map[string]interface{})["createUser"].(map[string]interface{})["email"])
+	})
+
+	t.Run("logs in", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, login, map[string]interface{}{
+			"email":    "ADA@example.com",
+			"password": "correct horse",
+		})
+		data := payload(t, resp, "login")
+		accessToken = data["accessToken"].(string)
+		refreshToken = data["refreshToken"].(string)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), `{ viewer { email } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"email": "ada@example.com"}, resp.Data.(map[string]interface{})["viewer"])
+	})
+
+	t.Run("wrong passwords are UNAUTHENTICATED", func(t *testing.T) {
+		for _, email := range []string{"ada@example.com", "nobody@example.com"} {
+			resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, login, map[string]interface{}{
+				"email":    email,
+				"password": "battery staple",
+			})
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+		}
+	})
+
+	t.Run("never exposes the password hash", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), `{ viewer { passwordHash } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "GRAPHQL_VALIDATION_FAILED", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("anonymous requests have no viewer", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `{ viewer { email } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["viewer"])
+	})
+
+	t.Run("refreshes sessions", func(t *testing.T) {
+		refresh := `
+			mutation Refresh($refreshToken: String!) {
+				refreshSession(refreshToken: $refreshToken) { accessToken refreshToken }
+			}
+		`
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, refresh, map[string]interface{}{"refreshToken": refreshToken})
+		data := payload(t, resp, "refreshSession")
+		assert.NotEqual(t, accessToken, data["accessToken"])
+
+		// The old refresh token was replaced
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, refresh, map[string]interface{}{"refreshToken": refreshToken})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+
+		accessToken = data["accessToken"].(string)
+		refreshToken = data["refreshToken"].(string)
+	})
+
+	t.Run("changes passwords", func(t *testing.T) {
+		change := `
+			mutation Change($current: String!, $new: String!) {
+				changePassword(input: {currentPassword: $current, newPassword: $new})
+			}
+		`
+		other := payload(t, testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, login, map[string]interface{}{
+			"email":    "ada@example.com",
+			"password": "correct horse",
+		}), "login")["accessToken"].(string)
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), change, map[string]interface{}{
+			"current": "battery staple",
+			"new":     "battery staple",
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), change, map[string]interface{}{
+			"current": "correct horse",
+			"new":     "battery staple",
+		})
+		require.Empty(t, resp.Errors)
+
+		// Other sessions end, the one that changed the password stays
+		_, err := auth.Authenticate(ctx, client, keys, other)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		as(t, accessToken)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, login, map[string]interface{}{
+			"email":    "ada@example.com",
+			"password": "battery staple",
+		})
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("creating a workspace joins it", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), `
+			mutation {
+				createWorkspace(input: {name: "Ada's"}) { id }
+			}
+		`, nil)
+		id := payload(t, resp, "createWorkspace")["id"].(string)
+
+		workspaceCtx := tenant.NewContext(context.Background(), uuid.MustParse(id))
+		_, err := auth.Authenticate(workspaceCtx, client, keys, accessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("logs out", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, as(t, accessToken), `mutation { logout }`, nil)
+		require.Empty(t, resp.Errors)
+
+		_, err := auth.Authenticate(ctx, client, keys, accessToken)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `mutation { logout }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("sets up the passwords of users created by admins", func(t *testing.T) {
+		margaret := client.User.Create().SetEmail("margaret@example.com").SetName("Margaret").SaveX(ctx)
+		setup := fmt.Sprintf(`mutation { createPasswordSetup(userId: "%s") { token expiresAt } }`, margaret.ID)
+		setUp := `
+			mutation SetUp($token: String!) {
+				setUpPassword(input: {token: $token, password: "correct horse"}) { accessToken }
+			}
+		`
+
+		// Only the latest token works
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setup, nil)
+		require.Empty(t, resp.Errors)
+		stale := resp.Data.(map[string]interface{})["createPasswordSetup"].(map[string]interface{})["token"]
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setup, nil)
+		require.Empty(t, resp.Errors)
+		token := resp.Data.(map[string]interface{})["createPasswordSetup"].(map[string]interface{})["token"]
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setUp, map[string]interface{}{"token": stale})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setUp, map[string]interface{}{"token": token})
+		require.Empty(t, resp.Errors)
+
+		// The token works once, and users with a password get none
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setUp, map[string]interface{}{"token": token})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, setup, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, login, map[string]interface{}{
+			"email":    "margaret@example.com",
+			"password": "correct horse",
+		})
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("doesn't set up the passwords of members of other workspaces", func(t *testing.T) {
+		alan := client.User.Create().SetEmail("alan@example.com").SetName("Alan").SaveX(ctx)
+		other := client.Workspace.Create().SetName("Elsewhere").SaveX(context.Background())
+		client.Membership.Create().SetUserID(alan.ID).ExecX(testutil.ContextIn(other.ID))
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, fmt.Sprintf(`
+			mutation { createPasswordSetup(userId: "%s") { token } }
+		`, alan.ID), nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("users without a password set one", func(t *testing.T) {
+		edsger := client.User.Create().SetEmail("edsger@example.com").SetName("Edsger").SaveX(ctx)
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, testutil.ContextAs(client, edsger), `
+			mutation { changePassword(input: {newPassword: "correct horse"}) }
+		`, nil)
+		require.Empty(t, resp.Errors)
+
+		// From then on they need to know it
+		edsger = client.User.GetX(ctx, edsger.ID)
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, testutil.ContextAs(client, edsger), `
+			mutation { changePassword(input: {newPassword: "battery staple"}) }
+		`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+}
+
+func TestRefreshTokenReuse(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	member, _ := testutil.SeedTestData(t, client)
+	keys := testutil.Keys(client)
+
+	tokens, err := auth.OpenSession(ctx, client, keys, member)
+	require.NoError(t, err)
+
+	// Another refresh with the same token wins the race against the next one
+	raced := false
+	client.Session.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.SessionFunc(func(ctx context.Context, m *ent.SessionMutation) (ent.Value, error) {
+			if _, ok := m.RefreshTokenHash(); ok && m.Op().Is(ent.OpUpdate) && !raced {
+				raced = true
+				_, err := auth.RefreshSession(ctx, client, keys, tokens.RefreshToken)
+				require.NoError(t, err)
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	_, err = auth.RefreshSession(ctx, client, keys, tokens.RefreshToken)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	require.True(t, raced)
+
+	// Both clients are logged out, as one of them stole the token
+	s := client.Session.Query().OnlyX(ctx)
+	assert.NotNil(t, s.RevokedAt)
+	_, err = auth.Authenticate(ctx, client, keys, tokens.AccessToken)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	member, _ := testutil.SeedTestData(t, client)
+	outsider := client.User.Create().SetEmail("outsider@example.com").SetName("Outsider").SaveX(tenant.Detach(ctx))
+
+	// token opens a session for u
+	token := func(t *testing.T, u *ent.User) string {
+		tokens, err := auth.OpenSession(ctx, client, testutil.Keys(client), u)
+		require.NoError(t, err)
+		return tokens.AccessToken
+	}
+
+	srv := testutil.CreateGraphQLServer(client)
+
+	// status returns the status of a request with the Authorization header
+	status := func(t *testing.T, authorization string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"query": "{ viewer { email } }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(tenant.Header, testutil.Workspace(client).ID.String())
+		req.Header.Set("Authorization", authorization)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, status(t, "Bearer "+token(t, member)))
+	assert.Equal(t, http.StatusUnauthorized, status(t, "Bearer not-a-token"))
+	assert.Equal(t, http.StatusUnauthorized, status(t, "Basic "+token(t, member)))
+	assert.Equal(t, http.StatusForbidden, status(t, "Bearer "+token(t, outsider)))
+}
+
+func TestAccessTokens(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	member, _ := testutil.SeedTestData(t, client)
+	keys := testutil.Keys(client)
+
+	// jwks fetches the published key set
+	jwks := func(t *testing.T) *jose.JSONWebKeySet {
+		rec := httptest.NewRecorder()
+		keys.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var set jose.JSONWebKeySet
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+		return &set
+	}
+
+	tokens, err := auth.OpenSession(ctx, client, keys, member)
+	require.NoError(t, err)
+
+	t.Run("are JWTs verifiable with the published keys", func(t *testing.T) {
+		parsed, err := jwt.ParseSigned(tokens.AccessToken, []jose.SignatureAlgorithm{jose.EdDSA})
+		require.NoError(t, err)
+		published := jwks(t).Key(parsed.Headers[0].KeyID)
+		require.Len(t, published, 1)
+
+		var claims auth.Claims
+		require.NoError(t, parsed.Claims(published[0].Key, &claims))
+		assert.Equal(t, auth.Issuer, claims.Issuer)
+		assert.Equal(t, member.ID.String(), claims.Subject)
+		require.NotNil(t, claims.WorkspaceID)
+		assert.Equal(t, testutil.Workspace(client).ID, *claims.WorkspaceID)
+		assert.Equal(t, tokens.AccessExpiresAt, claims.Expiry.Time())
+	})
+
+	t.Run("act in the workspace they were issued in", func(t *testing.T) {
+		authCtx, err := auth.Authenticate(context.Background(), client, keys, tokens.AccessToken)
+		require.NoError(t, err)
+		workspaceID, ok := tenant.FromContext(authCtx)
+		require.True(t, ok)
+		assert.Equal(t, testutil.Workspace(client).ID, workspaceID)
+
+		// Tokens issued outside of a workspace carry none
+		bare, err := auth.OpenSession(context.Background(), client, keys, member)
+		require.NoError(t, err)
+		authCtx, err = auth.Authenticate(context.Background(), client, keys, bare.AccessToken)
+		require.NoError(t, err)
+		_, ok = tenant.FromContext(authCtx)
+		assert.False(t, ok)
+	})
+
+	t.Run("are accepted by every instance", func(t *testing.T) {
+		other, err := auth.NewKeys(client, testutil.KeySecret)
+		require.NoError(t, err)
+		_, err = auth.Authenticate(ctx, client, other, tokens.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("stores signing keys encrypted", func(t *testing.T) {
+		parsed, err := jwt.ParseSigned(tokens.AccessToken, []jose.SignatureAlgorithm{jose.EdDSA})
+		require.NoError(t, err)
+		stored := client.SigningKey.GetX(ctx, uuid.MustParse(parsed.Headers[0].KeyID))
+		published := jwks(t).Key(parsed.Headers[0].KeyID)
+		require.Len(t, published, 1)
+		assert.NotContains(t, string(stored.PrivateKey), string(published[0].Key.(ed25519.PublicKey)))
+
+		// Instances with another secret can't use them
+		other, err := auth.NewKeys(client, "another secret")
+		require.NoError(t, err)
+		_, err = auth.Authenticate(ctx, client, other, tokens.AccessToken)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("loads keys again at most every few seconds for unknown key IDs", func(t *testing.T) {
+		other, err := auth.NewKeys(client, testutil.KeySecret)
+		require.NoError(t, err)
+		_, err = auth.Authenticate(ctx, client, other, tokens.AccessToken)
+		require.NoError(t, err)
+
+		// A key another instance generated since is only picked up later
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		sealed, err := keys.Seal(private)
+		require.NoError(t, err)
+		newer := client.SigningKey.Create().SetPrivateKey(sealed).SaveX(ctx)
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.EdDSA, Key: private},
+			(&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), newer.ID.String()),
+		)
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(auth.Claims{
+			Claims: jwt.Claims{
+				Issuer:  auth.Issuer,
+				Subject: member.ID.String(),
+				Expiry:  jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+			SessionID: uuid.New(),
+		}).Serialize()
+		require.NoError(t, err)
+
+		_, err = auth.Authenticate(ctx, client, other, token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		fresh, err := auth.NewKeys(client, testutil.KeySecret)
+		require.NoError(t, err)
+		_, err = auth.Authenticate(ctx, client, fresh, token)
+		assert.NoError(t, err)
+
+		client.SigningKey.DeleteOne(newer).ExecX(ctx)
+	})
+
+	t.Run("rejects tampered tokens", func(t *testing.T) {
+		parts := strings.Split(tokens.AccessToken, ".")
+		other, err := auth.OpenSession(ctx, client, keys, member)
+		require.NoError(t, err)
+		forged := parts[0] + "." + strings.Split(other.AccessToken, ".")[1] + "." + parts[2]
+
+		_, err = auth.Authenticate(ctx, client, keys, forged)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("denies tokens of logged out sessions", func(t *testing.T) {
+		authCtx, err := auth.Authenticate(ctx, client, keys, tokens.AccessToken)
+		require.NoError(t, err)
+		sessionID, _ := auth.SessionFromContext(authCtx)
+		require.NoError(t, auth.RevokeSession(ctx, client, sessionID))
+
+		_, err = auth.Authenticate(ctx, client, keys, tokens.AccessToken)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		assert.True(t, client.DeniedSession.Query().Where(deniedsession.ID(sessionID)).ExistX(ctx))
+	})
+
+	t.Run("rotates signing keys", func(t *testing.T) {
+		// Start over with a key that signed for too long
+		client.SigningKey.Delete().ExecX(ctx)
+		now := time.Now()
+		_, retiredKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		sealed, err := keys.Seal(retiredKey)
+		require.NoError(t, err)
+		retired := client.SigningKey.Create().
+			SetPrivateKey(sealed).
+			SetCreatedAt(now.Add(-auth.KeyRotationInterval - time.Minute)).
+			SaveX(ctx)
+		expired := client.SigningKey.Create().
+			SetPrivateKey(sealed).
+			SetCreatedAt(now.Add(-auth.KeyRotationInterval - auth.AccessTokenTTL - time.Minute)).
+			SaveX(ctx)
+
+		// A token the retired key signed while it was current
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.EdDSA, Key: retiredKey},
+			(&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), retired.ID.String()),
+		)
+		require.NoError(t, err)
+		old, err := jwt.Signed(signer).Claims(auth.Claims{
+			Claims: jwt.Claims{
+				Issuer:  auth.Issuer,
+				Subject: member.ID.String(),
+				Expiry:  jwt.NewNumericDate(now.Add(time.Minute)),
+			},
+			SessionID: uuid.New(),
+		}).Serialize()
+		require.NoError(t, err)
+
+		require.NoError(t, keys.Rotate(ctx))
+
+		// A new key took over, the retired one verifies until its tokens
+		// expired
+		set := jwks(t)
+		require.Len(t, set.Keys, 2)
+		assert.NotEqual(t, retired.ID.String(), set.Keys[0].KeyID)
+		assert.Equal(t, retired.ID.String(), set.Keys[1].KeyID)
+		assert.Empty(t, set.Key(expired.ID.String()))
+
+		_, err = auth.Authenticate(ctx, client, keys, old)
+		assert.NoError(t, err)
+
+		fresh, err := auth.OpenSession(ctx, client, keys, member)
+		require.NoError(t, err)
+		parsed, err := jwt.ParseSigned(fresh.AccessToken, []jose.SignatureAlgorithm{jose.EdDSA})
+		require.NoError(t, err)
+		assert.Equal(t, set.Keys[0].KeyID, parsed.Headers[0].KeyID)
+	})
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend-go/ent/schema"
+	"backend-go/graph/tests/testutil"
+	"backend-go/pubsub"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeChangeFeed(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(testutil.Context(client))
+	defer cancel()
+
+	events, err := testutil.ChangeFeed(client).Subscribe(ctx)
+	require.NoError(t, err)
+
+	next := func(t *testing.T) pubsub.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for change event")
+		}
+		return pubsub.Event{}
+	}
+
+	assertNoEvent := func(t *testing.T) {
+		select {
+		case event := <-events:
+			t.Fatalf("unexpected change event: %+v", event)
+		default:
+		}
+	}
+
+	t.Run("records writes made outside of resolvers", func(t *testing.T) {
+		user := client.User.Create().SetEmail("feed@example.com").SetName("Feed").SaveX(ctx)
+		event := next(t)
+		assert.Equal(t, pubsub.OpCreate, event.Op)
+		require.NotNil(t, event.User)
+		assert.Equal(t, user.ID, event.User.ID)
+
+		todo := client.Todo.Create().SetTitle("Outside").SetUser(user).SaveX(ctx)
+		event = next(t)
+		assert.Equal(t, pubsub.OpCreate, event.Op)
+		require.NotNil(t, event.Todo)
+		assert.Equal(t, todo.ID, event.Todo.ID)
+
+		client.Todo.UpdateOne(todo).SetCompleted(true).ExecX(ctx)
+		event = next(t)
+		assert.Equal(t, pubsub.OpUpdate, event.Op)
+		assert.True(t, event.Todo.Completed)
+
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+		event = next(t)
+		assert.Equal(t, pubsub.OpDelete, event.Op)
+		assert.Equal(t, "Outside", event.Todo.Title)
+	})
+
+	t.Run("emits one event per row of bulk updates", func(t *testing.T) {
+		client.Todo.Create().SetTitle("Bulk 1").ExecX(ctx)
+		client.Todo.Create().SetTitle("Bulk 2").ExecX(ctx)
+		next(t)
+		next(t)
+
+		client.Todo.Update().SetCompleted(true).ExecX(ctx)
+		titles := []string{next(t).Todo.Title, next(t).Todo.Title}
+		assert.ElementsMatch(t, []string{"Bulk 1", "Bulk 2"}, titles)
+		assertNoEvent(t)
+	})
+
+	t.Run("announces soft deletes and restores", func(t *testing.T) {
+		todo := client.Todo.Create().SetTitle("Trashed").SaveX(ctx)
+		next(t)
+
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+		event := next(t)
+		assert.Equal(t, pubsub.OpDelete, event.Op)
+		assert.NotNil(t, event.Todo.DeletedAt)
+		assertNoEvent(t)
+
+		client.Todo.UpdateOne(todo).ClearDeletedAt().ExecX(schema.SkipSoftDelete(ctx))
+		event = next(t)
+		assert.Equal(t, pubsub.OpCreate, event.Op)
+		assert.Nil(t, event.Todo.DeletedAt)
+
+		// Purging a deleted row was announced by its soft delete already
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+		next(t)
+		client.Todo.DeleteOne(todo).ExecX(schema.SkipSoftDelete(ctx))
+		assertNoEvent(t)
+	})
+
+	t.Run("waits for transactions to commit", func(t *testing.T) {
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		tx.Todo.Create().SetTitle("Committed").ExecX(ctx)
+		assertNoEvent(t)
+
+		require.NoError(t, tx.Commit())
+		assert.Equal(t, "Committed", next(t).Todo.Title)
+
+		tx, err = client.Tx(ctx)
+		require.NoError(t, err)
+		tx.Todo.Create().SetTitle("Rolled back").ExecX(ctx)
+		require.NoError(t, tx.Rollback())
+		assertNoEvent(t)
+	})
+}
@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComments(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	author, seeded := testutil.SeedTestData(t, client)
+	srv := testutil.CreateGraphQLServer(client)
+	authorCtx := testutil.ContextAs(client, author)
+
+	addComment := func(t *testing.T, body string) map[string]interface{} {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, `
+			mutation AddComment($input: AddCommentInput!) {
+				addComment(input: $input) {
+					id
+					body
+					edited
+					author {
+						name
+					}
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{
+			"todoId": seeded.ID.String(),
+			"body":   body,
+		}})
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["addComment"].(map[string]interface{})
+	}
+
+	editComment := func(t *testing.T, id interface{}, body string) map[string]interface{} {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, `
+			mutation EditComment($input: EditCommentInput!) {
+				editComment(input: $input) {
+					body
+					edited
+					revisions {
+						body
+					}
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"id": id, "body": body}})
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["editComment"].(map[string]interface{})
+	}
+
+	t.Run("adds comments with their author", func(t *testing.T) {
+		result := addComment(t, "Should we split this up?")
+		assert.Equal(t, "Should we split this up?", result["body"])
+		assert.Equal(t, false, result["edited"])
+		assert.Equal(t, map[string]interface{}{"name": "Test User"}, result["author"])
+	})
+
+	t.Run("keeps the previous bodies of edited comments", func(t *testing.T) {
+		id := addComment(t, "First draft")["id"]
+
+		result := editComment(t, id, "First draft")
+		assert.Equal(t, false, result["edited"], "saving the same body isn't an edit")
+		assert.Empty(t, result["revisions"])
+
+		editComment(t, id, "Second draft")
+		result = editComment(t, id, "Final")
+		assert.Equal(t, "Final", result["body"])
+		assert.Equal(t, true, result["edited"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"body": "First draft"},
+			map[string]interface{}{"body": "Second draft"},
+		}, result["revisions"])
+	})
+
+	t.Run("deletes comments", func(t *testing.T) {
+		id := addComment(t, "Never mind")["id"]
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, `
+			mutation DeleteComment($id: UUID!) {
+				deleteComment(id: $id)
+			}
+		`, map[string]interface{}{"id": id})
+		require.Empty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, `
+			mutation DeleteComment($id: UUID!) {
+				deleteComment(id: $id)
+			}
+		`, map[string]interface{}{"id": id})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("rejects comments on unknown todos and empty bodies", func(t *testing.T) {
+		query := `
+			mutation AddComment($input: AddCommentInput!) {
+				addComment(input: $input) {
+					id
+				}
+			}
+		`
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, query, map[string]interface{}{"input": map[string]interface{}{
+			"todoId": "00000000-0000-0000-0000-000000000000",
+			"body":   "Hello?",
+		}})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, authorCtx, query, map[string]interface{}{"input": map[string]interface{}{
+			"todoId": seeded.ID.String(),
+			"body":   "",
+		}})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+
+		// Comments need someone to write them
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"input": map[string]interface{}{
+			"todoId": seeded.ID.String(),
+			"body":   "Who am I?",
+		}})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("pages through the comments of a todo oldest first", func(t *testing.T) {
+		query := `
+			query Comments($id: ID!, $after: String) {
+				node(id: $id) {
+					... on Todo {
+						comments(first: 2, after: $after) {
+							totalCount
+							edges {
+								node {
+									body
+								}
+							}
+							pageInfo {
+								hasNextPage
+								endCursor
+							}
+						}
+					}
+				}
+			}
+		`
+
+		var bodies []string
+		var after interface{}
+		for {
+			resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Todo", seeded.ID), "after": after})
+			require.Empty(t, resp.Errors)
+
+			conn := resp.Data.(map[string]interface{})["node"].(map[string]interface{})["comments"].(map[string]interface{})
+			assert.Equal(t, float64(2), conn["totalCount"])
+			for _, e := range conn["edges"].([]interface{}) {
+				bodies = append(bodies, e.(map[string]interface{})["node"].(map[string]interface{})["body"].(string))
+			}
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+		assert.Equal(t, []string{"Should we split this up?", "Final"}, bodies)
+	})
+
+	t.Run("only authors and admins change comments", func(t *testing.T) {
+		// member returns a context acting as a new member of the test workspace
+		// with role
+		member := func(name string, role membership.Role) context.Context {
+			u := client.User.Create().
+				SetEmail(name + "@example.com").
+				SetName(name).
+				SaveX(testutil.Context(client))
+			return auth.WithRole(testutil.ContextAs(client, u), role)
+		}
+		aliceCtx := member("alice", membership.RoleMEMBER)
+		bobCtx := member("bob", membership.RoleMEMBER)
+		adminCtx := member("admin", membership.RoleADMIN)
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, fmt.Sprintf(`
+			mutation { addComment(input: {todoId: "%s", body: "Mine"}) { id author { name } } }
+		`, seeded.ID), nil)
+		require.Empty(t, resp.Errors)
+		added := resp.Data.(map[string]interface{})["addComment"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"name": "alice"}, added["author"])
+
+		_, id, err := model.ParseGlobalID(added["id"].(string))
+		require.NoError(t, err)
+		edit := fmt.Sprintf(`mutation { editComment(input: {id: "%s", body: "Bob's now"}) { body } }`, id)
+		del := fmt.Sprintf(`mutation { deleteComment(id: "%s") }`, id)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, edit, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, del, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, fmt.Sprintf(`
+			mutation { editComment(input: {id: "%s", body: "Still mine"}) { body } }
+		`, id), nil)
+		require.Empty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, edit, nil)
+		require.Empty(t, resp.Errors)
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, del, nil)
+		require.Empty(t, resp.Errors)
+	})
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataloaderBatching(t *testing.T) {
+	// Setup test database with a query counter
+	client, counter := testutil.SetupTestDBWithQueryCounter(t)
+	defer client.Close()
+
+	srv := testutil.CreateGraphQLServer(client)
+
+	// seedUsers creates n more users with two todos each
+	seeded := 0
+	seedUsers := func(t *testing.T, n int) {
+		ctx := testutil.Context(client)
+		for i := seeded; i < seeded+n; i++ {
+			user, err := client.User.
+				Create().
+				SetEmail(fmt.Sprintf("user-%d@example.com", i)).
+				SetName(fmt.Sprintf("User %d", i)).
+				Save(ctx)
+			require.NoError(t, err)
+
+			for j := 0; j < 2; j++ {
+				_, err := client.Todo.
+					Create().
+					SetTitle(fmt.Sprintf("Todo %d of user %d", j, i)).
+					SetUser(user).
+					Save(ctx)
+				require.NoError(t, err)
+			}
+		}
+		seeded += n
+	}
+
+	// countQueries runs query and returns the number of SQL queries it caused
+	countQueries := func(t *testing.T, query string) int {
+		counter.Reset()
+		resp := testutil.ExecuteGraphQLWithServer(t, srv, query, nil)
+		require.Empty(t, resp.Errors)
+		return counter.Count()
+	}
+
+	t.Run("skips edge queries for unselected fields", func(t *testing.T) {
+		seedUsers(t, 1)
+
+		assert.Equal(t, 1, countQueries(t, `{ users { id name } }`))
+		assert.Equal(t, 1, countQueries(t, `{ todos { id title userId } }`))
+	})
+
+	t.Run("deep nesting costs one query per edge", func(t *testing.T) {
+		query := `{
+			users {
+				id
+				todos {
+					id
+					user {
+						id
+						todos {
+							id
+							user {
+								name
+							}
+						}
+					}
+				}
+			}
+		}`
+
+		few := countQueries(t, query)
+
+		seedUsers(t, 5)
+		many := countQueries(t, query)
+
+		// users, todos by user and users by ID; the deeper levels hit the loader cache
+		assert.Equal(t, 3, few)
+		assert.Equal(t, few, many, "query count should not grow with the number of rows")
+	})
+
+	t.Run("resolves the same data as eager loading", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServer(t, srv, `{
+			todos {
+				id
+				userId
+				user {
+					id
+					todos {
+						id
+					}
+				}
+			}
+		}`, nil)
+		require.Empty(t, resp.Errors)
+
+		for _, item := range resp.Data.(map[string]interface{})["todos"].([]interface{}) {
+			todoData := item.(map[string]interface{})
+			userData := todoData["user"].(map[string]interface{})
+			assert.Equal(t, model.GlobalID("User", uuid.MustParse(todoData["userId"].(string))), userData["id"])
+
+			var ownTodoIDs []interface{}
+			for _, ownTodo := range userData["todos"].([]interface{}) {
+				ownTodoIDs = append(ownTodoIDs, ownTodo.(map[string]interface{})["id"])
+			}
+			assert.Contains(t, ownTodoIDs, todoData["id"])
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/graph/tests/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodes(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, _ := testutil.SeedTestData(t, client)
+
+	// firstError executes an operation expected to fail with a single error
+	firstError := func(t *testing.T, query string, variables map[string]interface{}) map[string]interface{} {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Len(t, resp.Errors, 1)
+		return resp.Errors[0].Extensions
+	}
+
+	t.Run("missing entities are NOT_FOUND", func(t *testing.T) {
+		extensions := firstError(t, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, title: "Missing"}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"id": uuid.New().String()})
+		assert.Equal(t, "NOT_FOUND", extensions["code"])
+	})
+
+	t.Run("missing references point at their field", func(t *testing.T) {
+		extensions := firstError(t, `
+			mutation CreateTodo($userId: UUID) {
+				createTodo(input: {title: "Orphan", userId: $userId}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"userId": uuid.New().String()})
+		assert.Equal(t, "NOT_FOUND", extensions["code"])
+		assert.Equal(t, "input.userId", extensions["fields"].([]interface{})[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("invalid arguments list their fields", func(t *testing.T) {
+		extensions := firstError(t, `
+			query Todos($where: TodoWhereInput) {
+				todos(where: $where) {
+					id
+				}
+			}
+		`, map[string]interface{}{
+			"where": map[string]interface{}{"userIdIn": []string{user.ID.String(), "not-a-uuid"}},
+		})
+		assert.Equal(t, "INVALID_ARGUMENT", extensions["code"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "where.userIdIn[1]", "message": "invalid UUID length: 10"},
+		}, extensions["fields"])
+
+		extensions = firstError(t, `{
+			todosConnection(after: "not-a-cursor") {
+				totalCount
+			}
+		}`, nil)
+		assert.Equal(t, "INVALID_ARGUMENT", extensions["code"])
+		assert.Equal(t, "after", extensions["fields"].([]interface{})[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("constraint violations are CONFLICT", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateUser($email: String!) {
+				createUser(input: {email: $email, name: "Duplicate"}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"email": user.Email})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "conflicts with an existing record", resp.Errors[0].Message)
+	})
+
+	t.Run("unexpected failures are INTERNAL", func(t *testing.T) {
+		broken := testutil.SetupTestDB(t)
+		require.NoError(t, broken.Close())
+
+		resp := testutil.ExecuteGraphQL(t, broken, `{ todos { id } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "INTERNAL", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "internal server error", resp.Errors[0].Message)
+		assert.Nil(t, resp.Errors[0].Extensions["fields"])
+	})
+
+	t.Run("query validation keeps gqlgen's code", func(t *testing.T) {
+		extensions := firstError(t, `{ bogus }`, nil)
+		assert.Equal(t, "GRAPHQL_VALIDATION_FAILED", extensions["code"])
+	})
+}
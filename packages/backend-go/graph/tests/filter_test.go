@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodosFiltering(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	alice, err := client.User.Create().SetEmail("alice@example.com").SetName("Alice").Save(ctx)
+	require.NoError(t, err)
+	bob, err := client.User.Create().SetEmail("bob@example.com").SetName("Bob").Save(ctx)
+	require.NoError(t, err)
+
+	client.Todo.Create().SetTitle("Write docs").SetUser(alice).SaveX(ctx)
+	client.Todo.Create().SetTitle("Fix login bug").SetCompleted(true).SetUser(alice).SaveX(ctx)
+	client.Todo.Create().SetTitle("Review docs PR").SetUser(bob).SaveX(ctx)
+	client.Todo.Create().SetTitle("Unassigned chore").SaveX(ctx)
+
+	query := `
+		query Todos($where: TodoWhereInput, $orderBy: TodoOrder) {
+			todos(where: $where, orderBy: $orderBy) {
+				title
+			}
+		}
+	`
+
+	titles := func(t *testing.T, variables map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, todo := range resp.Data.(map[string]interface{})["todos"].([]interface{}) {
+			result = append(result, todo.(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+
+	t.Run("filters by title substring", func(t *testing.T) {
+		result := titles(t, map[string]interface{}{
+			"where":   map[string]interface{}{"titleContains": "DOCS"},
+			"orderBy": map[string]interface{}{"field": "TITLE"},
+		})
+		assert.Equal(t, []string{"Review docs PR", "Write docs"}, result)
+	})
+
+	t.Run("filters by completion state and assignee", func(t *testing.T) {
+		result := titles(t, map[string]interface{}{
+			"where": map[string]interface{}{
+				"completed": false,
+				"userIdIn":  []string{alice.ID.String(), bob.ID.String()},
+			},
+			"orderBy": map[string]interface{}{"field": "TITLE"},
+		})
+		assert.Equal(t, []string{"Review docs PR", "Write docs"}, result)
+	})
+
+	t.Run("filters unassigned todos", func(t *testing.T) {
+		result := titles(t, map[string]interface{}{
+			"where": map[string]interface{}{"userIdIsNull": true},
+		})
+		assert.Equal(t, []string{"Unassigned chore"}, result)
+	})
+
+	t.Run("orders descending", func(t *testing.T) {
+		result := titles(t, map[string]interface{}{
+			"orderBy": map[string]interface{}{"field": "TITLE", "direction": "DESC"},
+		})
+		assert.Equal(t, []string{"Write docs", "Unassigned chore", "Review docs PR", "Fix login bug"}, result)
+	})
+
+	t.Run("rejects malformed user IDs", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+			"where": map[string]interface{}{"userIdIn": []string{"not-a-uuid"}},
+		})
+		assert.NotEmpty(t, resp.Errors)
+	})
+
+	t.Run("pages through an ordered connection", func(t *testing.T) {
+		connQuery := `
+			query Page($after: String) {
+				todosConnection(first: 2, after: $after, orderBy: {field: COMPLETED, direction: DESC}) {
+					edges {
+						node {
+							completed
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`
+
+		var completed []bool
+		var after interface{}
+		for {
+			resp := testutil.ExecuteGraphQL(t, client, connQuery, map[string]interface{}{"after": after})
+			require.Empty(t, resp.Errors)
+
+			conn := resp.Data.(map[string]interface{})["todosConnection"].(map[string]interface{})
+			for _, e := range conn["edges"].([]interface{}) {
+				node := e.(map[string]interface{})["node"].(map[string]interface{})
+				completed = append(completed, node["completed"].(bool))
+			}
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+
+		assert.Equal(t, []bool{true, false, false, false}, completed)
+	})
+
+	t.Run("rejects cursors from a different order", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `{
+			todosConnection(first: 1) {
+				pageInfo {
+					endCursor
+				}
+			}
+		}`, nil)
+		require.Empty(t, resp.Errors)
+		cursor := resp.Data.(map[string]interface{})["todosConnection"].(map[string]interface{})["pageInfo"].(map[string]interface{})["endCursor"]
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			query Page($after: String) {
+				todosConnection(after: $after, orderBy: {field: TITLE}) {
+					totalCount
+				}
+			}
+		`, map[string]interface{}{"after": cursor})
+		assert.NotEmpty(t, resp.Errors)
+	})
+}
+
+func TestUsersFiltering(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	alice, err := client.User.Create().SetEmail("alice@example.com").SetName("Alice Smith").Save(ctx)
+	require.NoError(t, err)
+	client.User.Create().SetEmail("bob@example.org").SetName("Bob Smith").SaveX(ctx)
+	client.Todo.Create().SetTitle("Alice's todo").SetUser(alice).SaveX(ctx)
+
+	query := `
+		query Users($where: UserWhereInput, $orderBy: UserOrder) {
+			users(where: $where, orderBy: $orderBy) {
+				name
+			}
+		}
+	`
+
+	names := func(t *testing.T, variables map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, user := range resp.Data.(map[string]interface{})["users"].([]interface{}) {
+			result = append(result, user.(map[string]interface{})["name"].(string))
+		}
+		return result
+	}
+
+	t.Run("filters by email", func(t *testing.T) {
+		assert.Equal(t, []string{"Alice Smith"}, names(t, map[string]interface{}{
+			"where": map[string]interface{}{"email": "ALICE@example.com"},
+		}))
+		assert.Equal(t, []string{"Bob Smith"}, names(t, map[string]interface{}{
+			"where": map[string]interface{}{"emailContains": ".org"},
+		}))
+	})
+
+	t.Run("filters by name and orders by name", func(t *testing.T) {
+		assert.Equal(t, []string{"Bob Smith", "Alice Smith"}, names(t, map[string]interface{}{
+			"where":   map[string]interface{}{"nameContains": "smith"},
+			"orderBy": map[string]interface{}{"field": "NAME", "direction": "DESC"},
+		}))
+	})
+
+	t.Run("filters by having todos", func(t *testing.T) {
+		assert.Equal(t, []string{"Alice Smith"}, names(t, map[string]interface{}{
+			"where": map[string]interface{}{"hasTodos": true},
+		}))
+		assert.Equal(t, []string{"Bob Smith"}, names(t, map[string]interface{}{
+			"where": map[string]interface{}{"hasTodos": false},
+		}))
+	})
+}
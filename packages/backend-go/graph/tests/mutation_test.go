@@ -3,6 +3,7 @@ package tests
 import (
 	"testing"
 
+	"backend-go/graph/model"
 	"backend-go/graph/tests/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,29 @@ func TestCreateTodoMutation(t *testing.T) {
 		assert.Equal(t, "New Test Todo", createTodo["title"])
 		assert.Equal(t, false, createTodo["completed"])
 	})
+
+	t.Run("rejects a malformed user ID", func(t *testing.T) {
+		query := `
+			mutation CreateTodo($input: CreateTodoInput!) {
+				createTodo(input: $input) {
+					id
+				}
+			}
+		`
+
+		variables := map[string]interface{}{
+			"input": map[string]interface{}{
+				"title":  "Assigned to nobody",
+				"userId": "not-a-uuid",
+			},
+		}
+
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+
+		// Should fail instead of silently dropping the user
+		require.Len(t, resp.Errors, 1, "GraphQL mutation should have an error")
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+	})
 }
 
 func TestUpdateTodoMutation(t *testing.T) {
@@ -91,10 +115,64 @@ func TestUpdateTodoMutation(t *testing.T) {
 		updateTodo, ok := data["updateTodo"].(map[string]interface{})
 		require.True(t, ok, "updateTodo should be an object")
 
-		assert.Equal(t, todo.ID.String(), updateTodo["id"])
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), updateTodo["id"])
 		assert.Equal(t, "Updated Todo", updateTodo["title"])
 		assert.Equal(t, true, updateTodo["completed"])
 	})
+
+	t.Run("keeps or clears the assignee", func(t *testing.T) {
+		user, err := client.User.Create().SetEmail("assignee@example.com").SetName("Assignee").Save(testutil.Context(client))
+		require.NoError(t, err)
+		todo, err := client.Todo.Create().SetTitle("Assigned todo").SetUser(user).Save(testutil.Context(client))
+		require.NoError(t, err)
+
+		query := `
+			mutation UpdateTodo($input: UpdateTodoInput!) {
+				updateTodo(input: $input) {
+					userId
+				}
+			}
+		`
+
+		// Leaving userId out keeps the assignee
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "title": "Renamed"},
+		})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, user.ID.String(), resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})["userId"])
+
+		// An explicit null unassigns the todo
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "userId": nil},
+		})
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})["userId"])
+	})
+
+	t.Run("rejects malformed and missing assignees", func(t *testing.T) {
+		todo, err := client.Todo.Create().SetTitle("Unassigned todo").Save(testutil.Context(client))
+		require.NoError(t, err)
+
+		query := `
+			mutation UpdateTodo($input: UpdateTodoInput!) {
+				updateTodo(input: $input) {
+					id
+				}
+			}
+		`
+
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "userId": "not-a-uuid"},
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "userId": "00000000-0000-0000-0000-000000000000"},
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
 }
 
 func TestDeleteTodoMutation(t *testing.T) {
@@ -107,7 +185,7 @@ func TestDeleteTodoMutation(t *testing.T) {
 		_, todo := testutil.SeedTestData(t, client)
 
 		query := `
-			mutation DeleteTodo($id: ID!) {
+			mutation DeleteTodo($id: UUID!) {
 				deleteTodo(id: $id)
 			}
 		`
@@ -130,9 +208,9 @@ func TestDeleteTodoMutation(t *testing.T) {
 		assert.True(t, deleteTodo, "deleteTodo should return true")
 	})
 
-	t.Run("returns false for non-existent todo", func(t *testing.T) {
+	t.Run("fails with NOT_FOUND for non-existent todo", func(t *testing.T) {
 		query := `
-			mutation DeleteTodo($id: ID!) {
+			mutation DeleteTodo($id: UUID!) {
 				deleteTodo(id: $id)
 			}
 		`
@@ -143,16 +221,9 @@ func TestDeleteTodoMutation(t *testing.T) {
 
 		resp := testutil.ExecuteGraphQL(t, client, query, variables)
 
-		// Should have no errors
-		assert.Empty(t, resp.Errors, "GraphQL mutation should not have errors")
-
-		// Should return false
-		data, ok := resp.Data.(map[string]interface{})
-		require.True(t, ok, "response data should be an object")
-
-		deleteTodo, ok := data["deleteTodo"].(bool)
-		require.True(t, ok, "deleteTodo should be a boolean")
-		assert.False(t, deleteTodo, "deleteTodo should return false for non-existent todo")
+		// Should report the missing todo
+		require.Len(t, resp.Errors, 1, "GraphQL mutation should have an error")
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
 	})
 }
 
@@ -231,7 +302,8 @@ func TestUpdateUserMutation(t *testing.T) {
 			},
 		}
 
-		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		// Users only change themselves
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), testutil.ContextAs(client, user), query, variables)
 
 		// Should have no errors
 		assert.Empty(t, resp.Errors, "GraphQL mutation should not have errors")
@@ -243,7 +315,7 @@ func TestUpdateUserMutation(t *testing.T) {
 		updateUser, ok := data["updateUser"].(map[string]interface{})
 		require.True(t, ok, "updateUser should be an object")
 
-		assert.Equal(t, user.ID.String(), updateUser["id"])
+		assert.Equal(t, model.GlobalID("User", user.ID), updateUser["id"])
 		assert.Equal(t, "updated@example.com", updateUser["email"])
 		assert.Equal(t, "Updated User", updateUser["name"])
 	})
@@ -259,7 +331,7 @@ func TestDeleteUserMutation(t *testing.T) {
 		user, _ := testutil.SeedTestData(t, client)
 
 		query := `
-			mutation DeleteUser($id: ID!) {
+			mutation DeleteUser($id: UUID!) {
 				deleteUser(id: $id)
 			}
 		`
@@ -268,7 +340,8 @@ func TestDeleteUserMutation(t *testing.T) {
 			"id": user.ID.String(),
 		}
 
-		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		// Users only delete themselves
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), testutil.ContextAs(client, user), query, variables)
 
 		// Should have no errors
 		assert.Empty(t, resp.Errors, "GraphQL mutation should not have errors")
@@ -282,9 +355,9 @@ func TestDeleteUserMutation(t *testing.T) {
 		assert.True(t, deleteUser, "deleteUser should return true")
 	})
 
-	t.Run("returns false for non-existent user", func(t *testing.T) {
+	t.Run("fails with NOT_FOUND for non-existent user", func(t *testing.T) {
 		query := `
-			mutation DeleteUser($id: ID!) {
+			mutation DeleteUser($id: UUID!) {
 				deleteUser(id: $id)
 			}
 		`
@@ -295,15 +368,8 @@ func TestDeleteUserMutation(t *testing.T) {
 
 		resp := testutil.ExecuteGraphQL(t, client, query, variables)
 
-		// Should have no errors
-		assert.Empty(t, resp.Errors, "GraphQL mutation should not have errors")
-
-		// Should return false
-		data, ok := resp.Data.(map[string]interface{})
-		require.True(t, ok, "response data should be an object")
-
-		deleteUser, ok := data["deleteUser"].(bool)
-		require.True(t, ok, "deleteUser should be a boolean")
-		assert.False(t, deleteUser, "deleteUser should return false for non-existent user")
+		// Should report the missing user
+		require.Len(t, resp.Errors, 1, "GraphQL mutation should have an error")
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
 	})
 }
@@ -0,0 +1,193 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/graph/generated"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestNodeQuery(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, todo := testutil.SeedTestData(t, client)
+
+	query := `
+		query Node($id: ID!) {
+			node(id: $id) {
+				__typename
+				id
+				... on Todo {
+					title
+					user {
+						id
+					}
+				}
+				... on User {
+					email
+				}
+			}
+		}
+	`
+
+	t.Run("resolves a todo", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Todo", todo.ID)})
+		require.Empty(t, resp.Errors)
+
+		node := resp.Data.(map[string]interface{})["node"].(map[string]interface{})
+		assert.Equal(t, "Todo", node["__typename"])
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), node["id"])
+		assert.Equal(t, "Test Todo", node["title"])
+		assert.Equal(t, model.GlobalID("User", user.ID), node["user"].(map[string]interface{})["id"])
+	})
+
+	t.Run("resolves a user", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("User", user.ID)})
+		require.Empty(t, resp.Errors)
+
+		node := resp.Data.(map[string]interface{})["node"].(map[string]interface{})
+		assert.Equal(t, "User", node["__typename"])
+		assert.Equal(t, "test@example.com", node["email"])
+	})
+
+	t.Run("returns null for unknown IDs", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Todo", uuid.Nil)})
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["node"])
+	})
+
+	t.Run("looks only in the table of the type the ID names", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Todo", user.ID)})
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["node"])
+
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Workspace", testutil.Workspace(client).ID)})
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["node"])
+	})
+
+	t.Run("rejects malformed IDs", func(t *testing.T) {
+		for _, id := range []string{"not-a-uuid", todo.ID.String()} {
+			resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": id})
+			require.NotEmpty(t, resp.Errors, id)
+			assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+		}
+	})
+}
+
+func TestNodesQuery(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, todo := testutil.SeedTestData(t, client)
+
+	query := `
+		query Nodes($ids: [ID!]!) {
+			nodes(ids: $ids) {
+				__typename
+				id
+			}
+		}
+	`
+
+	missing := model.GlobalID("Tag", uuid.Nil)
+	resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{
+		"ids": []string{model.GlobalID("User", user.ID), missing, model.GlobalID("Todo", todo.ID)},
+	})
+	require.Empty(t, resp.Errors)
+
+	nodes := resp.Data.(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 3, "nodes should keep the length and order of ids")
+
+	assert.Equal(t, "User", nodes[0].(map[string]interface{})["__typename"])
+	assert.Equal(t, model.GlobalID("User", user.ID), nodes[0].(map[string]interface{})["id"])
+	assert.Nil(t, nodes[1])
+	assert.Equal(t, "Todo", nodes[2].(map[string]interface{})["__typename"])
+	assert.Equal(t, model.GlobalID("Todo", todo.ID), nodes[2].(map[string]interface{})["id"])
+}
+
+func TestNodeIDArguments(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	_, todo := testutil.SeedTestData(t, client)
+	tag := client.Tag.Create().SetName("urgent").SaveX(testutil.Context(client))
+
+	addTags := `
+		mutation AddTags($todoId: UUID!, $tagIds: [UUID!]!) {
+			addTagsToTodo(todoId: $todoId, tagIds: $tagIds) { id }
+		}
+	`
+
+	// invalidField returns the invalid field of the only error of a response
+	invalidField := func(t *testing.T, resp *testutil.GraphQLResponse) interface{} {
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+		fields := resp.Errors[0].Extensions["fields"].([]interface{})
+		require.Len(t, fields, 1)
+		return fields[0].(map[string]interface{})["field"]
+	}
+
+	t.Run("accepts global IDs of the expected type", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, addTags, map[string]interface{}{
+			"todoId": model.GlobalID("Todo", todo.ID),
+			"tagIds": []string{model.GlobalID("Tag", tag.ID)},
+		})
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("rejects global IDs of other types", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, addTags, map[string]interface{}{
+			"todoId": model.GlobalID("Tag", tag.ID),
+			"tagIds": []string{tag.ID.String()},
+		})
+		assert.Equal(t, "todoId", invalidField(t, resp))
+
+		resp = testutil.ExecuteGraphQL(t, client, addTags, map[string]interface{}{
+			"todoId": todo.ID.String(),
+			"tagIds": []string{tag.ID.String(), model.GlobalID("Todo", todo.ID)},
+		})
+		assert.Equal(t, "tagIds", invalidField(t, resp))
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, title: "Tagged"}) { id }
+			}
+		`, map[string]interface{}{"id": model.GlobalID("Tag", tag.ID)})
+		assert.Equal(t, "input.id", invalidField(t, resp))
+		assert.Equal(t, "Test Todo", client.Todo.GetX(testutil.Context(client), todo.ID).Title)
+	})
+
+	t.Run("every UUID argument names its node type", func(t *testing.T) {
+		// hasNodeID reports whether a UUID argument or input field is
+		// checked by @nodeId
+		hasNodeID := func(typ *ast.Type, directives ast.DirectiveList) bool {
+			for typ.Elem != nil {
+				typ = typ.Elem
+			}
+			return typ.NamedType != "UUID" || directives.ForName("nodeId") != nil
+		}
+
+		schema := generated.NewExecutableSchema(generated.Config{}).Schema()
+		for _, def := range schema.Types {
+			for _, field := range def.Fields {
+				if def.Kind == ast.InputObject {
+					assert.True(t, hasNodeID(field.Type, field.Directives), "%s.%s", def.Name, field.Name)
+				}
+				for _, arg := range field.Arguments {
+					assert.True(t, hasNodeID(arg.Type, arg.Directives), "%s.%s(%s)", def.Name, field.Name, arg.Name)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/ent/user"
+	"backend-go/graph"
+	"backend-go/graph/tests/testutil"
+	"backend-go/tenant"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleSignOn(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	workspace := testutil.Workspace(client)
+	keys := testutil.Keys(client)
+	provider := testutil.NewFakeOIDCProvider(t)
+
+	sso, err := auth.NewOIDC(context.Background(), client, keys, auth.OIDCConfig{
+		IssuerURL:    provider.URL,
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		RedirectURL:  "http://api.test/auth/callback",
+		Roles: []auth.RoleRule{
+			{Claim: "groups", Value: "everyone", WorkspaceID: workspace.ID, Role: membership.RoleVIEWER},
+			{Claim: "groups", Value: "admins", WorkspaceID: workspace.ID, Role: membership.RoleADMIN},
+		},
+	})
+	require.NoError(t, err)
+
+	// The routes as main sets them up
+	router := mux.NewRouter()
+	router.HandleFunc("/auth/login", sso.Login).Methods("GET")
+	router.HandleFunc("/auth/callback", sso.Callback).Methods("GET")
+	router.HandleFunc("/auth/logout", sso.Logout).Methods("GET", "POST")
+	handler := tenant.Middleware(auth.Middleware(client, keys)(router))
+
+	// serve sends a request to the routes
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// noRedirects follows no redirects, so tests can inspect them
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	// startLogin starts a login at path, returning its flow cookie and the
+	// callback the provider sends the user back to
+	startLogin := func(t *testing.T, path string) (*http.Cookie, *url.URL) {
+		rec := serve(httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusFound, rec.Code)
+		cookies := rec.Result().Cookies()
+		require.Len(t, cookies, 1)
+
+		resp, err := noRedirects.Get(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		callback, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		return cookies[0], callback
+	}
+
+	// callback completes a login like the browser coming back
+	callback := func(cookie *http.Cookie, callback *url.URL) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+		req.AddCookie(cookie)
+		return serve(req)
+	}
+
+	// login logs in as claims, returning the access token
+	login := func(t *testing.T, claims map[string]any) string {
+		provider.LoginAs(claims)
+		rec := callback(startLogin(t, "/auth/login"))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var tokens map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokens))
+		require.NotEmpty(t, tokens["refreshToken"])
+		return tokens["accessToken"]
+	}
+
+	// role returns the role of the user with email in the test workspace
+	role := func(t *testing.T, email string) membership.Role {
+		m, err := client.Membership.Query().
+			Where(membership.HasUserWith(user.Email(email))).
+			Only(ctx)
+		require.NoError(t, err)
+		return m.Role
+	}
+
+	ada := map[string]any{
+		"sub":            "ada",
+		"email":          "ada@example.com",
+		"email_verified": true,
+		"name":           "Ada Lovelace",
+		"groups":         []string{"everyone", "admins"},
+	}
+
+	t.Run("provisions users on their first login", func(t *testing.T) {
+		accessToken := login(t, ada)
+
+		authCtx, err := auth.Authenticate(ctx, client, keys, accessToken)
+		require.NoError(t, err)
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), authCtx, `{ viewer { name email } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "Ada Lovelace", "email": "ada@example.com"}, resp.Data.(map[string]interface{})["viewer"])
+
+		// SSO users have no password
+		u := client.User.Query().Where(user.Email("ada@example.com")).OnlyX(tenant.System(ctx))
+		assert.Nil(t, u.PasswordHash)
+	})
+
+	t.Run("grants the most privileged role claims map to", func(t *testing.T) {
+		assert.Equal(t, membership.RoleADMIN, role(t, "ada@example.com"))
+	})
+
+	t.Run("matches returning users by email", func(t *testing.T) {
+		demoted := map[string]any{
+			"sub":            "ada",
+			"email":          "ADA@example.com",
+			"email_verified": true,
+			"name":           "Ada",
+			"groups":         []string{"everyone"},
+		}
+		login(t, demoted)
+
+		n := client.User.Query().Where(user.EmailEqualFold("ada@example.com")).CountX(tenant.System(ctx))
+		assert.Equal(t, 1, n)
+		assert.Equal(t, membership.RoleVIEWER, role(t, "ada@example.com"))
+	})
+
+	t.Run("takes back roles no rule matches anymore", func(t *testing.T) {
+		login(t, map[string]any{"sub": "ada", "email": "ada@example.com", "email_verified": true})
+		assert.False(t, client.Membership.Query().Where(membership.HasUserWith(user.Email("ada@example.com"))).ExistX(ctx))
+
+		login(t, ada)
+		assert.Equal(t, membership.RoleADMIN, role(t, "ada@example.com"))
+	})
+
+	t.Run("keeps the last owner of a workspace", func(t *testing.T) {
+		client.Membership.Update().
+			Where(membership.HasUserWith(user.Email("ada@example.com"))).
+			SetRole(membership.RoleOWNER).
+			ExecX(ctx)
+
+		login(t, ada)
+		assert.Equal(t, membership.RoleOWNER, role(t, "ada@example.com"))
+
+		// Once someone else owns the workspace too
+		owner := client.User.Create().SetEmail("owner@example.com").SetName("Owner").SaveX(ctx)
+		client.Membership.Update().
+			Where(membership.UserID(owner.ID)).
+			SetRole(membership.RoleOWNER).
+			ExecX(ctx)
+
+		login(t, ada)
+		assert.Equal(t, membership.RoleADMIN, role(t, "ada@example.com"))
+	})
+
+	t.Run("opens sessions in the workspace of the login", func(t *testing.T) {
+		provider.LoginAs(ada)
+		rec := callback(startLogin(t, "/auth/login?workspaceId="+workspace.ID.String()))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var tokens map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokens))
+
+		authCtx, err := auth.Authenticate(context.Background(), client, keys, tokens["accessToken"])
+		require.NoError(t, err)
+		workspaceID, ok := tenant.FromContext(authCtx)
+		require.True(t, ok)
+		assert.Equal(t, workspace.ID, workspaceID)
+	})
+
+	t.Run("rejects unverified emails", func(t *testing.T) {
+		provider.LoginAs(map[string]any{"sub": "eve", "email": "ada@example.com", "email_verified": false})
+		rec := callback(startLogin(t, "/auth/login"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		// Providers that don't tell whether they verified the email
+		provider.LoginAs(map[string]any{"sub": "eve", "email": "ada@example.com"})
+		rec = callback(startLogin(t, "/auth/login"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("rejects callbacks of other logins", func(t *testing.T) {
+		provider.LoginAs(ada)
+		cookie, first := startLogin(t, "/auth/login")
+		_, second := startLogin(t, "/auth/login")
+
+		// The state doesn't match the flow of the browser
+		assert.Equal(t, http.StatusBadRequest, callback(cookie, second).Code)
+
+		// The code was issued for the PKCE challenge of the other flow
+		forged := *second
+		query := forged.Query()
+		query.Set("state", first.Query().Get("state"))
+		forged.RawQuery = query.Encode()
+		assert.Equal(t, http.StatusUnauthorized, callback(cookie, &forged).Code)
+
+		// Without the flow cookie
+		rec := serve(httptest.NewRequest(http.MethodGet, first.RequestURI(), nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("logs out", func(t *testing.T) {
+		accessToken := login(t, ada)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		rec := serve(req)
+		require.Equal(t, http.StatusFound, rec.Code)
+		assert.True(t, strings.HasPrefix(rec.Header().Get("Location"), provider.URL+"/logout?"))
+
+		_, err := auth.Authenticate(ctx, client, keys, accessToken)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("disables passwords", func(t *testing.T) {
+		srv := testutil.WithWorkspace(client, graph.NewServer(&graph.Resolver{
+			Client:           client,
+			Broker:           testutil.ChangeFeed(client),
+			Keys:             keys,
+			DisablePasswords: true,
+		}))
+		resp := testutil.ExecuteGraphQLWithServer(t, srv, `
+			mutation {
+				signUp(input: {email: "bob@example.com", name: "Bob", password: "correct horse"}) { accessToken }
+			}
+		`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+}
+
+func TestParseRoleRules(t *testing.T) {
+	rules, err := auth.ParseRoleRules(`[{"claim": "groups", "value": "admins", "workspaceId": "6f1c3c1e-8d7a-4c41-9a55-0d4d3f1f2b7a", "role": "ADMIN"}]`)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, membership.RoleADMIN, rules[0].Role)
+
+	_, err = auth.ParseRoleRules(`[{"claim": "groups", "value": "admins", "workspaceId": "6f1c3c1e-8d7a-4c41-9a55-0d4d3f1f2b7a", "role": "ROOT"}]`)
+	assert.Error(t, err)
+
+	_, err = auth.ParseRoleRules(`[{"claim": "groups", "value": "admins", "role": "ADMIN"}]`)
+	assert.Error(t, err)
+}
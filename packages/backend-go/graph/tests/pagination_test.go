@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodosConnectionQuery(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	for i := 1; i <= 5; i++ {
+		_, err := client.Todo.
+			Create().
+			SetTitle(fmt.Sprintf("Todo %d", i)).
+			Save(ctx)
+		require.NoError(t, err)
+	}
+
+	query := `
+		query TodosConnection($first: Int, $after: String, $last: Int, $before: String) {
+			todosConnection(first: $first, after: $after, last: $last, before: $before) {
+				totalCount
+				edges {
+					cursor
+					node {
+						id
+						title
+					}
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	`
+
+	fetchPage := func(t *testing.T, variables map[string]interface{}) map[string]interface{} {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["todosConnection"].(map[string]interface{})
+	}
+
+	nodeIDs := func(conn map[string]interface{}) []string {
+		var ids []string
+		for _, e := range conn["edges"].([]interface{}) {
+			ids = append(ids, e.(map[string]interface{})["node"].(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	t.Run("walks forward through all pages", func(t *testing.T) {
+		var seen []string
+		var after interface{}
+		for {
+			conn := fetchPage(t, map[string]interface{}{"first": 2, "after": after})
+			assert.Equal(t, float64(5), conn["totalCount"])
+
+			ids := nodeIDs(conn)
+			assert.LessOrEqual(t, len(ids), 2)
+			seen = append(seen, ids...)
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+
+		require.Len(t, seen, 5, "every todo should be returned exactly once")
+		assert.Len(t, uniqueStrings(seen), 5)
+	})
+
+	t.Run("walks backward from the end", func(t *testing.T) {
+		all := nodeIDs(fetchPage(t, map[string]interface{}{"first": 5}))
+
+		conn := fetchPage(t, map[string]interface{}{"last": 2})
+		assert.Equal(t, all[3:], nodeIDs(conn))
+
+		pageInfo := conn["pageInfo"].(map[string]interface{})
+		assert.True(t, pageInfo["hasPreviousPage"].(bool))
+
+		conn = fetchPage(t, map[string]interface{}{"last": 2, "before": pageInfo["startCursor"]})
+		assert.Equal(t, all[1:3], nodeIDs(conn))
+	})
+
+	t.Run("cursors stay stable when rows are added", func(t *testing.T) {
+		first := fetchPage(t, map[string]interface{}{"first": 2})
+		endCursor := first["pageInfo"].(map[string]interface{})["endCursor"]
+		before := nodeIDs(fetchPage(t, map[string]interface{}{"first": 10, "after": endCursor}))
+
+		_, err := client.Todo.Create().SetTitle("Late todo").Save(ctx)
+		require.NoError(t, err)
+
+		after := nodeIDs(fetchPage(t, map[string]interface{}{"first": 10, "after": endCursor}))
+		for _, id := range before {
+			assert.Contains(t, after, id, "rows after the cursor should still follow it")
+		}
+		for _, id := range nodeIDs(first) {
+			assert.NotContains(t, after, id, "rows before the cursor should not reappear")
+		}
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"after": "not-a-cursor"})
+		assert.NotEmpty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"first": 1, "last": 1})
+		assert.NotEmpty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"first": -1})
+		assert.NotEmpty(t, resp.Errors)
+	})
+}
+
+func TestUsersConnectionQuery(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, todo := testutil.SeedTestData(t, client)
+
+	query := `{
+		usersConnection(first: 10) {
+			totalCount
+			edges {
+				cursor
+				node {
+					id
+					email
+					todos {
+						id
+					}
+				}
+			}
+			pageInfo {
+				hasNextPage
+				hasPreviousPage
+			}
+		}
+	}`
+
+	resp := testutil.ExecuteGraphQL(t, client, query, nil)
+	require.Empty(t, resp.Errors)
+
+	conn := resp.Data.(map[string]interface{})["usersConnection"].(map[string]interface{})
+	assert.Equal(t, float64(1), conn["totalCount"])
+
+	edges := conn["edges"].([]interface{})
+	require.Len(t, edges, 1)
+
+	edge := edges[0].(map[string]interface{})
+	assert.NotEmpty(t, edge["cursor"])
+
+	node := edge["node"].(map[string]interface{})
+	assert.Equal(t, model.GlobalID("User", user.ID), node["id"])
+	assert.Equal(t, model.GlobalID("Todo", todo.ID), node["todos"].([]interface{})[0].(map[string]interface{})["id"])
+
+	pageInfo := conn["pageInfo"].(map[string]interface{})
+	assert.False(t, pageInfo["hasNextPage"].(bool))
+	assert.False(t, pageInfo["hasPreviousPage"].(bool))
+}
+
+func uniqueStrings(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,251 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/membership"
+	"backend-go/ent/privacy"
+	"backend-go/ent/schema"
+	"backend-go/ent/todo"
+	"backend-go/graph/tests/testutil"
+	"backend-go/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertForbidden asserts that err is a privacy denial clients see as FORBIDDEN
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	assert.ErrorIs(t, err, privacy.Deny)
+	appErr, ok := apperror.As(err)
+	require.True(t, ok, "expected an apperror, got %v", err)
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestPrivacyPolicies(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+
+	// as returns a context acting as a new member of the test workspace with
+	// role, along with the user
+	n := 0
+	as := func(role membership.Role) (context.Context, *ent.User) {
+		n++
+		u := client.User.Create().
+			SetEmail(fmt.Sprintf("privacy%d@example.com", n)).
+			SetName(fmt.Sprintf("Privacy %d", n)).
+			SaveX(ctx)
+		client.Membership.Update().
+			Where(membership.UserID(u.ID)).
+			SetRole(role).
+			ExecX(ctx)
+		return auth.WithRole(auth.NewContext(ctx, u), role), u
+	}
+
+	memberCtx, member := as(membership.RoleMEMBER)
+	otherCtx, other := as(membership.RoleMEMBER)
+	viewerCtx, _ := as(membership.RoleVIEWER)
+	adminCtx, _ := as(membership.RoleADMIN)
+
+	t.Run("members change the todos they created", func(t *testing.T) {
+		created := client.Todo.Create().SetTitle("Mine").SaveX(memberCtx)
+		require.NotNil(t, created.CreatorID)
+		assert.Equal(t, member.ID, *created.CreatorID)
+
+		updated, err := client.Todo.UpdateOne(created).SetTitle("Still mine").Save(memberCtx)
+		require.NoError(t, err)
+		assert.Equal(t, "Still mine", updated.Title)
+
+		_, err = client.Todo.UpdateOne(created).SetTitle("Yours now").Save(otherCtx)
+		assertForbidden(t, err)
+		assertForbidden(t, client.Todo.DeleteOne(created).Exec(otherCtx))
+	})
+
+	t.Run("members change the todos they are assigned to", func(t *testing.T) {
+		assigned := client.Todo.Create().SetTitle("Assigned").AddAssignees(other).SaveX(memberCtx)
+
+		_, err := client.Todo.UpdateOne(assigned).SetCompleted(true).Save(otherCtx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("members watch any todo themselves", func(t *testing.T) {
+		theirs := client.Todo.Create().SetTitle("Theirs").SaveX(otherCtx)
+
+		_, err := client.Todo.UpdateOne(theirs).AddWatchers(member).Save(memberCtx)
+		assert.NoError(t, err)
+		_, err = client.Todo.UpdateOne(theirs).RemoveWatchers(member).Save(memberCtx)
+		assert.NoError(t, err)
+
+		_, err = client.Todo.UpdateOne(theirs).AddWatchers(other).Save(memberCtx)
+		assertForbidden(t, err)
+	})
+
+	t.Run("bulk changes skip the todos of others", func(t *testing.T) {
+		mine := client.Todo.Create().SetTitle("Bulk mine").SaveX(memberCtx)
+		theirs := client.Todo.Create().SetTitle("Bulk theirs").SaveX(otherCtx)
+
+		n, err := client.Todo.Update().
+			Where(todo.IDIn(mine.ID, theirs.ID)).
+			SetCompleted(true).
+			Save(memberCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.False(t, client.Todo.GetX(ctx, theirs.ID).Completed)
+	})
+
+	t.Run("missing todos stay not found", func(t *testing.T) {
+		missing := client.Todo.Create().SetTitle("Gone").SaveX(ctx)
+		client.Todo.DeleteOne(missing).ExecX(ctx)
+
+		_, err := client.Todo.UpdateOne(missing).SetTitle("Back").Save(memberCtx)
+		assert.True(t, ent.IsNotFound(err), "expected not found, got %v", err)
+	})
+
+	t.Run("viewers only read", func(t *testing.T) {
+		_, err := client.Todo.Query().All(viewerCtx)
+		assert.NoError(t, err)
+
+		_, err = client.Todo.Create().SetTitle("Nope").Save(viewerCtx)
+		assertForbidden(t, err)
+	})
+
+	t.Run("admins change every todo", func(t *testing.T) {
+		theirs := client.Todo.Create().SetTitle("Admin target").SaveX(otherCtx)
+
+		_, err := client.Todo.UpdateOne(theirs).SetTitle("Admin was here").Save(adminCtx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("admins don't change other users", func(t *testing.T) {
+		_, err := client.User.UpdateOne(other).SetName("Renamed").Save(adminCtx)
+		assertForbidden(t, err)
+		_, err = client.User.UpdateOne(other).SetEmail("admin@example.com").Save(adminCtx)
+		assertForbidden(t, err)
+		assertForbidden(t, client.User.DeleteOne(other).Exec(adminCtx))
+	})
+
+	t.Run("admins restore deleted members", func(t *testing.T) {
+		_, gone := as(membership.RoleMEMBER)
+		client.User.DeleteOne(gone).ExecX(tenant.System(ctx))
+
+		_, err := client.User.UpdateOneID(gone.ID).
+			ClearDeletedAt().
+			Save(schema.SkipSoftDelete(adminCtx))
+		assert.NoError(t, err)
+	})
+
+	t.Run("users only change themselves", func(t *testing.T) {
+		_, err := client.User.UpdateOne(member).SetName("Me").Save(memberCtx)
+		assert.NoError(t, err)
+
+		_, err = client.User.UpdateOne(other).SetName("You").Save(memberCtx)
+		assertForbidden(t, err)
+		assertForbidden(t, client.User.DeleteOne(other).Exec(memberCtx))
+		assertForbidden(t, client.User.Update().SetName("Everyone").Exec(memberCtx))
+	})
+
+	t.Run("contexts without a role see nothing", func(t *testing.T) {
+		anonymous := tenant.NewContext(context.Background(), testutil.Workspace(client).ID)
+
+		_, err := client.Todo.Query().All(anonymous)
+		assert.ErrorIs(t, err, privacy.Deny)
+		_, err = client.User.Query().All(anonymous)
+		assert.ErrorIs(t, err, privacy.Deny)
+
+		_, err = client.Todo.Query().All(context.Background())
+		assert.ErrorIs(t, err, tenant.ErrNoWorkspace)
+	})
+
+	t.Run("system contexts bypass the policies", func(t *testing.T) {
+		system := tenant.System(context.Background())
+
+		count, err := client.Todo.Query().Count(system)
+		require.NoError(t, err)
+		assert.Positive(t, count)
+
+		theirs := client.Todo.Create().SetTitle("System target").SaveX(otherCtx)
+		_, err = client.Todo.UpdateOne(theirs).SetTitle("Done by a job").Save(system)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPrivacyPoliciesThroughGraphQL(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	srv := testutil.CreateGraphQLServer(client)
+	ctx := testutil.Context(client)
+
+	// as returns a context acting as a new member of the test workspace
+	as := func(email string) context.Context {
+		u := client.User.Create().SetEmail(email).SetName(email).SaveX(ctx)
+		return auth.WithRole(auth.NewContext(ctx, u), membership.RoleMEMBER)
+	}
+	aliceCtx := as("alice@example.com")
+	bobCtx := as("bob@example.com")
+
+	updateTodo := `
+		mutation UpdateTodo($id: UUID!) {
+			updateTodo(input: {id: $id, title: "Changed"}) { title }
+		}
+	`
+
+	resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, `
+		mutation { createTodo(input: {title: "Alice's"}) { id } }
+	`, nil)
+	require.Empty(t, resp.Errors)
+	id := resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})["id"]
+
+	t.Run("members change their own todos", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, updateTodo, map[string]interface{}{"id": id})
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("members don't change the todos of others", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, updateTodo, map[string]interface{}{"id": id})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, `
+			mutation DeleteTodo($id: UUID!) { deleteTodo(id: $id) }
+		`, map[string]interface{}{"id": id})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("members read the todos of others", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, `query { todos { title } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Len(t, resp.Data.(map[string]interface{})["todos"], 1)
+	})
+
+	t.Run("members complete subtasks only if they may change all of them", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, `
+			mutation CreateTodo($parentId: UUID!) {
+				createTodo(input: {title: "Bob's subtask", parentId: $parentId}) { id }
+			}
+		`, map[string]interface{}{"parentId": id})
+		require.Empty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, done: true, completeSubtasks: true}) { completed }
+			}
+		`, map[string]interface{}{"id": id})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+
+		// Nothing was completed
+		assert.Zero(t, client.Todo.Query().Where(todo.Completed(true)).CountX(ctx))
+	})
+}
@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjects(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	owner, _ := testutil.SeedTestData(t, client)
+
+	resp := testutil.ExecuteGraphQL(t, client, `
+		mutation CreateProject($input: CreateProjectInput!) {
+			createProject(input: $input) {
+				id
+				name
+				description
+				archived
+				owner {
+					name
+				}
+			}
+		}
+	`, map[string]interface{}{"input": map[string]interface{}{
+		"name":        "Launch",
+		"description": "Everything for the launch",
+		"ownerId":     owner.ID.String(),
+	}})
+	require.Empty(t, resp.Errors)
+	created := resp.Data.(map[string]interface{})["createProject"].(map[string]interface{})
+	assert.Equal(t, "Launch", created["name"])
+	assert.Equal(t, false, created["archived"])
+	assert.Equal(t, map[string]interface{}{"name": "Test User"}, created["owner"])
+	projectID := created["id"].(string)
+
+	ctx := testutil.Context(client)
+	for _, title := range []string{"Press release", "Landing page"} {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateTodo($input: CreateTodoInput!) {
+				createTodo(input: $input) {
+					project {
+						name
+					}
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"title": title, "projectId": projectID}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "Launch"}, resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})["project"])
+	}
+
+	titles := func(t *testing.T, query string, variables map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, todo := range resp.Data.(map[string]interface{})["todos"].([]interface{}) {
+			result = append(result, todo.(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+	listTodos := `
+		query Todos($where: TodoWhereInput) {
+			todos(where: $where, orderBy: {field: TITLE}) {
+				title
+			}
+		}
+	`
+	projectTodos := func(t *testing.T, where map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query ProjectTodos($id: UUID!, $where: TodoWhereInput) {
+				project(id: $id) {
+					todos(where: $where, orderBy: {field: TITLE}) {
+						edges {
+							node {
+								title
+							}
+						}
+					}
+				}
+			}
+		`, map[string]interface{}{"id": projectID, "where": where})
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		conn := resp.Data.(map[string]interface{})["project"].(map[string]interface{})["todos"].(map[string]interface{})
+		for _, e := range conn["edges"].([]interface{}) {
+			result = append(result, e.(map[string]interface{})["node"].(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+	archive := func(t *testing.T, archived bool) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateProject($input: UpdateProjectInput!) {
+				updateProject(input: $input) {
+					archived
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"id": projectID, "archived": archived}})
+		require.Empty(t, resp.Errors)
+	}
+
+	t.Run("filters the todos of a project", func(t *testing.T) {
+		assert.Equal(t, []string{"Landing page", "Press release"}, projectTodos(t, nil))
+		assert.Equal(t, []string{"Press release"}, projectTodos(t, map[string]interface{}{"titleContains": "press"}))
+		assert.Equal(t, []string{"Landing page", "Press release"}, titles(t, listTodos, map[string]interface{}{
+			"where": map[string]interface{}{"projectIdIn": []string{projectID}},
+		}))
+	})
+
+	t.Run("hides the todos of archived projects from default listings", func(t *testing.T) {
+		archive(t, true)
+		defer archive(t, false)
+
+		assert.Equal(t, []string{"Test Todo"}, titles(t, listTodos, nil))
+		assert.Equal(t, []string{"Landing page", "Press release", "Test Todo"}, titles(t, listTodos, map[string]interface{}{
+			"where": map[string]interface{}{"includeArchived": true},
+		}))
+		assert.Equal(t, []string{"Landing page", "Press release"}, projectTodos(t, nil), "the project itself still lists them")
+
+		resp := testutil.ExecuteGraphQL(t, client, `{ projects { name } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Empty(t, resp.Data.(map[string]interface{})["projects"])
+
+		resp = testutil.ExecuteGraphQL(t, client, `{ projects(includeArchived: true) { name } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Len(t, resp.Data.(map[string]interface{})["projects"], 1)
+	})
+
+	t.Run("updates projects", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateProject($input: UpdateProjectInput!) {
+				updateProject(input: $input) {
+					name
+					description
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"id": projectID, "name": "Relaunch", "description": nil}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "Relaunch", "description": nil}, resp.Data.(map[string]interface{})["updateProject"])
+	})
+
+	t.Run("rejects todos in unknown projects", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation {
+				createTodo(input: {title: "Lost", projectId: "00000000-0000-0000-0000-000000000000"}) {
+					id
+				}
+			}
+		`, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("deleting a project keeps its todos", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation DeleteProject($id: UUID!) {
+				deleteProject(id: $id)
+			}
+		`, map[string]interface{}{"id": projectID})
+		require.Empty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			query Project($id: UUID!) {
+				project(id: $id) {
+					id
+				}
+			}
+		`, map[string]interface{}{"id": projectID})
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["project"])
+
+		assert.Equal(t, 3, client.Todo.Query().CountX(ctx))
+		assert.Equal(t, []string{"Landing page", "Press release", "Test Todo"}, titles(t, listTodos, nil))
+	})
+
+	t.Run("only owners and admins change projects", func(t *testing.T) {
+		srv := testutil.CreateGraphQLServer(client)
+
+		// member returns a context acting as a new member of the test workspace
+		// with role, along with their ID
+		member := func(name string, role membership.Role) (context.Context, string) {
+			u := client.User.Create().
+				SetEmail(name + "@example.com").
+				SetName(name).
+				SaveX(ctx)
+			return auth.WithRole(testutil.ContextAs(client, u), role), u.ID.String()
+		}
+		aliceCtx, _ := member("alice", membership.RoleMEMBER)
+		bobCtx, bobID := member("bob", membership.RoleMEMBER)
+		adminCtx, _ := member("admin", membership.RoleADMIN)
+
+		// Projects belong to their creator
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, `
+			mutation { createProject(input: {name: "Alice's"}) { id owner { name } } }
+		`, nil)
+		require.Empty(t, resp.Errors)
+		created := resp.Data.(map[string]interface{})["createProject"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"name": "alice"}, created["owner"])
+		id := created["id"].(string)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, fmt.Sprintf(`
+			mutation { createProject(input: {name: "Bob's", ownerId: "%s"}) { id } }
+		`, bobID), nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, fmt.Sprintf(`
+			mutation { createProject(input: {name: "Bob's", ownerId: "%s"}) { owner { name } } }
+		`, bobID), nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "bob"}, resp.Data.(map[string]interface{})["createProject"].(map[string]interface{})["owner"])
+
+		update := fmt.Sprintf(`mutation { updateProject(input: {id: "%s", name: "Renamed"}) { name } }`, id)
+		del := fmt.Sprintf(`mutation { deleteProject(id: "%s") }`, id)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, update, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, bobCtx, del, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, update, nil)
+		require.Empty(t, resp.Errors)
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, aliceCtx, del, nil)
+		require.Empty(t, resp.Errors)
+	})
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"testing"
 
+	"backend-go/graph/model"
 	"backend-go/graph/tests/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -67,7 +68,7 @@ func TestTodosQuery(t *testing.T) {
 		todoData, ok := todos[0].(map[string]interface{})
 		require.True(t, ok, "todo should be an object")
 
-		assert.Equal(t, todo.ID.String(), todoData["id"])
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), todoData["id"])
 		assert.Equal(t, "Test Todo", todoData["title"])
 		assert.Equal(t, false, todoData["completed"])
 
@@ -119,7 +120,7 @@ func TestUsersQuery(t *testing.T) {
 		}
 
 		require.NotNil(t, testUser, "Should find test user")
-		assert.Equal(t, user.ID.String(), testUser["id"])
+		assert.Equal(t, model.GlobalID("User", user.ID), testUser["id"])
 		assert.Equal(t, "test@example.com", testUser["email"])
 		assert.Equal(t, "Test User", testUser["name"])
 
@@ -128,7 +129,7 @@ func TestUsersQuery(t *testing.T) {
 		require.Len(t, todos, 1)
 
 		todoData := todos[0].(map[string]interface{})
-		assert.Equal(t, todo.ID.String(), todoData["id"])
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), todoData["id"])
 		assert.Equal(t, "Test Todo", todoData["title"])
 		assert.Equal(t, false, todoData["completed"])
 	})
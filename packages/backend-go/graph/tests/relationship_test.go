@@ -1,9 +1,9 @@
 package tests
 
 import (
-	"context"
 	"testing"
 
+	"backend-go/graph/model"
 	"backend-go/graph/tests/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -82,7 +82,10 @@ func TestUserTodoRelationships(t *testing.T) {
 
 		assert.Equal(t, "John's first task", todoData["title"])
 		assert.Equal(t, false, todoData["completed"])
-		assert.Equal(t, userID, todoData["userId"])
+		// userId holds the UUID the global ID of the user names
+		userUUID, err := model.UnmarshalUUID(userID)
+		require.NoError(t, err)
+		assert.Equal(t, userUUID.String(), todoData["userId"])
 
 		// Check user relationship
 		userInTodo := todoData["user"].(map[string]interface{})
@@ -94,7 +97,7 @@ func TestUserTodoRelationships(t *testing.T) {
 	t.Run("queries todos with their users", func(t *testing.T) {
 
 		// Create user and todo with unique email
-		ctx := context.Background()
+		ctx := testutil.Context(client)
 
 		// Create a test user with unique email
 		user, err := client.User.
@@ -147,14 +150,14 @@ func TestUserTodoRelationships(t *testing.T) {
 		}
 
 		require.NotNil(t, testTodo, "Should find test todo")
-		assert.Equal(t, todo.ID.String(), testTodo["id"])
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), testTodo["id"])
 		assert.Equal(t, "Unique Test Todo", testTodo["title"])
 		assert.Equal(t, false, testTodo["completed"])
 		assert.Equal(t, user.ID.String(), testTodo["userId"])
 
 		// Check user relationship
 		userData := testTodo["user"].(map[string]interface{})
-		assert.Equal(t, user.ID.String(), userData["id"])
+		assert.Equal(t, model.GlobalID("User", user.ID), userData["id"])
 		assert.Equal(t, "unique-test@example.com", userData["email"])
 		assert.Equal(t, "Unique Test User", userData["name"])
 	})
@@ -192,8 +195,8 @@ func TestUserTodoRelationships(t *testing.T) {
 		assert.Nil(t, data["user"])
 	})
 
-	t.Run("user deletion cascades to todos", func(t *testing.T) {
-		ctx := context.Background()
+	t.Run("user deletion keeps todos assigned until restore", func(t *testing.T) {
+		ctx := testutil.Context(client)
 
 		// Create a user and todo
 		user, err := client.User.
@@ -212,7 +215,7 @@ func TestUserTodoRelationships(t *testing.T) {
 
 		// Delete the user
 		deleteUserQuery := `
-			mutation DeleteUser($id: ID!) {
+			mutation DeleteUser($id: UUID!) {
 				deleteUser(id: $id)
 			}
 		`
@@ -221,7 +224,8 @@ func TestUserTodoRelationships(t *testing.T) {
 			"id": user.ID.String(),
 		}
 
-		resp := testutil.ExecuteGraphQL(t, client, deleteUserQuery, variables)
+		// Users only delete themselves, admins restore them
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), testutil.ContextAs(client, user), deleteUserQuery, variables)
 		require.Empty(t, resp.Errors)
 
 		// Verify user was deleted
@@ -258,8 +262,37 @@ func TestUserTodoRelationships(t *testing.T) {
 		}
 
 		require.NotNil(t, testTodo, "Todo should still exist")
-		assert.Equal(t, todo.ID.String(), testTodo["id"])
-		assert.Nil(t, testTodo["userId"], "User ID should be null after user deletion")
-		assert.Nil(t, testTodo["user"], "User relationship should be null")
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), testTodo["id"])
+		assert.Equal(t, user.ID.String(), testTodo["userId"], "User ID should survive user deletion")
+		assert.Nil(t, testTodo["user"], "User relationship should be null while the user is deleted")
+
+		// Restore the user
+		restoreUserQuery := `
+			mutation RestoreUser($id: UUID!) {
+				restoreUser(id: $id) {
+					id
+				}
+			}
+		`
+
+		resp = testutil.ExecuteGraphQL(t, client, restoreUserQuery, variables)
+		require.Empty(t, resp.Errors)
+
+		// Verify the todo is linked to the user again
+		todoResp := testutil.ExecuteGraphQL(t, client, `
+			query Todo($id: ID!) {
+				node(id: $id) {
+					... on Todo {
+						user {
+							id
+						}
+					}
+				}
+			}
+		`, map[string]interface{}{"id": model.GlobalID("Todo", todo.ID)})
+		require.Empty(t, todoResp.Errors)
+
+		node := todoResp.Data.(map[string]interface{})["node"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"id": model.GlobalID("User", user.ID)}, node["user"])
 	})
 }
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend-go/ent/todo"
+	"backend-go/graph/tests/testutil"
+	"backend-go/pubsub"
+	"backend-go/remind"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	now := time.Now()
+	soon := client.Todo.Create().SetTitle("Call back").SetDueAt(now.Add(30 * time.Minute)).SaveX(ctx)
+	late := client.Todo.Create().SetTitle("Taxes").SetDueAt(now.Add(-48 * time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Holiday").SetDueAt(now.Add(3 * time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Done early").SetCompleted(true).SetDueAt(now.Add(30 * time.Minute)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Someday").SaveX(ctx)
+	trashed := client.Todo.Create().SetTitle("Never mind").SetDueAt(now.Add(30 * time.Minute)).SaveX(ctx)
+	client.Todo.DeleteOne(trashed).ExecX(ctx)
+
+	reminder := remind.New(client, time.Hour)
+
+	subscribeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := testutil.ChangeFeed(client).Subscribe(subscribeCtx)
+	require.NoError(t, err)
+
+	// reminded returns the titles of the todos announced by the events
+	// received so far
+	reminded := func() []string {
+		var titles []string
+		for {
+			select {
+			case event := <-events:
+				if event.Op == pubsub.OpRemind {
+					titles = append(titles, event.Todo.Title)
+				}
+			default:
+				return titles
+			}
+		}
+	}
+
+	t.Run("announces open todos due within the lead time once", func(t *testing.T) {
+		n, err := reminder.Remind(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.ElementsMatch(t, []string{"Call back", "Taxes"}, reminded())
+		assert.NotNil(t, client.Todo.GetX(ctx, soon.ID).RemindedAt)
+
+		n, err = reminder.Remind(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Empty(t, reminded())
+	})
+
+	t.Run("announces todos again once their due date moves", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, fmt.Sprintf(`
+			mutation { updateTodo(input: {id: "%s", dueAt: "%s"}) { remindedAt } }
+		`, soon.ID, now.Add(45*time.Minute).Format(time.RFC3339)), nil)
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})["remindedAt"])
+
+		// Other changes keep the reminder
+		client.Todo.UpdateOne(late).SetTitle("Taxes!").ExecX(ctx)
+		assert.NotNil(t, client.Todo.GetX(ctx, late.ID).RemindedAt)
+
+		n, err := reminder.Remind(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []string{"Call back"}, reminded())
+	})
+
+	t.Run("reminds across workspaces", func(t *testing.T) {
+		other := client.Workspace.Create().SetName("Other Workspace").SaveX(context.Background())
+		elsewhere := client.Todo.Create().SetTitle("Elsewhere").SetDueAt(now).SaveX(testutil.ContextIn(other.ID))
+
+		n, err := reminder.Remind(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.True(t, client.Todo.Query().Where(todo.ID(elsewhere.ID), todo.RemindedAtNotNil()).ExistX(testutil.ContextIn(other.ID)))
+	})
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/graph"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoles(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	srv := testutil.CreateGraphQLServer(client)
+	ctx := testutil.Context(client)
+	keys := testutil.Keys(client)
+
+	// as returns a context acting as a new member of the test workspace with
+	// role, along with their ID
+	n := 0
+	as := func(t *testing.T, role membership.Role) (context.Context, string) {
+		n++
+		u := client.User.Create().
+			SetEmail(fmt.Sprintf("member%d@example.com", n)).
+			SetName(fmt.Sprintf("Member %d", n)).
+			SaveX(ctx)
+		client.Membership.Update().
+			Where(membership.UserID(u.ID)).
+			SetRole(role).
+			ExecX(ctx)
+
+		tokens, err := auth.OpenSession(ctx, client, keys, u)
+		require.NoError(t, err)
+		authCtx, err := auth.Authenticate(ctx, client, keys, tokens.AccessToken)
+		require.NoError(t, err)
+		return authCtx, u.ID.String()
+	}
+
+	// errorCode returns the code of the only error of a response
+	errorCode := func(t *testing.T, resp *testutil.GraphQLResponse) interface{} {
+		require.Len(t, resp.Errors, 1)
+		return resp.Errors[0].Extensions["code"]
+	}
+
+	viewerCtx, viewerID := as(t, membership.RoleVIEWER)
+	memberCtx, memberID := as(t, membership.RoleMEMBER)
+	adminCtx, _ := as(t, membership.RoleADMIN)
+	ownerCtx, ownerID := as(t, membership.RoleOWNER)
+
+	t.Run("viewers read but don't write", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `query { todos { id } workspace { viewerRole } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, "VIEWER", resp.Data.(map[string]interface{})["workspace"].(map[string]interface{})["viewerRole"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `
+			mutation { createTodo(input: {title: "Nope"}) { id } }
+		`, nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("viewers only see their own email", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `
+			query { users(orderBy: {field: NAME}) { id email } }
+		`, nil)
+
+		users := resp.Data.(map[string]interface{})["users"].([]interface{})
+		require.Len(t, users, 4)
+		require.Len(t, resp.Errors, 3)
+		for _, err := range resp.Errors {
+			assert.Equal(t, "FORBIDDEN", err.Extensions["code"])
+			assert.Equal(t, "email", err.Path[len(err.Path)-1])
+		}
+		for _, u := range users {
+			u := u.(map[string]interface{})
+			if u["id"] == model.GlobalID("User", uuid.MustParse(viewerID)) {
+				assert.Equal(t, "member1@example.com", u["email"])
+			} else {
+				assert.Nil(t, u["email"])
+			}
+		}
+	})
+
+	t.Run("viewers don't filter or sort by email", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `
+			query { users(where: {emailContains: "member2"}) { id } }
+		`, nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `
+			query { usersConnection(orderBy: {field: EMAIL}) { totalCount } }
+		`, nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, memberCtx, `
+			query { users(where: {emailContains: "member2"}) { id email } }
+		`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Len(t, resp.Data.(map[string]interface{})["users"], 1)
+	})
+
+	t.Run("users only delete themselves", func(t *testing.T) {
+		victimCtx, victimID := as(t, membership.RoleMEMBER)
+		deleteUser := fmt.Sprintf(`mutation { deleteUser(id: "%s") }`, victimID)
+
+		for _, ctx := range []context.Context{memberCtx, adminCtx, ownerCtx} {
+			resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, deleteUser, nil)
+			assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+		}
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, victimCtx, deleteUser, nil)
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("admins don't change members of other workspaces too", func(t *testing.T) {
+		_, victimID := as(t, membership.RoleMEMBER)
+		other := client.Workspace.Create().SetName("Elsewhere").SaveX(context.Background())
+		client.Membership.Create().SetUserID(uuid.MustParse(victimID)).ExecX(testutil.ContextIn(other.ID))
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, fmt.Sprintf(`
+			mutation { updateUser(input: {id: "%s", email: "admin@example.com"}) { id } }
+		`, victimID), nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, fmt.Sprintf(`
+			mutation { deleteUser(id: "%s") }
+		`, victimID), nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		// The other workspace still has them as they were
+		victim := client.User.GetX(testutil.ContextIn(other.ID), uuid.MustParse(victimID))
+		assert.Nil(t, victim.DeletedAt)
+		assert.NotEqual(t, "admin@example.com", victim.Email)
+
+		// Admins manage their membership instead
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, fmt.Sprintf(`
+			mutation { removeWorkspaceMember(userId: "%s") }
+		`, victimID), nil)
+		require.Empty(t, resp.Errors)
+		assert.NotNil(t, client.User.GetX(testutil.ContextIn(other.ID), uuid.MustParse(victimID)))
+	})
+
+	t.Run("only owners make owners", func(t *testing.T) {
+		setRole := func(role string) string {
+			return fmt.Sprintf(`mutation { setMemberRole(userId: "%s", role: %s) { id } }`, memberID, role)
+		}
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, memberCtx, setRole("ADMIN"), nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, setRole("OWNER"), nil)
+		assert.Equal(t, "FORBIDDEN", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, adminCtx, setRole("ADMIN"), nil)
+		require.Empty(t, resp.Errors)
+
+		role := client.Membership.Query().Where(membership.UserID(uuid.MustParse(memberID))).OnlyX(ctx).Role
+		assert.Equal(t, membership.RoleADMIN, role)
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ownerCtx, setRole("MEMBER"), nil)
+		require.Empty(t, resp.Errors)
+	})
+
+	t.Run("the last owner can't leave", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ownerCtx, fmt.Sprintf(`
+			mutation { removeWorkspaceMember(userId: "%s") }
+		`, ownerID), nil)
+		assert.Equal(t, "CONFLICT", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, ownerCtx, fmt.Sprintf(`
+			mutation { setMemberRole(userId: "%s", role: VIEWER) { id } }
+		`, ownerID), nil)
+		assert.Equal(t, "CONFLICT", errorCode(t, resp))
+	})
+
+	t.Run("creators own their workspaces", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, viewerCtx, `
+			mutation { createWorkspace(input: {name: "Side project"}) { viewerRole } }
+		`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, "OWNER", resp.Data.(map[string]interface{})["createWorkspace"].(map[string]interface{})["viewerRole"])
+	})
+
+	t.Run("anonymous requests are unauthenticated", func(t *testing.T) {
+		// Without the owner role tests act with by default
+		bare := graph.NewServer(&graph.Resolver{Client: client, Broker: testutil.ChangeFeed(client), Keys: keys})
+		anonymousCtx := tenant.NewContext(context.Background(), testutil.Workspace(client).ID)
+
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, bare, anonymousCtx, `query { todos { id } }`, nil)
+		assert.Equal(t, "UNAUTHENTICATED", errorCode(t, resp))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, bare, anonymousCtx, `query { viewer { id } }`, nil)
+		require.Empty(t, resp.Errors)
+	})
+}
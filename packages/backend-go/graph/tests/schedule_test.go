@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"backend-go/ent/todo"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoCompletion(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	_, seeded := testutil.SeedTestData(t, client)
+
+	update := func(t *testing.T, input map[string]interface{}) map[string]interface{} {
+		input["id"] = seeded.ID.String()
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTodo($input: UpdateTodoInput!) {
+				updateTodo(input: $input) {
+					completedAt
+					dueAt
+					priority
+				}
+			}
+		`, map[string]interface{}{"input": input})
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})
+	}
+
+	t.Run("stamps completedAt when a todo is completed", func(t *testing.T) {
+		result := update(t, map[string]interface{}{"done": true})
+		require.NotNil(t, result["completedAt"])
+
+		completedAt := result["completedAt"]
+		assert.Equal(t, completedAt, update(t, map[string]interface{}{"title": "Renamed"})["completedAt"])
+		assert.Equal(t, completedAt, update(t, map[string]interface{}{"done": true})["completedAt"], "completing twice keeps the first stamp")
+	})
+
+	t.Run("clears completedAt when a todo is reopened", func(t *testing.T) {
+		assert.Nil(t, update(t, map[string]interface{}{"done": false})["completedAt"])
+	})
+
+	t.Run("sets and clears due dates and priority", func(t *testing.T) {
+		result := update(t, map[string]interface{}{"dueAt": "2030-01-02T03:04:05Z", "priority": "URGENT"})
+		assert.Equal(t, "2030-01-02T03:04:05Z", result["dueAt"])
+		assert.Equal(t, "URGENT", result["priority"])
+
+		result = update(t, map[string]interface{}{"title": "Keeps the due date"})
+		assert.Equal(t, "2030-01-02T03:04:05Z", result["dueAt"])
+
+		result = update(t, map[string]interface{}{"dueAt": nil})
+		assert.Nil(t, result["dueAt"])
+		assert.Equal(t, "URGENT", result["priority"])
+	})
+
+	t.Run("creates todos with medium priority by default", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation {
+				createTodo(input: {title: "Defaults"}) {
+					priority
+					dueAt
+					completedAt
+				}
+			}
+		`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{
+			"priority":    "MEDIUM",
+			"dueAt":       nil,
+			"completedAt": nil,
+		}, resp.Data.(map[string]interface{})["createTodo"])
+	})
+}
+
+func TestTodoSchedule(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	alice := client.User.Create().SetEmail("alice@example.com").SetName("Alice").SaveX(ctx)
+	now := time.Now()
+
+	client.Todo.Create().SetTitle("Taxes").SetUser(alice).SetPriority(todo.PriorityURGENT).SetDueAt(now.Add(-48 * time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Dentist").SetUser(alice).SetPriority(todo.PriorityLOW).SetDueAt(now.Add(-time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Done late").SetUser(alice).SetCompleted(true).SetDueAt(now.Add(-time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Holiday").SetUser(alice).SetPriority(todo.PriorityHIGH).SetDueAt(now.Add(24 * time.Hour)).SaveX(ctx)
+	client.Todo.Create().SetTitle("Someday").SetUser(alice).SaveX(ctx)
+
+	titles := func(t *testing.T, query, field string, variables map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, query, variables)
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, todo := range resp.Data.(map[string]interface{})[field].([]interface{}) {
+			result = append(result, todo.(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+
+	todosQuery := `
+		query Todos($where: TodoWhereInput, $orderBy: TodoOrder) {
+			todos(where: $where, orderBy: $orderBy) {
+				title
+			}
+		}
+	`
+
+	t.Run("lists overdue todos most overdue first", func(t *testing.T) {
+		assert.Equal(t, []string{"Taxes", "Dentist"}, titles(t, `
+			query Overdue($userId: UUID!) {
+				overdueTodos(userId: $userId) {
+					title
+				}
+			}
+		`, "overdueTodos", map[string]interface{}{"userId": alice.ID.String()}))
+	})
+
+	t.Run("lists the overdue todos of every assignee", func(t *testing.T) {
+		bob := client.User.Create().SetEmail("bob@example.com").SetName("Bob").SaveX(ctx)
+		shared := client.Todo.Create().SetTitle("Shared").SetUser(bob).SetDueAt(now.Add(-2 * time.Hour)).SaveX(ctx)
+		client.Todo.UpdateOne(shared).AddAssignees(alice).ExecX(ctx)
+		defer client.Todo.DeleteOne(shared).ExecX(ctx)
+
+		overdue := `
+			query Overdue($userId: UUID!) {
+				overdueTodos(userId: $userId) {
+					title
+				}
+			}
+		`
+		assert.Equal(t, []string{"Taxes", "Shared", "Dentist"}, titles(t, overdue, "overdueTodos", map[string]interface{}{"userId": alice.ID.String()}))
+		assert.Equal(t, []string{"Shared"}, titles(t, overdue, "overdueTodos", map[string]interface{}{"userId": bob.ID.String()}))
+	})
+
+	t.Run("filters by priority and due date", func(t *testing.T) {
+		assert.Equal(t, []string{"Holiday", "Taxes"}, titles(t, todosQuery, "todos", map[string]interface{}{
+			"where":   map[string]interface{}{"priorityIn": []string{"HIGH", "URGENT"}},
+			"orderBy": map[string]interface{}{"field": "TITLE"},
+		}))
+		assert.Equal(t, []string{"Holiday"}, titles(t, todosQuery, "todos", map[string]interface{}{
+			"where": map[string]interface{}{"dueAfter": now.Format(time.RFC3339Nano)},
+		}))
+		assert.Equal(t, []string{"Someday"}, titles(t, todosQuery, "todos", map[string]interface{}{
+			"where": map[string]interface{}{"hasDueDate": false},
+		}))
+	})
+
+	t.Run("orders by priority rank", func(t *testing.T) {
+		assert.Equal(t, []string{"Taxes", "Holiday"}, titles(t, todosQuery, "todos", map[string]interface{}{
+			"orderBy": map[string]interface{}{"field": "PRIORITY", "direction": "DESC"},
+		})[:2])
+		assert.Equal(t, "Dentist", titles(t, todosQuery, "todos", map[string]interface{}{
+			"orderBy": map[string]interface{}{"field": "PRIORITY"},
+		})[0])
+	})
+
+	pageThrough := func(t *testing.T, orderBy map[string]interface{}) []string {
+		var result []string
+		var after interface{}
+		for {
+			resp := testutil.ExecuteGraphQL(t, client, `
+				query Page($after: String, $orderBy: TodoOrder) {
+					todosConnection(first: 2, after: $after, orderBy: $orderBy) {
+						edges {
+							node {
+								title
+							}
+						}
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			`, map[string]interface{}{"after": after, "orderBy": orderBy})
+			require.Empty(t, resp.Errors)
+
+			conn := resp.Data.(map[string]interface{})["todosConnection"].(map[string]interface{})
+			for _, e := range conn["edges"].([]interface{}) {
+				result = append(result, e.(map[string]interface{})["node"].(map[string]interface{})["title"].(string))
+			}
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				return result
+			}
+			after = pageInfo["endCursor"]
+		}
+	}
+
+	t.Run("pages by due date with undated todos last", func(t *testing.T) {
+		result := pageThrough(t, map[string]interface{}{"field": "DUE_AT"})
+		require.Len(t, result, 5)
+		assert.Equal(t, "Taxes", result[0])
+		assert.ElementsMatch(t, []string{"Dentist", "Done late"}, result[1:3])
+		assert.Equal(t, []string{"Holiday", "Someday"}, result[3:])
+	})
+
+	t.Run("pages by priority", func(t *testing.T) {
+		result := pageThrough(t, map[string]interface{}{"field": "PRIORITY", "direction": "DESC"})
+		require.Len(t, result, 5)
+		assert.Equal(t, []string{"Taxes", "Holiday"}, result[:2])
+		assert.ElementsMatch(t, []string{"Done late", "Someday"}, result[2:4])
+		assert.Equal(t, "Dentist", result[4])
+	})
+}
@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/ent/schema"
+	"backend-go/graph/tests/testutil"
+	"backend-go/purge"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+
+	titles := func(t *testing.T, where map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query Todos($where: TodoWhereInput) {
+				todos(where: $where) {
+					title
+				}
+			}
+		`, map[string]interface{}{"where": where})
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, todo := range resp.Data.(map[string]interface{})["todos"].([]interface{}) {
+			result = append(result, todo.(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+
+	t.Run("deleted todos move to the trash", func(t *testing.T) {
+		todo := client.Todo.Create().SetTitle("Trashed").SaveX(ctx)
+
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation DeleteTodo($id: UUID!) {
+				deleteTodo(id: $id)
+			}
+		`, map[string]interface{}{"id": todo.ID.String()})
+		require.Empty(t, resp.Errors)
+
+		assert.NotContains(t, titles(t, nil), "Trashed")
+		assert.Contains(t, titles(t, map[string]interface{}{"includeDeleted": true}), "Trashed")
+
+		// The row is still there, only marked as deleted
+		deleted := client.Todo.GetX(schema.SkipSoftDelete(ctx), todo.ID)
+		assert.NotNil(t, deleted.DeletedAt)
+	})
+
+	t.Run("deleted todos can't be changed or deleted again", func(t *testing.T) {
+		todo := client.Todo.Create().SetTitle("Gone").SaveX(ctx)
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, done: true}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"id": todo.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation DeleteTodo($id: UUID!) {
+				deleteTodo(id: $id)
+			}
+		`, map[string]interface{}{"id": todo.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("restores deleted todos", func(t *testing.T) {
+		todo := client.Todo.Create().SetTitle("Restored").SaveX(ctx)
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+
+		query := `
+			mutation RestoreTodo($id: UUID!) {
+				restoreTodo(id: $id) {
+					title
+					deletedAt
+				}
+			}
+		`
+
+		resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": todo.ID.String()})
+		require.Empty(t, resp.Errors)
+
+		restored := resp.Data.(map[string]interface{})["restoreTodo"].(map[string]interface{})
+		assert.Equal(t, "Restored", restored["title"])
+		assert.Nil(t, restored["deletedAt"])
+		assert.Contains(t, titles(t, nil), "Restored")
+
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": "00000000-0000-0000-0000-000000000000"})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		// Todos that aren't in the trash can't be restored
+		resp = testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": todo.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("only admins see the trash", func(t *testing.T) {
+		memberCtx := auth.WithRole(tenant.NewContext(context.Background(), testutil.Workspace(client).ID), membership.RoleMEMBER)
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), memberCtx, `{
+			todos(where: {includeDeleted: true}) { title }
+		}`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("deleted users give up their email", func(t *testing.T) {
+		user := client.User.Create().SetEmail("reused@example.com").SetName("First").SaveX(ctx)
+		client.User.DeleteOne(user).ExecX(testutil.ContextAs(client, user))
+
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation {
+				createUser(input: {email: "reused@example.com", name: "Second"}) {
+					id
+				}
+			}
+		`, nil)
+		require.Empty(t, resp.Errors)
+
+		// The first user can't come back while the email is taken
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation RestoreUser($id: UUID!) {
+				restoreUser(id: $id) {
+					id
+				}
+			}
+		`, map[string]interface{}{"id": user.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("users with only deleted todos have no todos", func(t *testing.T) {
+		user := client.User.Create().SetEmail("idle@example.com").SetName("Idle").SaveX(ctx)
+		todo := client.Todo.Create().SetTitle("Idle todo").SetUser(user).SaveX(ctx)
+		client.Todo.DeleteOne(todo).ExecX(ctx)
+
+		resp := testutil.ExecuteGraphQL(t, client, `{
+			users(where: {hasTodos: true}) {
+				name
+			}
+		}`, nil)
+		require.Empty(t, resp.Errors)
+		assert.NotContains(t, resp.Data.(map[string]interface{})["users"], map[string]interface{}{"name": "Idle"})
+	})
+}
+
+func TestPurge(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	all := schema.SkipSoftDelete(ctx)
+	expired := time.Now().Add(-2 * time.Hour)
+
+	user := client.User.Create().SetEmail("purged@example.com").SetName("Purged").SetDeletedAt(expired).SaveX(ctx)
+	orphan := client.Todo.Create().SetTitle("Orphan").SetUser(user).SaveX(ctx)
+	client.Todo.Create().SetTitle("Expired").SetDeletedAt(expired).SaveX(ctx)
+	recent := client.Todo.Create().SetTitle("Recent").SetDeletedAt(time.Now()).SaveX(ctx)
+	live := client.Todo.Create().SetTitle("Live").SaveX(ctx)
+
+	n, err := purge.New(client, time.Hour).Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	remaining := client.Todo.Query().IDsX(all)
+	assert.ElementsMatch(t, []uuid.UUID{orphan.ID, recent.ID, live.ID}, remaining)
+	assert.Zero(t, client.User.Query().CountX(all))
+
+	// Todos of purged users are left unassigned
+	assert.Nil(t, client.Todo.GetX(ctx, orphan.ID).UserID)
+}
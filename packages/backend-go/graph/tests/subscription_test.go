@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/graph"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+	"backend-go/pubsub"
+	"backend-go/remind"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signalingBroker reports every completed Subscribe call, so tests only start
+// mutating once the subscription is listening
+type signalingBroker struct {
+	pubsub.Broker
+	subscribed chan struct{}
+}
+
+func (b *signalingBroker) Subscribe(ctx context.Context) (<-chan pubsub.Event, error) {
+	events, err := b.Broker.Subscribe(ctx)
+	b.subscribed <- struct{}{}
+	return events, err
+}
+
+type todoEvent struct {
+	ID     string
+	Title  string
+	UserID *string
+}
+
+func TestTodoSubscriptions(t *testing.T) {
+	// Setup test database
+	entClient := testutil.SetupTestDB(t)
+	defer entClient.Close()
+
+	user, todo := testutil.SeedTestData(t, entClient)
+
+	broker := &signalingBroker{Broker: testutil.ChangeFeed(entClient), subscribed: make(chan struct{}, 1)}
+	srv := graph.NewServer(&graph.Resolver{Client: entClient, Broker: broker})
+	gqlClient := client.New(testutil.WithWorkspace(entClient, srv))
+
+	// subscribe starts a subscription and waits until it is listening
+	subscribe := func(t *testing.T, query string, options ...client.Option) *client.Subscription {
+		sub := gqlClient.Websocket(query, options...)
+		t.Cleanup(func() { _ = sub.Close() })
+
+		select {
+		case <-broker.subscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription was not established")
+		}
+		return sub
+	}
+
+	// next reads the next event of a subscription to field
+	next := func(t *testing.T, sub *client.Subscription, field string) todoEvent {
+		received := make(chan map[string]todoEvent, 1)
+		failed := make(chan error, 1)
+		go func() {
+			var resp map[string]todoEvent
+			if err := sub.Next(&resp); err != nil {
+				failed <- err
+				return
+			}
+			received <- resp
+		}()
+
+		select {
+		case resp := <-received:
+			return resp[field]
+		case err := <-failed:
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for subscription event")
+		}
+		return todoEvent{}
+	}
+
+	t.Run("todoCreated", func(t *testing.T) {
+		sub := subscribe(t, `subscription { todoCreated { id title userId } }`)
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation { createTodo(input: {title: "Live todo"}) { id } }`, &resp)
+
+		event := next(t, sub, "todoCreated")
+		assert.Equal(t, resp["createTodo"].(map[string]interface{})["id"], event.ID)
+		assert.Equal(t, "Live todo", event.Title)
+		assert.Nil(t, event.UserID)
+	})
+
+	t.Run("todoUpdated", func(t *testing.T) {
+		sub := subscribe(t, `subscription { todoUpdated { id title } }`)
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation($id: UUID!) { updateTodo(input: {id: $id, title: "Renamed"}) { id } }`, &resp,
+			client.Var("id", todo.ID.String()))
+
+		event := next(t, sub, "todoUpdated")
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), event.ID)
+		assert.Equal(t, "Renamed", event.Title)
+	})
+
+	t.Run("todoDeleted emits the last state", func(t *testing.T) {
+		sub := subscribe(t, `subscription { todoDeleted { id title userId } }`)
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation($id: UUID!) { deleteTodo(id: $id) }`, &resp,
+			client.Var("id", todo.ID.String()))
+		require.Equal(t, true, resp["deleteTodo"])
+
+		event := next(t, sub, "todoDeleted")
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), event.ID)
+		assert.Equal(t, "Renamed", event.Title)
+		require.NotNil(t, event.UserID)
+		assert.Equal(t, user.ID.String(), *event.UserID)
+	})
+
+	t.Run("filters by user", func(t *testing.T) {
+		sub := subscribe(t, `subscription($userId: UUID) { todoCreated(userId: $userId) { title } }`,
+			client.Var("userId", user.ID.String()))
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation { createTodo(input: {title: "Someone else's"}) { id } }`, &resp)
+		gqlClient.MustPost(`mutation($userId: UUID) { createTodo(input: {title: "Assigned", userId: $userId}) { id } }`, &resp,
+			client.Var("userId", user.ID.String()))
+
+		event := next(t, sub, "todoCreated")
+		assert.Equal(t, "Assigned", event.Title)
+	})
+
+	t.Run("filters by any assignee", func(t *testing.T) {
+		other := entClient.User.Create().SetEmail("other@example.com").SetName("Other").SaveX(testutil.Context(entClient))
+		assigned := entClient.Todo.Create().SetTitle("Shared").SetUser(other).SaveX(testutil.Context(entClient))
+
+		sub := subscribe(t, `subscription($userId: UUID) { todoUpdated(userId: $userId) { title } }`,
+			client.Var("userId", user.ID.String()))
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation($todoId: UUID!, $userIds: [UUID!]!) { assignTodo(todoId: $todoId, userIds: $userIds) { id } }`, &resp,
+			client.Var("todoId", assigned.ID.String()), client.Var("userIds", []string{user.ID.String()}))
+
+		event := next(t, sub, "todoUpdated")
+		assert.Equal(t, "Shared", event.Title)
+	})
+
+	t.Run("todoReminder announces todos as they come due", func(t *testing.T) {
+		ctx := testutil.Context(entClient)
+		entClient.Todo.Create().SetTitle("Someone else's call").SetDueAt(time.Now().Add(10 * time.Minute)).SaveX(ctx)
+		entClient.Todo.Create().SetTitle("Call back").SetUser(user).SetDueAt(time.Now().Add(10 * time.Minute)).SaveX(ctx)
+
+		sub := subscribe(t, `subscription($userId: UUID) { todoReminder(userId: $userId) { title } }`,
+			client.Var("userId", user.ID.String()))
+
+		_, err := remind.New(entClient, time.Hour).Remind(context.Background())
+		require.NoError(t, err)
+
+		event := next(t, sub, "todoReminder")
+		assert.Equal(t, "Call back", event.Title)
+	})
+
+	t.Run("ignores other workspaces", func(t *testing.T) {
+		sub := subscribe(t, `subscription { todoCreated { title } }`)
+
+		other := entClient.Workspace.Create().SetName("Other Workspace").SaveX(context.Background())
+		entClient.Todo.Create().SetTitle("Elsewhere").SaveX(testutil.ContextIn(other.ID))
+
+		var resp map[string]interface{}
+		gqlClient.MustPost(`mutation { createTodo(input: {title: "Here"}) { id } }`, &resp)
+
+		event := next(t, sub, "todoCreated")
+		assert.Equal(t, "Here", event.Title)
+	})
+}
+
+func TestSubscriptionSessions(t *testing.T) {
+	// Setup test database
+	entClient := testutil.SetupTestDB(t)
+	defer entClient.Close()
+
+	ctx := testutil.Context(entClient)
+	user, _ := testutil.SeedTestData(t, entClient)
+	keys := testutil.Keys(entClient)
+
+	srv := graph.NewServer(&graph.Resolver{
+		Client:               entClient,
+		Broker:               testutil.ChangeFeed(entClient),
+		Keys:                 keys,
+		SessionCheckInterval: 50 * time.Millisecond,
+	})
+	gqlClient := client.New(srv)
+
+	// subscribe starts a subscription authenticated by accessToken
+	subscribe := func(t *testing.T, accessToken string) *client.Subscription {
+		sub := gqlClient.WebsocketWithPayload(`subscription { todoCreated { title } }`,
+			map[string]any{"Authorization": "Bearer " + accessToken})
+		t.Cleanup(func() { _ = sub.Close() })
+		return sub
+	}
+
+	// closed waits until the connection of sub is closed
+	closed := func(t *testing.T, sub *client.Subscription) {
+		failed := make(chan error, 1)
+		go func() {
+			var resp map[string]interface{}
+			failed <- sub.Next(&resp)
+		}()
+
+		select {
+		case err := <-failed:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("connection was not closed")
+		}
+	}
+
+	t.Run("closes once the access token expires", func(t *testing.T) {
+		workspaceID := testutil.Workspace(entClient).ID
+		token, err := keys.Sign(ctx, auth.Claims{
+			Claims: jwt.Claims{
+				Subject: user.ID.String(),
+				Expiry:  jwt.NewNumericDate(time.Now().Add(time.Second)),
+			},
+			SessionID:   uuid.New(),
+			WorkspaceID: &workspaceID,
+		})
+		require.NoError(t, err)
+
+		closed(t, subscribe(t, token))
+	})
+
+	t.Run("closes once the session is logged out", func(t *testing.T) {
+		tokens, err := auth.OpenSession(ctx, entClient, keys, user)
+		require.NoError(t, err)
+		sub := subscribe(t, tokens.AccessToken)
+
+		authCtx, err := auth.Authenticate(ctx, entClient, keys, tokens.AccessToken)
+		require.NoError(t, err)
+		sessionID, _ := auth.SessionFromContext(authCtx)
+		require.NoError(t, auth.RevokeSession(ctx, entClient, sessionID))
+
+		closed(t, sub)
+	})
+
+	t.Run("closes once the role of the user changes", func(t *testing.T) {
+		tokens, err := auth.OpenSession(ctx, entClient, keys, user)
+		require.NoError(t, err)
+		sub := subscribe(t, tokens.AccessToken)
+
+		entClient.Membership.Update().
+			Where(membership.UserID(user.ID)).
+			SetRole(membership.RoleVIEWER).
+			ExecX(ctx)
+
+		closed(t, sub)
+	})
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtasks(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	release := client.Todo.Create().SetTitle("Ship release").SaveX(ctx)
+
+	createSubtask := func(t *testing.T, title string, parentID string) string {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateTodo($input: CreateTodoInput!) {
+				createTodo(input: $input) {
+					id
+					parentId
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"title": title, "parentId": parentID}})
+		require.Empty(t, resp.Errors)
+		result := resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})
+		// parentID may be the global ID of a created todo, parentId is its UUID
+		parent, err := model.UnmarshalUUID(parentID)
+		require.NoError(t, err)
+		assert.Equal(t, parent.String(), result["parentId"])
+		return result["id"].(string)
+	}
+
+	changelog := createSubtask(t, "Write changelog", release.ID.String())
+	tag := createSubtask(t, "Tag version", release.ID.String())
+	proofread := createSubtask(t, "Proofread changelog", changelog)
+
+	updateTodo := func(t *testing.T, input map[string]interface{}) *testutil.GraphQLResponse {
+		return testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTodo($input: UpdateTodoInput!) {
+				updateTodo(input: $input) {
+					id
+				}
+			}
+		`, map[string]interface{}{"input": input})
+	}
+
+	progress := func(t *testing.T) map[string]interface{} {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query Todo($id: ID!) {
+				node(id: $id) {
+					... on Todo {
+						progress
+						subtasks {
+							title
+							completed
+							progress
+							parent {
+								title
+							}
+						}
+					}
+				}
+			}
+		`, map[string]interface{}{"id": model.GlobalID("Todo", release.ID)})
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["node"].(map[string]interface{})
+	}
+
+	t.Run("reports the fraction of completed subtasks", func(t *testing.T) {
+		result := progress(t)
+		assert.Equal(t, float64(0), result["progress"])
+		assert.Len(t, result["subtasks"], 2)
+		for _, subtask := range result["subtasks"].([]interface{}) {
+			assert.Equal(t, map[string]interface{}{"title": "Ship release"}, subtask.(map[string]interface{})["parent"])
+		}
+
+		require.Empty(t, updateTodo(t, map[string]interface{}{"id": tag, "done": true}).Errors)
+		assert.Equal(t, 0.5, progress(t)["progress"])
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		resp := updateTodo(t, map[string]interface{}{"id": release.ID.String(), "parentId": proofread})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "input.parentId", resp.Errors[0].Extensions["fields"].([]interface{})[0].(map[string]interface{})["field"])
+
+		resp = updateTodo(t, map[string]interface{}{"id": tag, "parentId": tag})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("filters top-level todos", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `{ todos(where: {hasParent: false}) { title } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Ship release"}}, resp.Data.(map[string]interface{})["todos"])
+	})
+
+	t.Run("completes nested subtasks along with their parent", func(t *testing.T) {
+		require.Empty(t, updateTodo(t, map[string]interface{}{"id": release.ID.String(), "done": true, "completeSubtasks": true}).Errors)
+
+		result := progress(t)
+		assert.Equal(t, float64(1), result["progress"])
+		for _, subtask := range result["subtasks"].([]interface{}) {
+			subtask := subtask.(map[string]interface{})
+			assert.Equal(t, true, subtask["completed"])
+			if subtask["title"] == "Write changelog" {
+				assert.Equal(t, float64(1), subtask["progress"], "the proofreading subtask is completed too")
+			}
+		}
+	})
+
+	t.Run("moves subtasks to the top level", func(t *testing.T) {
+		require.Empty(t, updateTodo(t, map[string]interface{}{"id": tag, "parentId": nil}).Errors)
+		assert.Len(t, progress(t)["subtasks"], 1)
+	})
+}
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+	work := client.Tag.Create().SetName("work").SetColor("#FF0000").SaveX(ctx)
+	urgent := client.Tag.Create().SetName("urgent").SaveX(ctx)
+	report := client.Todo.Create().SetTitle("Write report").AddTags(work, urgent).SaveX(ctx)
+	client.Todo.Create().SetTitle("Book meeting").AddTags(work).SaveX(ctx)
+	client.Todo.Create().SetTitle("Water plants").SaveX(ctx)
+
+	titles := func(t *testing.T, where map[string]interface{}) []string {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query Todos($where: TodoWhereInput) {
+				todos(where: $where, orderBy: {field: TITLE}) {
+					title
+				}
+			}
+		`, map[string]interface{}{"where": where})
+		require.Empty(t, resp.Errors)
+
+		var result []string
+		for _, todo := range resp.Data.(map[string]interface{})["todos"].([]interface{}) {
+			result = append(result, todo.(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+
+	t.Run("creates, renames and deletes tags", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation {
+				createTag(input: {name: "home"}) {
+					id
+					name
+					color
+				}
+			}
+		`, nil)
+		require.Empty(t, resp.Errors)
+		created := resp.Data.(map[string]interface{})["createTag"].(map[string]interface{})
+		assert.Equal(t, "home", created["name"])
+		assert.Equal(t, "#9E9E9E", created["color"])
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTag($input: UpdateTagInput!) {
+				updateTag(input: $input) {
+					name
+					color
+				}
+			}
+		`, map[string]interface{}{"input": map[string]interface{}{"id": created["id"], "name": "house", "color": "#00FF00"}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "house", "color": "#00FF00"}, resp.Data.(map[string]interface{})["updateTag"])
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation DeleteTag($id: UUID!) {
+				deleteTag(id: $id)
+			}
+		`, map[string]interface{}{"id": created["id"]})
+		require.Empty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQL(t, client, `{ tags { name } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "urgent"},
+			map[string]interface{}{"name": "work"},
+		}, resp.Data.(map[string]interface{})["tags"])
+	})
+
+	t.Run("rejects duplicate names and invalid colors", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `mutation { createTag(input: {name: "work"}) { id } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQL(t, client, `mutation { createTag(input: {name: "blue", color: "blue"}) { id } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("filters todos by any or all tags", func(t *testing.T) {
+		assert.Equal(t, []string{"Book meeting", "Write report"}, titles(t, map[string]interface{}{
+			"hasAnyTag": []string{work.ID.String(), urgent.ID.String()},
+		}))
+		assert.Equal(t, []string{"Write report"}, titles(t, map[string]interface{}{
+			"hasAllTags": []string{work.ID.String(), urgent.ID.String()},
+		}))
+	})
+
+	t.Run("adds and removes tags on a todo", func(t *testing.T) {
+		mutation := `
+			mutation Tag($todoId: UUID!, $tagIds: [UUID!]!) {
+				%s(todoId: $todoId, tagIds: $tagIds) {
+					version
+					tags {
+						name
+					}
+				}
+			}
+		`
+		variables := map[string]interface{}{
+			"todoId": report.ID.String(),
+			"tagIds": []string{urgent.ID.String()},
+		}
+
+		resp := testutil.ExecuteGraphQL(t, client, fmt.Sprintf(mutation, "removeTagsFromTodo"), variables)
+		require.Empty(t, resp.Errors)
+		result := resp.Data.(map[string]interface{})["removeTagsFromTodo"].(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "work"}}, result["tags"])
+		assert.Equal(t, float64(2), result["version"])
+
+		// Adding a tag twice keeps a single one
+		for range 2 {
+			resp = testutil.ExecuteGraphQL(t, client, fmt.Sprintf(mutation, "addTagsToTodo"), variables)
+			require.Empty(t, resp.Errors)
+		}
+		result = resp.Data.(map[string]interface{})["addTagsToTodo"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "urgent"},
+			map[string]interface{}{"name": "work"},
+		}, result["tags"])
+	})
+
+	t.Run("rejects unknown tags", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation Tag($todoId: UUID!, $tagIds: [UUID!]!) {
+				addTagsToTodo(todoId: $todoId, tagIds: $tagIds) {
+					id
+				}
+			}
+		`, map[string]interface{}{
+			"todoId": report.ID.String(),
+			"tagIds": []string{work.ID.String(), "00000000-0000-0000-0000-000000000000"},
+		})
+		require.NotEmpty(t, resp.Errors)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+		assert.Equal(t, "tagIds[1]", resp.Errors[0].Extensions["fields"].([]interface{})[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("pages through the todos of a tag", func(t *testing.T) {
+		query := `
+			query TagTodos($id: ID!, $after: String) {
+				node(id: $id) {
+					... on Tag {
+						todos(first: 1, after: $after, orderBy: {field: TITLE}) {
+							totalCount
+							edges {
+								node {
+									title
+								}
+							}
+							pageInfo {
+								hasNextPage
+								endCursor
+							}
+						}
+					}
+				}
+			}
+		`
+
+		var result []string
+		var after interface{}
+		for {
+			resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"id": model.GlobalID("Tag", work.ID), "after": after})
+			require.Empty(t, resp.Errors)
+
+			conn := resp.Data.(map[string]interface{})["node"].(map[string]interface{})["todos"].(map[string]interface{})
+			assert.Equal(t, float64(2), conn["totalCount"])
+			for _, e := range conn["edges"].([]interface{}) {
+				result = append(result, e.(map[string]interface{})["node"].(map[string]interface{})["title"].(string))
+			}
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+		assert.Equal(t, []string{"Book meeting", "Write report"}, result)
+	})
+}
@@ -8,11 +8,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"backend-go/auth"
 	"backend-go/ent"
+	"backend-go/ent/membership"
 	"backend-go/graph"
-	"backend-go/graph/generated"
+	"backend-go/tenant"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,8 +27,9 @@ type GraphQLRequest struct {
 type GraphQLResponse struct {
 	Data   interface{} `json:"data"`
 	Errors []struct {
-		Message string        `json:"message"`
-		Path    []interface{} `json:"path"`
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
 	} `json:"errors"`
 }
 
@@ -86,12 +88,32 @@ func ExecuteGraphQLWithServerAndContext(t *testing.T, srv http.Handler, ctx cont
 	return &response
 }
 
-// CreateGraphQLServer creates a GraphQL server for testing
-func CreateGraphQLServer(client *ent.Client) *handler.Server {
+// CreateGraphQLServer creates a GraphQL server for testing. Requests act in
+// the test workspace of client, see WithWorkspace.
+func CreateGraphQLServer(client *ent.Client) http.Handler {
 	resolver := &graph.Resolver{
 		Client: client,
+		Broker: ChangeFeed(client),
+		Keys:   Keys(client),
 	}
-	return handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+	return WithWorkspace(client, auth.Middleware(client, resolver.Keys)(graph.NewServer(resolver)))
+}
+
+// WithWorkspace selects the workspace of requests to srv like the server does,
+// falling back to the test workspace of client for requests that neither set
+// the header nor carry a workspace in their context. Requests without a role
+// act as owners, so tests don't have to log in; access tokens still replace
+// it with the role of their user.
+func WithWorkspace(client *ent.Client, srv http.Handler) http.Handler {
+	return tenant.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := tenant.FromContext(r.Context()); !ok {
+			r = r.WithContext(tenant.NewContext(r.Context(), Workspace(client).ID))
+		}
+		if _, ok := auth.RoleFromContext(r.Context()); !ok {
+			r = r.WithContext(auth.WithRole(r.Context(), membership.RoleOWNER))
+		}
+		srv.ServeHTTP(w, r)
+	}))
 }
 
 // ExecuteGraphQL is a convenience wrapper that creates the server and executes GraphQL
@@ -2,32 +2,193 @@ package testutil
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"backend-go/auth"
+	"backend-go/changefeed"
 	"backend-go/ent"
 	"backend-go/ent/enttest"
+	"backend-go/ent/membership"
+	"backend-go/tenant"
+
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// testDSN points at a shared in-memory SQLite database
+const testDSN = "file:ent?mode=memory&cache=shared&_fk=1"
+
 // SetupTestDB creates an in-memory SQLite database for testing
 func SetupTestDB(t *testing.T) *ent.Client {
-	client := enttest.Open(t, dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
-	
+	client := enttest.Open(t, dialect.SQLite, testDSN)
+
 	// Run the auto migration to create tables
 	ctx := context.Background()
 	err := client.Schema.Create(ctx)
 	require.NoError(t, err, "failed to create test schema")
-	
+
+	setupChangeFeed(t, client)
+	setupWorkspace(t, client)
+	setupKeys(t, client)
 	return client
 }
 
-// SeedTestData creates some test data
+var (
+	changeFeedsMu sync.Mutex
+	changeFeeds   = map[*ent.Client]*changefeed.Fake{}
+)
+
+// setupChangeFeed attaches a fake change feed to a test client, standing in
+// for the Postgres trigger SQLite lacks
+func setupChangeFeed(t *testing.T, client *ent.Client) {
+	ChangeFeed(client)
+	t.Cleanup(func() {
+		changeFeedsMu.Lock()
+		delete(changeFeeds, client)
+		changeFeedsMu.Unlock()
+	})
+}
+
+// ChangeFeed returns the change feed observing the writes made through client
+func ChangeFeed(client *ent.Client) *changefeed.Fake {
+	changeFeedsMu.Lock()
+	defer changeFeedsMu.Unlock()
+
+	feed, ok := changeFeeds[client]
+	if !ok {
+		feed = changefeed.NewFake(client)
+		changeFeeds[client] = feed
+	}
+	return feed
+}
+
+var (
+	workspacesMu sync.Mutex
+	workspaces   = map[*ent.Client]*ent.Workspace{}
+)
+
+// setupWorkspace creates the workspace the test data and requests of a test
+// client act in
+func setupWorkspace(t *testing.T, client *ent.Client) {
+	workspace, err := client.Workspace.Create().SetName("Test Workspace").Save(context.Background())
+	require.NoError(t, err, "failed to create test workspace")
+
+	workspacesMu.Lock()
+	workspaces[client] = workspace
+	workspacesMu.Unlock()
+	t.Cleanup(func() {
+		workspacesMu.Lock()
+		delete(workspaces, client)
+		workspacesMu.Unlock()
+	})
+}
+
+// Workspace returns the workspace the test data and requests of client act in
+func Workspace(client *ent.Client) *ent.Workspace {
+	workspacesMu.Lock()
+	defer workspacesMu.Unlock()
+	return workspaces[client]
+}
+
+// Context returns a context acting in the test workspace of client, see
+// ContextIn
+func Context(client *ent.Client) context.Context {
+	return ContextIn(Workspace(client).ID)
+}
+
+// ContextIn returns a context acting in the workspace with id as an owner,
+// which the privacy policies let change anything in it
+func ContextIn(id uuid.UUID) context.Context {
+	return auth.WithRole(tenant.NewContext(context.Background(), id), membership.RoleOWNER)
+}
+
+// ContextAs returns a context acting in the test workspace of client as the
+// logged in user u, for what only users do themselves
+func ContextAs(client *ent.Client, u *ent.User) context.Context {
+	return auth.NewContext(Context(client), u)
+}
+
+var (
+	keysMu sync.Mutex
+	keys   = map[*ent.Client]*auth.Keys{}
+)
+
+// KeySecret is the secret the signing keys of test clients are encrypted with
+const KeySecret = "test signing key secret"
+
+// setupKeys generates the key signing the access tokens of a test client
+func setupKeys(t *testing.T, client *ent.Client) {
+	k, err := auth.NewKeys(client, KeySecret)
+	require.NoError(t, err)
+	require.NoError(t, k.Rotate(context.Background()), "failed to generate signing key")
+
+	keysMu.Lock()
+	keys[client] = k
+	keysMu.Unlock()
+	t.Cleanup(func() {
+		keysMu.Lock()
+		delete(keys, client)
+		keysMu.Unlock()
+	})
+}
+
+// Keys returns the keys signing the access tokens of client
+func Keys(client *ent.Client) *auth.Keys {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	return keys[client]
+}
+
+// QueryCounter wraps a driver and counts the SQL queries sent through it
+type QueryCounter struct {
+	dialect.Driver
+	queries atomic.Int64
+}
+
+// Query counts and forwards a query to the wrapped driver
+func (c *QueryCounter) Query(ctx context.Context, query string, args, v any) error {
+	c.queries.Add(1)
+	return c.Driver.Query(ctx, query, args, v)
+}
+
+// Count returns the number of queries sent since the last Reset
+func (c *QueryCounter) Count() int {
+	return int(c.queries.Load())
+}
+
+// Reset sets the query count back to zero
+func (c *QueryCounter) Reset() {
+	c.queries.Store(0)
+}
+
+// SetupTestDBWithQueryCounter creates a test database like SetupTestDB and
+// returns a counter of the queries the client sends to it
+func SetupTestDBWithQueryCounter(t *testing.T) (*ent.Client, *QueryCounter) {
+	drv, err := entsql.Open(dialect.SQLite, testDSN)
+	require.NoError(t, err, "failed to open test database")
+
+	counter := &QueryCounter{Driver: drv}
+	client := ent.NewClient(ent.Driver(counter))
+
+	err = client.Schema.Create(context.Background())
+	require.NoError(t, err, "failed to create test schema")
+
+	setupChangeFeed(t, client)
+	setupWorkspace(t, client)
+	setupKeys(t, client)
+	return client, counter
+}
+
+// SeedTestData creates some test data in the test workspace
 func SeedTestData(t *testing.T, client *ent.Client) (*ent.User, *ent.Todo) {
-	ctx := context.Background()
-	
+	ctx := Context(client)
+
 	// Create a test user
 	user, err := client.User.
 		Create().
@@ -35,7 +196,7 @@ func SeedTestData(t *testing.T, client *ent.Client) (*ent.User, *ent.Todo) {
 		SetName("Test User").
 		Save(ctx)
 	require.NoError(t, err, "failed to create test user")
-	
+
 	// Create a test todo for the user
 	todo, err := client.Todo.
 		Create().
@@ -44,6 +205,6 @@ func SeedTestData(t *testing.T, client *ent.Client) (*ent.User, *ent.Todo) {
 		SetUser(user).
 		Save(ctx)
 	require.NoError(t, err, "failed to create test todo")
-	
+
 	return user, todo
 }
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamps(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	ctx := testutil.Context(client)
+
+	t.Run("sets createdAt and bumps updatedAt", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation {
+				createTodo(input: {title: "Timestamped"}) {
+					id
+					createdAt
+					updatedAt
+				}
+			}
+		`, nil)
+		require.Empty(t, resp.Errors)
+
+		created := resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})
+		createdAt, err := time.Parse(time.RFC3339Nano, created["createdAt"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), createdAt, time.Minute)
+
+		resp = testutil.ExecuteGraphQL(t, client, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, done: true}) {
+					createdAt
+					updatedAt
+				}
+			}
+		`, map[string]interface{}{"id": created["id"]})
+		require.Empty(t, resp.Errors)
+
+		updated := resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})
+		assert.Equal(t, created["createdAt"], updated["createdAt"])
+		updatedAt, err := time.Parse(time.RFC3339Nano, updated["updatedAt"].(string))
+		require.NoError(t, err)
+		assert.True(t, updatedAt.After(createdAt), "updatedAt should move forward")
+	})
+
+	t.Run("orders and pages by recency", func(t *testing.T) {
+		client.Todo.Delete().ExecX(ctx)
+		base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < 5; i++ {
+			client.Todo.Create().
+				SetTitle(fmt.Sprintf("Todo %d", i)).
+				SetCreatedAt(base.Add(time.Duration(i) * time.Hour)).
+				SaveX(ctx)
+		}
+
+		query := `
+			query Page($after: String) {
+				todosConnection(first: 2, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+					edges {
+						node {
+							title
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		`
+
+		var titles []string
+		var after interface{}
+		for {
+			resp := testutil.ExecuteGraphQL(t, client, query, map[string]interface{}{"after": after})
+			require.Empty(t, resp.Errors)
+
+			conn := resp.Data.(map[string]interface{})["todosConnection"].(map[string]interface{})
+			for _, e := range conn["edges"].([]interface{}) {
+				titles = append(titles, e.(map[string]interface{})["node"].(map[string]interface{})["title"].(string))
+			}
+
+			pageInfo := conn["pageInfo"].(map[string]interface{})
+			if !pageInfo["hasNextPage"].(bool) {
+				break
+			}
+			after = pageInfo["endCursor"]
+		}
+
+		assert.Equal(t, []string{"Todo 4", "Todo 3", "Todo 2", "Todo 1", "Todo 0"}, titles)
+	})
+
+	t.Run("filters by updatedAfter", func(t *testing.T) {
+		client.Todo.Delete().ExecX(ctx)
+		since := time.Now()
+		client.Todo.Create().SetTitle("Old").SetUpdatedAt(since.Add(-time.Hour)).SaveX(ctx)
+		client.Todo.Create().SetTitle("New").SetUpdatedAt(since.Add(time.Second)).SaveX(ctx)
+		client.User.Create().SetEmail("old@example.com").SetName("Old").SetUpdatedAt(since.Add(-time.Hour)).SaveX(ctx)
+		client.User.Create().SetEmail("new@example.com").SetName("New").SetUpdatedAt(since.Add(time.Second)).SaveX(ctx)
+
+		resp := testutil.ExecuteGraphQL(t, client, `
+			query Changed($since: DateTime!) {
+				todos(where: {updatedAfter: $since}) {
+					title
+				}
+				users(where: {updatedAfter: $since}) {
+					name
+				}
+			}
+		`, map[string]interface{}{"since": since.Format(time.RFC3339Nano)})
+		require.Empty(t, resp.Errors)
+
+		data := resp.Data.(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "New"}}, data["todos"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "New"}}, data["users"])
+	})
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/ent/todo"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutationTransactions(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, _ := testutil.SeedTestData(t, client)
+	ctx := testutil.Context(client)
+
+	t.Run("reads its own writes in the response", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateTodo($userId: UUID) {
+				createTodo(input: {title: "In a transaction", userId: $userId}) {
+					title
+					user {
+						name
+						todos {
+							title
+						}
+					}
+				}
+			}
+		`, map[string]interface{}{"userId": user.ID.String()})
+		require.Empty(t, resp.Errors)
+
+		created := resp.Data.(map[string]interface{})["createTodo"].(map[string]interface{})
+		createdUser := created["user"].(map[string]interface{})
+		assert.Equal(t, "Test User", createdUser["name"])
+		assert.Contains(t, createdUser["todos"], map[string]interface{}{"title": "In a transaction"})
+	})
+
+	t.Run("rolls back every field when one fails", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateAndFail($missing: UUID!) {
+				created: createTodo(input: {title: "Rolled back"}) {
+					id
+				}
+				failed: updateTodo(input: {id: $missing, title: "Nope"}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"missing": uuid.New().String()})
+		require.NotEmpty(t, resp.Errors)
+		assert.Nil(t, resp.Data)
+
+		exists, err := client.Todo.Query().Where(todo.Title("Rolled back")).Exist(ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("checks the assigned user inside the transaction", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, `
+			mutation CreateOrphan($userId: UUID) {
+				createTodo(input: {title: "Orphan", userId: $userId}) {
+					id
+				}
+			}
+		`, map[string]interface{}{"userId": uuid.New().String()})
+		assert.NotEmpty(t, resp.Errors)
+
+		exists, err := client.Todo.Query().Where(todo.Title("Orphan")).Exist(ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
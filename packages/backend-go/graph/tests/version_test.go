@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, todo := testutil.SeedTestData(t, client)
+
+	updateTodo := `
+		mutation UpdateTodo($input: UpdateTodoInput!) {
+			updateTodo(input: $input) {
+				title
+				version
+			}
+		}
+	`
+
+	t.Run("bumps the version on every update", func(t *testing.T) {
+		assert.Equal(t, 1, todo.Version)
+
+		resp := testutil.ExecuteGraphQL(t, client, updateTodo, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "title": "First edit"},
+		})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, float64(2), resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})["version"])
+
+		resp = testutil.ExecuteGraphQL(t, client, updateTodo, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "title": "Second edit", "expectedVersion": 2},
+		})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, float64(3), resp.Data.(map[string]interface{})["updateTodo"].(map[string]interface{})["version"])
+	})
+
+	t.Run("rejects stale writes with the current state", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, updateTodo, map[string]interface{}{
+			"input": map[string]interface{}{"id": todo.ID.String(), "done": true, "expectedVersion": 2},
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Nil(t, resp.Data)
+
+		extensions := resp.Errors[0].Extensions
+		assert.Equal(t, "CONFLICT", extensions["code"])
+		current := extensions["current"].(map[string]interface{})
+		assert.Equal(t, model.GlobalID("Todo", todo.ID), current["id"])
+		assert.Equal(t, "Second edit", current["title"])
+		assert.Equal(t, false, current["completed"])
+		assert.Equal(t, float64(3), current["version"])
+	})
+
+	t.Run("reports missing todos as not found", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQL(t, client, updateTodo, map[string]interface{}{
+			"input": map[string]interface{}{"id": "00000000-0000-0000-0000-000000000000", "done": true, "expectedVersion": 1},
+		})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("rejects stale user writes", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, testutil.CreateGraphQLServer(client), testutil.ContextAs(client, user), `
+			mutation UpdateUser($input: UpdateUserInput!) {
+				updateUser(input: $input) {
+					version
+				}
+			}
+		`, map[string]interface{}{
+			"input": map[string]interface{}{"id": user.ID.String(), "name": "Renamed", "expectedVersion": 0},
+		})
+		require.Len(t, resp.Errors, 1)
+
+		extensions := resp.Errors[0].Extensions
+		assert.Equal(t, "CONFLICT", extensions["code"])
+		assert.Equal(t, "Test User", extensions["current"].(map[string]interface{})["name"])
+	})
+}
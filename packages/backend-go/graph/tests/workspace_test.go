@@ -0,0 +1,344 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"backend-go/auth"
+	"backend-go/ent/membership"
+	"backend-go/ent/todo"
+	"backend-go/graph"
+	"backend-go/graph/model"
+	"backend-go/graph/tests/testutil"
+	"backend-go/tenant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceIsolation(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, todoA := testutil.SeedTestData(t, client)
+	tagA := client.Tag.Create().SetName("urgent").SaveX(testutil.Context(client))
+
+	other := client.Workspace.Create().SetName("Other Workspace").SaveX(context.Background())
+	otherCtx := testutil.ContextIn(other.ID)
+	outsider := client.User.Create().SetEmail("outsider@example.com").SetName("Outsider").SaveX(otherCtx)
+	todoB := client.Todo.Create().SetTitle("Other Todo").SetUser(outsider).SaveX(otherCtx)
+
+	srv := testutil.CreateGraphQLServer(client)
+
+	// inOther executes an operation in the other workspace
+	inOther := func(t *testing.T, query string, variables map[string]interface{}) *testutil.GraphQLResponse {
+		return testutil.ExecuteGraphQLWithServerAndContext(t, srv, otherCtx, query, variables)
+	}
+
+	t.Run("reads only the rows of its workspace", func(t *testing.T) {
+		resp := inOther(t, `{
+			todos { title }
+			users { email }
+			tags { name }
+			workspace { name members { email } }
+		}`, nil)
+		require.Empty(t, resp.Errors)
+
+		data := resp.Data.(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{"title": "Other Todo"}}, data["todos"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "outsider@example.com"}}, data["users"])
+		assert.Empty(t, data["tags"])
+		assert.Equal(t, map[string]interface{}{
+			"name":    "Other Workspace",
+			"members": []interface{}{map[string]interface{}{"email": "outsider@example.com"}},
+		}, data["workspace"])
+	})
+
+	t.Run("can't look up rows of another workspace", func(t *testing.T) {
+		resp := inOther(t, `
+			query Nodes($ids: [ID!]!) {
+				nodes(ids: $ids) { id }
+			}
+		`, map[string]interface{}{"ids": []string{model.GlobalID("Todo", todoA.ID), model.GlobalID("User", user.ID), model.GlobalID("Tag", tagA.ID)}})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{nil, nil, nil}, resp.Data.(map[string]interface{})["nodes"])
+	})
+
+	t.Run("can't write rows of another workspace", func(t *testing.T) {
+		resp := inOther(t, `
+			mutation UpdateTodo($id: UUID!) {
+				updateTodo(input: {id: $id, title: "Hijacked"}) { id }
+			}
+		`, map[string]interface{}{"id": todoA.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = inOther(t, `
+			mutation DeleteUser($id: UUID!) {
+				deleteUser(id: $id)
+			}
+		`, map[string]interface{}{"id": user.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, auth.NewContext(otherCtx, outsider), `
+			mutation AddComment($todoId: UUID!) {
+				addComment(input: {todoId: $todoId, body: "Hello"}) { id }
+			}
+		`, map[string]interface{}{"todoId": todoA.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		assert.Equal(t, "Test Todo", client.Todo.GetX(testutil.Context(client), todoA.ID).Title)
+	})
+
+	t.Run("can't reference rows of another workspace", func(t *testing.T) {
+		resp := inOther(t, `
+			mutation CreateTodo($userId: UUID) {
+				createTodo(input: {title: "Assigned across", userId: $userId}) { id }
+			}
+		`, map[string]interface{}{"userId": user.ID.String()})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = inOther(t, `
+			mutation AddTags($todoId: UUID!, $tagIds: [UUID!]!) {
+				addTagsToTodo(todoId: $todoId, tagIds: $tagIds) { id }
+			}
+		`, map[string]interface{}{"todoId": todoB.ID.String(), "tagIds": []string{tagA.ID.String()}})
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("fails closed without a workspace", func(t *testing.T) {
+		_, err := client.Todo.Query().All(context.Background())
+		assert.ErrorIs(t, err, tenant.ErrNoWorkspace)
+
+		_, err = client.Todo.Create().SetTitle("Nowhere").Save(context.Background())
+		assert.ErrorIs(t, err, tenant.ErrNoWorkspace)
+
+		// Without the test helper filling in the workspace
+		bare := graph.NewServer(&graph.Resolver{Client: client, Broker: testutil.ChangeFeed(client)})
+		resp := testutil.ExecuteGraphQLWithServer(t, bare, `{ todos { id } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("keeps rows in the workspace they were created in", func(t *testing.T) {
+		_, err := client.Todo.Create().
+			SetTitle("Smuggled").
+			SetWorkspaceID(other.ID).
+			Save(testutil.Context(client))
+		assert.Error(t, err)
+
+		n := client.Todo.Query().Where(todo.Title("Smuggled")).CountX(tenant.System(context.Background()))
+		assert.Zero(t, n)
+	})
+
+	t.Run("system contexts span workspaces", func(t *testing.T) {
+		n := client.Todo.Query().CountX(tenant.System(context.Background()))
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("filters users by their todos in the workspace only", func(t *testing.T) {
+		// The user has a todo in the test workspace, but none in the other one
+		client.Membership.Create().SetUserID(user.ID).ExecX(otherCtx)
+
+		query := `
+			query Users($hasTodos: Boolean!) {
+				users(where: {hasTodos: $hasTodos}, orderBy: {field: EMAIL}) { email }
+			}
+		`
+		resp := inOther(t, query, map[string]interface{}{"hasTodos": true})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "outsider@example.com"}}, resp.Data.(map[string]interface{})["users"])
+
+		resp = inOther(t, query, map[string]interface{}{"hasTodos": false})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "test@example.com"}}, resp.Data.(map[string]interface{})["users"])
+
+		resp = testutil.ExecuteGraphQLWithServer(t, srv, query, map[string]interface{}{"hasTodos": true})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "test@example.com"}}, resp.Data.(map[string]interface{})["users"])
+	})
+}
+
+func TestWorkspaceMembers(t *testing.T) {
+	// Setup test database
+	client := testutil.SetupTestDB(t)
+	defer client.Close()
+
+	user, _ := testutil.SeedTestData(t, client)
+	srv := testutil.CreateGraphQLServer(client)
+
+	// Creating a workspace doesn't need one to be selected, but a logged in
+	// user, who owns it
+	bare := graph.NewServer(&graph.Resolver{Client: client, Broker: testutil.ChangeFeed(client)})
+	createWorkspace := `
+		mutation {
+			createWorkspace(input: {name: "Second"}) { id name }
+		}
+	`
+	resp := testutil.ExecuteGraphQLWithServer(t, bare, createWorkspace, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "UNAUTHENTICATED", resp.Errors[0].Extensions["code"])
+
+	creator := client.User.Create().SetEmail("creator@example.com").SetName("Creator").SaveX(testutil.Context(client))
+	resp = testutil.ExecuteGraphQLWithServerAndContext(t, bare, auth.NewContext(context.Background(), creator), createWorkspace, nil)
+	require.Empty(t, resp.Errors)
+	created := resp.Data.(map[string]interface{})["createWorkspace"].(map[string]interface{})
+	assert.Equal(t, "Second", created["name"])
+
+	secondCtx := tenant.NewContext(context.Background(), uuid.MustParse(created["id"].(string)))
+
+	members := func(t *testing.T) []interface{} {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, secondCtx, `{ users { email } }`, nil)
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["users"].([]interface{})
+	}
+	creatorOnly := []interface{}{map[string]interface{}{"email": "creator@example.com"}}
+	assert.Equal(t, creatorOnly, members(t))
+
+	// The creator, acting as the owner of the second workspace
+	creatorCtx := auth.WithRole(auth.NewContext(secondCtx, creator), membership.RoleOWNER)
+	// userCtx acts as user, who is only a member of the first workspace
+	userCtx := auth.NewContext(testutil.Context(client), user)
+
+	invite := func(t *testing.T, userID uuid.UUID) *testutil.GraphQLResponse {
+		return testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, `
+			mutation Invite($userId: UUID!) {
+				inviteWorkspaceMember(userId: $userId, role: ADMIN) { id workspaceName role }
+			}
+		`, map[string]interface{}{"userId": userID.String()})
+	}
+	invitations := func(t *testing.T, ctx context.Context) []interface{} {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, ctx, `{ invitations { id workspaceName role } }`, nil)
+		require.Empty(t, resp.Errors)
+		return resp.Data.(map[string]interface{})["invitations"].([]interface{})
+	}
+	removeMember := `
+		mutation RemoveMember($userId: UUID!) {
+			removeWorkspaceMember(userId: $userId)
+		}
+	`
+	variables := map[string]interface{}{"userId": user.ID.String()}
+
+	t.Run("foreign admins don't change users they don't share a workspace with", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, `
+			mutation AddMember($userId: UUID!) {
+				addWorkspaceMember(userId: $userId) { email }
+			}
+		`, variables)
+		require.NotEmpty(t, resp.Errors)
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, `
+			mutation DeleteUser($userId: UUID!) {
+				deleteUser(id: $userId)
+			}
+		`, variables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, `
+			mutation UpdateUser($userId: UUID!) {
+				updateUser(input: {id: $userId, email: "creator+takeover@example.com"}) { id }
+			}
+		`, variables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		current := client.User.GetX(testutil.Context(client), user.ID)
+		assert.Equal(t, "test@example.com", current.Email)
+		assert.Nil(t, current.DeletedAt)
+		assert.Equal(t, creatorOnly, members(t))
+	})
+
+	t.Run("invites users, who only join by accepting", func(t *testing.T) {
+		resp := invite(t, user.ID)
+		require.Empty(t, resp.Errors)
+		invitation := resp.Data.(map[string]interface{})["inviteWorkspaceMember"].(map[string]interface{})
+		assert.Equal(t, "Second", invitation["workspaceName"])
+		assert.Equal(t, creatorOnly, members(t))
+
+		// Admins still can't change the invited user
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, `
+			mutation DeleteUser($userId: UUID!) {
+				deleteUser(id: $userId)
+			}
+		`, variables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		// Nobody else accepts it
+		acceptInvitation := `
+			mutation Accept($id: UUID!) {
+				acceptInvitation(id: $id) { name viewerRole }
+			}
+		`
+		acceptVariables := map[string]interface{}{"id": invitation["id"]}
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, acceptInvitation, acceptVariables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		assert.Equal(t, []interface{}{invitation}, invitations(t, userCtx))
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, userCtx, acceptInvitation, acceptVariables)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"name": "Second", "viewerRole": "ADMIN"}, resp.Data.(map[string]interface{})["acceptInvitation"])
+
+		assert.ElementsMatch(t, append(creatorOnly, map[string]interface{}{"email": "test@example.com"}), members(t))
+		assert.Empty(t, invitations(t, userCtx))
+	})
+
+	t.Run("inviting members is a CONFLICT", func(t *testing.T) {
+		resp := invite(t, user.ID)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("declined and cancelled invitations go away", func(t *testing.T) {
+		invitee := client.User.Create().SetEmail("invitee@example.com").SetName("Invitee").SaveX(testutil.Context(client))
+		inviteeCtx := auth.NewContext(testutil.Context(client), invitee)
+
+		resp := invite(t, invitee.ID)
+		require.Empty(t, resp.Errors)
+		resp = invite(t, invitee.ID)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "CONFLICT", resp.Errors[0].Extensions["code"])
+
+		id := invitations(t, inviteeCtx)[0].(map[string]interface{})["id"]
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, inviteeCtx, `
+			mutation Decline($id: UUID!) { declineInvitation(id: $id) }
+		`, map[string]interface{}{"id": id})
+		require.Empty(t, resp.Errors)
+		assert.Empty(t, invitations(t, inviteeCtx))
+
+		resp = invite(t, invitee.ID)
+		require.Empty(t, resp.Errors)
+		cancel := `mutation Cancel($id: UUID!) { cancelInvitation(id: $id) }`
+		cancelVariables := map[string]interface{}{"id": resp.Data.(map[string]interface{})["inviteWorkspaceMember"].(map[string]interface{})["id"]}
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, cancel, cancelVariables)
+		require.Empty(t, resp.Errors)
+		assert.Empty(t, invitations(t, inviteeCtx))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, creatorCtx, cancel, cancelVariables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("removes members", func(t *testing.T) {
+		resp := testutil.ExecuteGraphQLWithServerAndContext(t, srv, secondCtx, removeMember, variables)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, creatorOnly, members(t))
+
+		resp = testutil.ExecuteGraphQLWithServerAndContext(t, srv, secondCtx, removeMember, variables)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+		// The first workspace keeps the user
+		resp = testutil.ExecuteGraphQL(t, client, `{ users { email } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Len(t, resp.Data.(map[string]interface{})["users"], 3)
+	})
+}
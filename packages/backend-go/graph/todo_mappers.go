@@ -4,7 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"backend-go/apperror"
+	"backend-go/auth"
 	"backend-go/ent"
+	"backend-go/ent/predicate"
+	"backend-go/ent/project"
+	"backend-go/ent/schema"
+	"backend-go/ent/tag"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
 	"backend-go/graph/model"
 
 	"github.com/google/uuid"
@@ -23,56 +31,98 @@ import (
 // downstreamTodoMapper converts an Ent Todo entity to a GraphQL model
 func downstreamTodoMapper(entTodo *ent.Todo) *model.Todo {
 	todo := &model.Todo{
-		ID:        entTodo.ID.String(),
-		Title:     entTodo.Title,
-		Completed: entTodo.Completed,
+		ID:          model.GlobalID("Todo", entTodo.ID),
+		Title:       entTodo.Title,
+		Completed:   entTodo.Completed,
+		DueAt:       entTodo.DueAt,
+		Priority:    model.TodoPriority(entTodo.Priority),
+		CompletedAt: entTodo.CompletedAt,
+		RemindedAt:  entTodo.RemindedAt,
+		CreatedAt:   entTodo.CreatedAt,
+		UpdatedAt:   entTodo.UpdatedAt,
+		DeletedAt:   entTodo.DeletedAt,
+		Version:     entTodo.Version,
 	}
 
-	// Add user relationship if loaded
-	if entTodo.Edges.User != nil {
-		user := downstreamUserMapper(entTodo.Edges.User)
-		todo.User = user
-		// Also set the userID from the loaded user
-		todo.UserID = &user.ID
-	}
+	// The user, parent and project themselves are resolved lazily by field
+	// resolvers
+	todo.UserID = entTodo.UserID
+	todo.ParentID = entTodo.ParentID
+	todo.ProjectID = entTodo.ProjectID
 
 	return todo
 }
 
+// downstreamTodoConflictMapper reports an update that expected another version
+// of entTodo, carrying its current state for the client to merge with
+func downstreamTodoConflictMapper(entTodo *ent.Todo) error {
+	return apperror.Conflict("todo with id %s was changed concurrently, it is at version %d", entTodo.ID, entTodo.Version).
+		WithCurrent(downstreamTodoMapper(entTodo))
+}
+
+// downstreamTodoConnectionMapper converts a page of Ent Todo entities to a GraphQL connection
+func downstreamTodoConnectionMapper(page *pageResult[*ent.Todo]) *model.TodoConnection {
+	edges := make([]*model.TodoEdge, len(page.nodes))
+	for i, entTodo := range page.nodes {
+		edges[i] = &model.TodoEdge{
+			Node:   downstreamTodoMapper(entTodo),
+			Cursor: page.cursors[i],
+		}
+	}
+
+	return &model.TodoConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo,
+		TotalCount: page.totalCount,
+	}
+}
+
 // =============================================================================
 // UPSTREAM MAPPERS (Frontend → Database)
 // =============================================================================
 
 // upstreamCreateTodoMapper prepares a todo creation operation from GraphQL input
-func upstreamCreateTodoMapper(client *ent.Client, input model.CreateTodoInput) (*ent.TodoCreate, error) {
+func upstreamCreateTodoMapper(ctx context.Context, client *ent.Client, input model.CreateTodoInput) (*ent.TodoCreate, error) {
 	createQuery := client.Todo.
 		Create().
 		SetTitle(input.Title)
 
 	// Set user if provided
 	if input.UserID != nil {
-		userID, err := uuid.Parse(*input.UserID)
-		if err == nil { // Only set if valid UUID
-			// make sure the user exists
-			_, err = client.User.Get(context.Background(), userID)
-			if err != nil {
-				return nil, fmt.Errorf("user with id %s not found", userID)
-			}
-			createQuery = createQuery.SetUserID(userID)
+		userID, err := upstreamAssigneeMapper(ctx, client, *input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		createQuery = createQuery.SetUserID(userID)
+	}
+	if input.DueAt != nil {
+		createQuery = createQuery.SetDueAt(*input.DueAt)
+	}
+	if input.Priority != nil {
+		createQuery = createQuery.SetPriority(upstreamTodoPriorityMapper(*input.Priority))
+	}
+	if input.ParentID != nil {
+		// A new todo has no subtasks yet, so it can't end up among them
+		parentID, err := upstreamParentMapper(ctx, client, uuid.Nil, *input.ParentID)
+		if err != nil {
+			return nil, err
 		}
+		createQuery = createQuery.SetParentID(parentID)
+	}
+	if input.ProjectID != nil {
+		projectID, err := upstreamProjectMapper(ctx, client, *input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		createQuery = createQuery.SetProjectID(projectID)
 	}
 
 	return createQuery, nil
 }
 
 // upstreamUpdateTodoMapper prepares a todo update operation from GraphQL input
-func upstreamUpdateTodoMapper(client *ent.Client, input model.UpdateTodoInput) (*ent.TodoUpdateOne, error) {
-	todoID, err := uuid.Parse(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid todo ID: %w", err)
-	}
-
-	updateQuery := client.Todo.UpdateOneID(todoID)
+func upstreamUpdateTodoMapper(ctx context.Context, client *ent.Client, input model.UpdateTodoInput) (*ent.TodoUpdateOne, error) {
+	updateQuery := client.Todo.UpdateOneID(input.ID)
 
 	if input.Title != nil {
 		updateQuery = updateQuery.SetTitle(*input.Title)
@@ -80,17 +130,264 @@ func upstreamUpdateTodoMapper(client *ent.Client, input model.UpdateTodoInput) (
 	if input.Done != nil {
 		updateQuery = updateQuery.SetCompleted(*input.Done)
 	}
-	if input.UserID != nil {
-		userID, err := uuid.Parse(*input.UserID)
-		if err == nil { // Only set if valid UUID
-			// make sure the user exists
-			_, err = client.User.Get(context.Background(), userID)
+	// An explicit null unassigns the todo, leaving userId out keeps the assignee
+	if value, ok := input.UserID.ValueOK(); ok {
+		if value == nil {
+			updateQuery = updateQuery.ClearUserID()
+		} else {
+			userID, err := upstreamAssigneeMapper(ctx, client, *value)
 			if err != nil {
-				return nil, fmt.Errorf("user with id %s not found", userID)
+				return nil, err
 			}
 			updateQuery = updateQuery.SetUserID(userID)
 		}
 	}
+	// An explicit null clears the due date, leaving dueAt out keeps it
+	if value, ok := input.DueAt.ValueOK(); ok {
+		if value == nil {
+			updateQuery = updateQuery.ClearDueAt()
+		} else {
+			updateQuery = updateQuery.SetDueAt(*value)
+		}
+	}
+	if input.Priority != nil {
+		updateQuery = updateQuery.SetPriority(upstreamTodoPriorityMapper(*input.Priority))
+	}
+	// An explicit null makes the todo top-level, leaving parentId out keeps it
+	if value, ok := input.ParentID.ValueOK(); ok {
+		if value == nil {
+			updateQuery = updateQuery.ClearParentID()
+		} else {
+			parentID, err := upstreamParentMapper(ctx, client, input.ID, *value)
+			if err != nil {
+				return nil, err
+			}
+			updateQuery = updateQuery.SetParentID(parentID)
+		}
+	}
+	// An explicit null takes the todo out of its project, leaving projectId
+	// out keeps it
+	if value, ok := input.ProjectID.ValueOK(); ok {
+		if value == nil {
+			updateQuery = updateQuery.ClearProjectID()
+		} else {
+			projectID, err := upstreamProjectMapper(ctx, client, *value)
+			if err != nil {
+				return nil, err
+			}
+			updateQuery = updateQuery.SetProjectID(projectID)
+		}
+	}
+	// Stale writes match no row, the resolver tells them apart from missing todos
+	if input.ExpectedVersion != nil {
+		updateQuery = updateQuery.Where(todo.Version(*input.ExpectedVersion))
+	}
 
 	return updateQuery, nil
 }
+
+// upstreamAssigneeMapper makes sure the user passed as input.userId of a todo
+// mutation exists
+func upstreamAssigneeMapper(ctx context.Context, client *ent.Client, userID uuid.UUID) (uuid.UUID, error) {
+	exists, err := client.User.Query().Where(user.ID(userID)).Exist(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if !exists {
+		return uuid.Nil, apperror.NotFound("user with id %s not found", userID).
+			WithField("input.userId", "user does not exist")
+	}
+
+	return userID, nil
+}
+
+// upstreamParentMapper makes sure the todo passed as input.parentId of a todo
+// mutation exists and isn't todoID or one of its subtasks, which would make
+// the hierarchy a cycle. The hierarchy stays locked until the mutation's
+// transaction ends, so the check holds when the todo is written.
+func upstreamParentMapper(ctx context.Context, client *ent.Client, todoID, parentID uuid.UUID) (uuid.UUID, error) {
+	if err := lockHierarchy(ctx, client); err != nil {
+		return uuid.Nil, err
+	}
+
+	exists, err := client.Todo.Query().Where(todo.ID(parentID)).Exist(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up parent todo: %w", err)
+	}
+	if !exists {
+		return uuid.Nil, apperror.NotFound("todo with id %s not found", parentID).
+			WithField("input.parentId", "todo does not exist")
+	}
+
+	// Walk up from the parent, through deleted todos as well since they may
+	// be restored
+	visited := make(map[uuid.UUID]bool)
+	for ancestorID := &parentID; ancestorID != nil && !visited[*ancestorID]; {
+		if *ancestorID == todoID {
+			return uuid.Nil, apperror.InvalidArgument("input.parentId", "todo cannot be a subtask of itself or of its own subtasks")
+		}
+		visited[*ancestorID] = true
+
+		ancestor, err := client.Todo.Get(schema.SkipSoftDelete(ctx), *ancestorID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to look up parent todo: %w", err)
+		}
+		ancestorID = ancestor.ParentID
+	}
+
+	return parentID, nil
+}
+
+// upstreamTodoWhereMapper converts GraphQL todo filters to Ent predicates.
+// Filters relative to the logged in user are resolved from ctx.
+func upstreamTodoWhereMapper(ctx context.Context, where *model.TodoWhereInput) []predicate.Todo {
+	if where == nil {
+		return nil
+	}
+
+	var predicates []predicate.Todo
+	if where.TitleContains != nil {
+		predicates = append(predicates, todo.TitleContainsFold(*where.TitleContains))
+	}
+	if where.Completed != nil {
+		predicates = append(predicates, todo.Completed(*where.Completed))
+	}
+	if where.UserIDIn != nil {
+		predicates = append(predicates, todo.UserIDIn(where.UserIDIn...))
+	}
+	if where.UserIDIsNull != nil {
+		if *where.UserIDIsNull {
+			predicates = append(predicates, todo.UserIDIsNil())
+		} else {
+			predicates = append(predicates, todo.UserIDNotNil())
+		}
+	}
+	if where.AssigneeID != nil {
+		predicates = append(predicates, todo.HasAssigneesWith(user.ID(*where.AssigneeID)))
+	}
+	if where.AssignedToMe != nil {
+		// @loggedIn makes sure there is a viewer, match nothing without one
+		viewerID := uuid.Nil
+		if viewer, ok := auth.FromContext(ctx); ok {
+			viewerID = viewer.ID
+		}
+		if *where.AssignedToMe {
+			predicates = append(predicates, todo.HasAssigneesWith(user.ID(viewerID)))
+		} else {
+			predicates = append(predicates, todo.Not(todo.HasAssigneesWith(user.ID(viewerID))))
+		}
+	}
+	if where.WatcherID != nil {
+		predicates = append(predicates, todo.HasWatchersWith(user.ID(*where.WatcherID)))
+	}
+	if where.HasAssignees != nil {
+		if *where.HasAssignees {
+			predicates = append(predicates, todo.HasAssignees())
+		} else {
+			predicates = append(predicates, todo.Not(todo.HasAssignees()))
+		}
+	}
+	if where.DueBefore != nil {
+		predicates = append(predicates, todo.DueAtLT(*where.DueBefore))
+	}
+	if where.DueAfter != nil {
+		predicates = append(predicates, todo.DueAtGT(*where.DueAfter))
+	}
+	if where.HasDueDate != nil {
+		if *where.HasDueDate {
+			predicates = append(predicates, todo.DueAtNotNil())
+		} else {
+			predicates = append(predicates, todo.DueAtIsNil())
+		}
+	}
+	if where.PriorityIn != nil {
+		priorities := make([]todo.Priority, len(where.PriorityIn))
+		for i, priority := range where.PriorityIn {
+			priorities[i] = upstreamTodoPriorityMapper(priority)
+		}
+		predicates = append(predicates, todo.PriorityIn(priorities...))
+	}
+	if where.ProjectIDIn != nil {
+		predicates = append(predicates, todo.ProjectIDIn(where.ProjectIDIn...))
+	}
+	if where.HasParent != nil {
+		if *where.HasParent {
+			predicates = append(predicates, todo.ParentIDNotNil())
+		} else {
+			predicates = append(predicates, todo.ParentIDIsNil())
+		}
+	}
+	if where.CompletedBefore != nil {
+		predicates = append(predicates, todo.CompletedAtLT(*where.CompletedBefore))
+	}
+	if where.CompletedAfter != nil {
+		predicates = append(predicates, todo.CompletedAtGT(*where.CompletedAfter))
+	}
+	if where.UpdatedAfter != nil {
+		predicates = append(predicates, todo.UpdatedAtGT(*where.UpdatedAfter))
+	}
+	if where.HasAnyTag != nil {
+		predicates = append(predicates, todo.HasTagsWith(tag.IDIn(where.HasAnyTag...)))
+	}
+	// A todo carries every tag when it has an edge to each of them
+	for _, tagID := range where.HasAllTags {
+		predicates = append(predicates, todo.HasTagsWith(tag.ID(tagID)))
+	}
+
+	return predicates
+}
+
+// upstreamTodoListingMapper is upstreamTodoWhereMapper for the default todo
+// listings, which leave out the todos of archived projects unless
+// where.includeArchived is set
+func upstreamTodoListingMapper(ctx context.Context, where *model.TodoWhereInput) []predicate.Todo {
+	predicates := upstreamTodoWhereMapper(ctx, where)
+	if where == nil || where.IncludeArchived == nil || !*where.IncludeArchived {
+		predicates = append(predicates, todo.Or(
+			todo.ProjectIDIsNil(),
+			todo.HasProjectWith(project.Archived(false)),
+		))
+	}
+
+	return predicates
+}
+
+// upstreamTodoPriorityMapper converts a GraphQL todo priority to its Ent value
+func upstreamTodoPriorityMapper(priority model.TodoPriority) todo.Priority {
+	// Both enums share their values
+	return todo.Priority(priority)
+}
+
+// todoPriorityRanks lists the priorities from lowest to highest
+var todoPriorityRanks = []string{
+	todo.PriorityLOW.String(),
+	todo.PriorityMEDIUM.String(),
+	todo.PriorityHIGH.String(),
+	todo.PriorityURGENT.String(),
+}
+
+// upstreamTodoOrderMapper converts a GraphQL todo ordering to the column todos are sorted by
+func upstreamTodoOrderMapper(order *model.TodoOrder) pageOrder {
+	if order == nil {
+		return pageOrder{}
+	}
+
+	switch order.Field {
+	case model.TodoOrderFieldTitle:
+		return newPageOrder(todo.FieldTitle, order.Direction)
+	case model.TodoOrderFieldCompleted:
+		return newPageOrder(todo.FieldCompleted, order.Direction)
+	case model.TodoOrderFieldCreatedAt:
+		return newTimePageOrder(todo.FieldCreatedAt, order.Direction)
+	case model.TodoOrderFieldUpdatedAt:
+		return newTimePageOrder(todo.FieldUpdatedAt, order.Direction)
+	case model.TodoOrderFieldDueAt:
+		return newNullableTimePageOrder(todo.FieldDueAt, order.Direction)
+	case model.TodoOrderFieldPriority:
+		return newRankedPageOrder(todo.FieldPriority, todoPriorityRanks, order.Direction)
+	case model.TodoOrderFieldCompletedAt:
+		return newNullableTimePageOrder(todo.FieldCompletedAt, order.Direction)
+	default:
+		return newPageOrder("", order.Direction)
+	}
+}
@@ -5,12 +5,18 @@ package graph
 // Code generated by github.com/99designs/gqlgen version v0.17.78
 
 import (
+	"backend-go/apperror"
 	"backend-go/ent"
+	"backend-go/ent/schema"
 	"backend-go/ent/todo"
+	"backend-go/ent/user"
 	"backend-go/graph/generated"
+	"backend-go/graph/loader"
 	"backend-go/graph/model"
+	"backend-go/pubsub"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -18,7 +24,7 @@ import (
 // CreateTodo is the resolver for the createTodo field.
 func (r *mutationResolver) CreateTodo(ctx context.Context, input model.CreateTodoInput) (*model.Todo, error) {
 	// Use upstream mapper to prepare the creation operation
-	createQuery, err := upstreamCreateTodoMapper(r.Client, input)
+	createQuery, err := upstreamCreateTodoMapper(ctx, r.client(ctx), input)
 	if err != nil {
 		return nil, err
 	}
@@ -28,18 +34,6 @@ func (r *mutationResolver) CreateTodo(ctx context.Context, input model.CreateTod
 		return nil, fmt.Errorf("failed to create todo: %w", err)
 	}
 
-	// Reload with user relationship if user was set
-	if input.UserID != nil {
-		entTodo, err = r.Client.Todo.
-			Query().
-			Where(todo.ID(entTodo.ID)).
-			WithUser().
-			Only(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to reload todo with user: %w", err)
-		}
-	}
-
 	// Use downstream mapper to convert to GraphQL model
 	return downstreamTodoMapper(entTodo), nil
 }
@@ -47,34 +41,54 @@ func (r *mutationResolver) CreateTodo(ctx context.Context, input model.CreateTod
 // UpdateTodo is the resolver for the updateTodo field.
 func (r *mutationResolver) UpdateTodo(ctx context.Context, input model.UpdateTodoInput) (*model.Todo, error) {
 	// Use upstream mapper to prepare the update operation
-	updateQuery, err := upstreamUpdateTodoMapper(r.Client, input)
+	updateQuery, err := upstreamUpdateTodoMapper(ctx, r.client(ctx), input)
 	if err != nil {
 		return nil, err
 	}
 
 	entTodo, err := updateQuery.Save(ctx)
+	if ent.IsNotFound(err) && input.ExpectedVersion != nil {
+		// The todo may exist at another version than the expected one
+		current, getErr := r.client(ctx).Todo.Get(ctx, input.ID)
+		switch {
+		case getErr == nil:
+			return nil, downstreamTodoConflictMapper(current)
+		case !ent.IsNotFound(getErr):
+			return nil, fmt.Errorf("failed to update todo: %w", getErr)
+		}
+	}
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return nil, fmt.Errorf("todo with id %s not found", input.ID)
+			return nil, apperror.NotFound("todo with id %s not found", input.ID)
 		}
 		return nil, fmt.Errorf("failed to update todo: %w", err)
 	}
 
+	if input.CompleteSubtasks != nil && *input.CompleteSubtasks && input.Done != nil && *input.Done {
+		if err := completeSubtasks(ctx, r.client(ctx), entTodo.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Use downstream mapper to convert to GraphQL model
 	return downstreamTodoMapper(entTodo), nil
 }
 
 // DeleteTodo is the resolver for the deleteTodo field.
-func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (bool, error) {
-	todoID, err := uuid.Parse(id)
+func (r *mutationResolver) DeleteTodo(ctx context.Context, id uuid.UUID) (bool, error) {
+	// Keep the last state of the todo for subscribers
+	entTodo, err := r.client(ctx).Todo.Get(ctx, id)
 	if err != nil {
-		return false, fmt.Errorf("invalid todo ID: %w", err)
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("todo with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to delete todo: %w", err)
 	}
 
-	err = r.Client.Todo.DeleteOneID(todoID).Exec(ctx)
+	err = r.client(ctx).Todo.DeleteOne(entTodo).Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return false, nil // Todo didn't exist, but that's okay
+			return false, apperror.NotFound("todo with id %s not found", id)
 		}
 		return false, fmt.Errorf("failed to delete todo: %w", err)
 	}
@@ -82,10 +96,36 @@ func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (bool, err
 	return true, nil
 }
 
+// RestoreTodo is the resolver for the restoreTodo field.
+func (r *mutationResolver) RestoreTodo(ctx context.Context, id uuid.UUID) (*model.Todo, error) {
+	// Deleted todos can only be updated with soft deletion skipped
+	entTodo, err := r.client(ctx).Todo.UpdateOneID(id).
+		Where(todo.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Save(schema.SkipSoftDelete(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("deleted todo with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to restore todo: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
 // Todos is the resolver for the todos field.
-func (r *queryResolver) Todos(ctx context.Context) ([]*model.Todo, error) {
-	entTodos, err := r.Client.Todo.Query().
-		WithUser(). // Load user relationship
+func (r *queryResolver) Todos(ctx context.Context, where *model.TodoWhereInput, orderBy *model.TodoOrder) ([]*model.Todo, error) {
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	order := upstreamTodoOrderMapper(orderBy)
+
+	entTodos, err := r.client(ctx).Todo.Query().
+		Where(upstreamTodoListingMapper(ctx, where)...).
+		Order(todo.OrderOption(order.apply(false))).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query todos: %w", err)
@@ -100,11 +140,170 @@ func (r *queryResolver) Todos(ctx context.Context) ([]*model.Todo, error) {
 	return todos, nil
 }
 
+// OverdueTodos is the resolver for the overdueTodos field.
+func (r *queryResolver) OverdueTodos(ctx context.Context, userID uuid.UUID) ([]*model.Todo, error) {
+	entTodos, err := r.client(ctx).Todo.Query().
+		Where(
+			todo.HasAssigneesWith(user.ID(userID)),
+			todo.Completed(false),
+			todo.DueAtLT(time.Now()),
+		).
+		Where(upstreamTodoListingMapper(ctx, nil)...).
+		Order(todo.ByDueAt(), todo.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overdue todos: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	todos := make([]*model.Todo, len(entTodos))
+	for i, entTodo := range entTodos {
+		todos[i] = downstreamTodoMapper(entTodo)
+	}
+
+	return todos, nil
+}
+
+// TodosConnection is the resolver for the todosConnection field.
+func (r *queryResolver) TodosConnection(ctx context.Context, first *int, after *string, last *int, before *string, where *model.TodoWhereInput, orderBy *model.TodoOrder) (*model.TodoConnection, error) {
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	page, err := newPageArgs(first, after, last, before, upstreamTodoOrderMapper(orderBy))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := paginateTodos(ctx, r.client(ctx).Todo.Query().Where(upstreamTodoListingMapper(ctx, where)...), page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to a GraphQL connection
+	return downstreamTodoConnectionMapper(result), nil
+}
+
+// TodoCreated is the resolver for the todoCreated field.
+func (r *subscriptionResolver) TodoCreated(ctx context.Context, userID *uuid.UUID) (<-chan *model.Todo, error) {
+	return r.subscribeTodos(ctx, pubsub.OpCreate, userID)
+}
+
+// TodoUpdated is the resolver for the todoUpdated field.
+func (r *subscriptionResolver) TodoUpdated(ctx context.Context, userID *uuid.UUID) (<-chan *model.Todo, error) {
+	return r.subscribeTodos(ctx, pubsub.OpUpdate, userID)
+}
+
+// TodoDeleted is the resolver for the todoDeleted field.
+func (r *subscriptionResolver) TodoDeleted(ctx context.Context, userID *uuid.UUID) (<-chan *model.Todo, error) {
+	return r.subscribeTodos(ctx, pubsub.OpDelete, userID)
+}
+
+// TodoReminder is the resolver for the todoReminder field.
+func (r *subscriptionResolver) TodoReminder(ctx context.Context, userID *uuid.UUID) (<-chan *model.Todo, error) {
+	return r.subscribeTodos(ctx, pubsub.OpRemind, userID)
+}
+
+// User is the resolver for the user field.
+func (r *todoResolver) User(ctx context.Context, obj *model.Todo) (*model.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+
+	// Batched with the users of every other todo in the response
+	entUser, err := loader.For(ctx).UserByID.Load(ctx, *obj.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if entUser == nil {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(entUser), nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *todoResolver) Parent(ctx context.Context, obj *model.Todo) (*model.Todo, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	// Batched with the parents of every other todo in the response
+	entTodo, err := loader.For(ctx).TodoByID.Load(ctx, *obj.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if entTodo == nil {
+		return nil, nil
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamTodoMapper(entTodo), nil
+}
+
+// Subtasks is the resolver for the subtasks field.
+func (r *todoResolver) Subtasks(ctx context.Context, obj *model.Todo) ([]*model.Todo, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	// Batched with the subtasks of every other todo in the response
+	entTodos, err := loader.For(ctx).SubtasksByParentID.Load(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	todos := make([]*model.Todo, len(entTodos))
+	for i, entTodo := range entTodos {
+		todos[i] = downstreamTodoMapper(entTodo)
+	}
+
+	return todos, nil
+}
+
+// Progress is the resolver for the progress field.
+func (r *todoResolver) Progress(ctx context.Context, obj *model.Todo) (*float64, error) {
+	todoID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	// Shares its batch with Todo.subtasks
+	entTodos, err := loader.For(ctx).SubtasksByParentID.Load(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entTodos) == 0 {
+		return nil, nil
+	}
+
+	completed := 0
+	for _, entTodo := range entTodos {
+		if entTodo.Completed {
+			completed++
+		}
+	}
+	progress := float64(completed) / float64(len(entTodos))
+	return &progress, nil
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+// Todo returns generated.TodoResolver implementation.
+func (r *Resolver) Todo() generated.TodoResolver { return &todoResolver{r} }
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type todoResolver struct{ *Resolver }
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"backend-go/ent"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Transactioner runs every mutation operation inside a single ent.Tx. The
+// transaction commits when the operation resolves without errors and rolls
+// back otherwise, taking the writes of its sibling mutation fields with it.
+// Resolvers reach the transaction through Resolver.client.
+type Transactioner struct {
+	Client *ent.Client
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = Transactioner{}
+
+// ExtensionName returns the name the extension is registered under
+func (Transactioner) ExtensionName() string {
+	return "EntTransactioner"
+}
+
+// Validate makes sure the extension has a client to open transactions with
+func (t Transactioner) Validate(graphql.ExecutableSchema) error {
+	if t.Client == nil {
+		return fmt.Errorf("transactioner requires an ent client")
+	}
+	return nil
+}
+
+// InterceptOperation opens the transaction of a mutation and settles it once
+// the response is ready
+func (t Transactioner) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if graphql.GetOperationContext(ctx).Operation.Operation != ast.Mutation {
+		return next(ctx)
+	}
+
+	tx, err := t.Client.Tx(ctx)
+	if err != nil {
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "failed to start transaction: %v", err))
+	}
+	responses := next(ent.NewTxContext(ctx, tx))
+
+	return func(ctx context.Context) *graphql.Response {
+		resp := responses(ctx)
+		if resp == nil || len(resp.Errors) > 0 {
+			if err := tx.Rollback(); err != nil {
+				log.Printf("failed to roll back transaction: %v", err)
+			}
+			if resp != nil {
+				// Nothing in the response was persisted
+				resp.Data = json.RawMessage("null")
+			}
+			return resp
+		}
+
+		if err := tx.Commit(); err != nil {
+			return graphql.ErrorResponse(ctx, "failed to commit transaction: %v", err)
+		}
+		return resp
+	}
+}
+
+// client returns the client of the transaction the request runs in, or the
+// plain client outside of mutations
+func (r *Resolver) client(ctx context.Context) *ent.Client {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	return r.Client
+}
@@ -1,10 +1,16 @@
 package graph
 
 import (
+	"context"
 	"fmt"
 
+	"backend-go/apperror"
 	"backend-go/ent"
+	"backend-go/ent/predicate"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
 	"backend-go/graph/model"
+	"backend-go/tenant"
 
 	"github.com/google/uuid"
 )
@@ -21,23 +27,40 @@ import (
 
 // downstreamUserMapper converts an Ent User entity to a GraphQL model
 func downstreamUserMapper(entUser *ent.User) *model.User {
-	user := &model.User{
-		ID:    entUser.ID.String(),
-		Email: entUser.Email,
-		Name:  entUser.Name,
-		Todos: []*model.Todo{}, // Initialize empty slice
-	}
-
-	// Add todos relationship if loaded
-	if entUser.Edges.Todos != nil {
-		todos := make([]*model.Todo, len(entUser.Edges.Todos))
-		for i, entTodo := range entUser.Edges.Todos {
-			todos[i] = downstreamTodoMapper(entTodo)
+	// Todos are resolved lazily by the User.todos field resolver
+	return &model.User{
+		ID:        model.GlobalID("User", entUser.ID),
+		Email:     &entUser.Email,
+		Name:      entUser.Name,
+		CreatedAt: entUser.CreatedAt,
+		UpdatedAt: entUser.UpdatedAt,
+		DeletedAt: entUser.DeletedAt,
+		Version:   entUser.Version,
+	}
+}
+
+// downstreamUserConflictMapper reports an update that expected another version
+// of entUser, carrying its current state for the client to merge with
+func downstreamUserConflictMapper(entUser *ent.User) error {
+	return apperror.Conflict("user with id %s was changed concurrently, it is at version %d", entUser.ID, entUser.Version).
+		WithCurrent(downstreamUserMapper(entUser))
+}
+
+// downstreamUserConnectionMapper converts a page of Ent User entities to a GraphQL connection
+func downstreamUserConnectionMapper(page *pageResult[*ent.User]) *model.UserConnection {
+	edges := make([]*model.UserEdge, len(page.nodes))
+	for i, entUser := range page.nodes {
+		edges[i] = &model.UserEdge{
+			Node:   downstreamUserMapper(entUser),
+			Cursor: page.cursors[i],
 		}
-		user.Todos = todos
 	}
 
-	return user
+	return &model.UserConnection{
+		Edges:      edges,
+		PageInfo:   page.pageInfo,
+		TotalCount: page.totalCount,
+	}
 }
 
 // =============================================================================
@@ -45,7 +68,7 @@ func downstreamUserMapper(entUser *ent.User) *model.User {
 // =============================================================================
 
 // upstreamCreateUserMapper prepares a user creation operation from GraphQL input
-func upstreamCreateUserMapper(client *ent.Client, input model.CreateUserInput) *ent.UserCreate {
+func upstreamCreateUserMapper(ctx context.Context, client *ent.Client, input model.CreateUserInput) *ent.UserCreate {
 	return client.User.
 		Create().
 		SetEmail(input.Email).
@@ -53,13 +76,8 @@ func upstreamCreateUserMapper(client *ent.Client, input model.CreateUserInput) *
 }
 
 // upstreamUpdateUserMapper prepares a user update operation from GraphQL input
-func upstreamUpdateUserMapper(client *ent.Client, input model.UpdateUserInput) (*ent.UserUpdateOne, error) {
-	userID, err := uuid.Parse(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
-	}
-
-	updateQuery := client.User.UpdateOneID(userID)
+func upstreamUpdateUserMapper(ctx context.Context, client *ent.Client, input model.UpdateUserInput) *ent.UserUpdateOne {
+	updateQuery := client.User.UpdateOneID(input.ID)
 
 	if input.Email != nil {
 		updateQuery = updateQuery.SetEmail(*input.Email)
@@ -67,6 +85,103 @@ func upstreamUpdateUserMapper(client *ent.Client, input model.UpdateUserInput) (
 	if input.Name != nil {
 		updateQuery = updateQuery.SetName(*input.Name)
 	}
+	// Stale writes match no row, the resolver tells them apart from missing users
+	if input.ExpectedVersion != nil {
+		updateQuery = updateQuery.Where(user.Version(*input.ExpectedVersion))
+	}
+
+	return updateQuery
+}
+
+// upstreamUserWhereMapper converts GraphQL user filters to Ent predicates.
+// Users span workspaces, so filters on their todos only look at the todos of
+// the workspace of ctx.
+func upstreamUserWhereMapper(ctx context.Context, where *model.UserWhereInput) []predicate.User {
+	if where == nil {
+		return nil
+	}
+
+	var predicates []predicate.User
+	if where.Email != nil {
+		predicates = append(predicates, user.EmailEqualFold(*where.Email))
+	}
+	if where.EmailContains != nil {
+		predicates = append(predicates, user.EmailContainsFold(*where.EmailContains))
+	}
+	if where.NameContains != nil {
+		predicates = append(predicates, user.NameContainsFold(*where.NameContains))
+	}
+	if where.HasTodos != nil {
+		// Edge predicates bypass query interceptors, so deleted todos and
+		// those of other workspaces are skipped explicitly
+		workspaceID, _ := tenant.FromContext(ctx)
+		hasTodos := user.HasTodosWith(todo.DeletedAtIsNil(), todo.WorkspaceID(workspaceID))
+		if *where.HasTodos {
+			predicates = append(predicates, hasTodos)
+		} else {
+			predicates = append(predicates, user.Not(hasTodos))
+		}
+	}
+	if where.UpdatedAfter != nil {
+		predicates = append(predicates, user.UpdatedAtGT(*where.UpdatedAfter))
+	}
+
+	return predicates
+}
+
+// upstreamUserOrderMapper converts a GraphQL user ordering to the column users are sorted by
+func upstreamUserOrderMapper(order *model.UserOrder) pageOrder {
+	if order == nil {
+		return pageOrder{}
+	}
+
+	switch order.Field {
+	case model.UserOrderFieldEmail:
+		return newPageOrder(user.FieldEmail, order.Direction)
+	case model.UserOrderFieldName:
+		return newPageOrder(user.FieldName, order.Direction)
+	case model.UserOrderFieldCreatedAt:
+		return newTimePageOrder(user.FieldCreatedAt, order.Direction)
+	case model.UserOrderFieldUpdatedAt:
+		return newTimePageOrder(user.FieldUpdatedAt, order.Direction)
+	default:
+		return newPageOrder("", order.Direction)
+	}
+}
+
+// upstreamUserIDsMapper makes sure every user passed as userIds of a todo
+// mutation exists
+func upstreamUserIDsMapper(ctx context.Context, client *ent.Client, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	existing, err := client.User.Query().Where(user.IDIn(userIDs...)).IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	for i, id := range userIDs {
+		if !found[id] {
+			return nil, apperror.NotFound("user with id %s not found", id).
+				WithField(fmt.Sprintf("userIds[%d]", i), "user does not exist")
+		}
+	}
+
+	return userIDs, nil
+}
+
+// upstreamWatcherMapper makes sure the user passed as userId of a watch
+// mutation exists
+func upstreamWatcherMapper(ctx context.Context, client *ent.Client, userID uuid.UUID) (uuid.UUID, error) {
+	exists, err := client.User.Query().Where(user.ID(userID)).Exist(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if !exists {
+		return uuid.Nil, apperror.NotFound("user with id %s not found", userID).
+			WithField("userId", "user does not exist")
+	}
 
-	return updateQuery, nil
+	return userID, nil
 }
@@ -5,7 +5,12 @@ package graph
 // Code generated by github.com/99designs/gqlgen version v0.17.78
 
 import (
+	"backend-go/apperror"
 	"backend-go/ent"
+	"backend-go/ent/schema"
+	"backend-go/ent/user"
+	"backend-go/graph/generated"
+	"backend-go/graph/loader"
 	"backend-go/graph/model"
 	"context"
 	"fmt"
@@ -16,7 +21,7 @@ import (
 // CreateUser is the resolver for the createUser field.
 func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*model.User, error) {
 	// Use upstream mapper to prepare the creation operation
-	createQuery := upstreamCreateUserMapper(r.Client, input)
+	createQuery := upstreamCreateUserMapper(ctx, r.client(ctx), input)
 
 	entUser, err := createQuery.Save(ctx)
 	if err != nil {
@@ -30,15 +35,22 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUse
 // UpdateUser is the resolver for the updateUser field.
 func (r *mutationResolver) UpdateUser(ctx context.Context, input model.UpdateUserInput) (*model.User, error) {
 	// Use upstream mapper to prepare the update operation
-	updateQuery, err := upstreamUpdateUserMapper(r.Client, input)
-	if err != nil {
-		return nil, err
-	}
+	updateQuery := upstreamUpdateUserMapper(ctx, r.client(ctx), input)
 
 	entUser, err := updateQuery.Save(ctx)
+	if ent.IsNotFound(err) && input.ExpectedVersion != nil {
+		// The user may exist at another version than the expected one
+		current, getErr := r.client(ctx).User.Get(ctx, input.ID)
+		switch {
+		case getErr == nil:
+			return nil, downstreamUserConflictMapper(current)
+		case !ent.IsNotFound(getErr):
+			return nil, fmt.Errorf("failed to update user: %w", getErr)
+		}
+	}
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return nil, fmt.Errorf("user with id %s not found", input.ID)
+			return nil, apperror.NotFound("user with id %s not found", input.ID)
 		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
@@ -48,16 +60,20 @@ func (r *mutationResolver) UpdateUser(ctx context.Context, input model.UpdateUse
 }
 
 // DeleteUser is the resolver for the deleteUser field.
-func (r *mutationResolver) DeleteUser(ctx context.Context, id string) (bool, error) {
-	userID, err := uuid.Parse(id)
+func (r *mutationResolver) DeleteUser(ctx context.Context, id uuid.UUID) (bool, error) {
+	// Keep the last state of the user for subscribers
+	entUser, err := r.client(ctx).User.Get(ctx, id)
 	if err != nil {
-		return false, fmt.Errorf("invalid user ID: %w", err)
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("user with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	err = r.Client.User.DeleteOneID(userID).Exec(ctx)
+	err = r.client(ctx).User.DeleteOne(entUser).Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return false, nil // User didn't exist, but that's okay
+			return false, apperror.NotFound("user with id %s not found", id)
 		}
 		return false, fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -65,10 +81,40 @@ func (r *mutationResolver) DeleteUser(ctx context.Context, id string) (bool, err
 	return true, nil
 }
 
+// RestoreUser is the resolver for the restoreUser field.
+func (r *mutationResolver) RestoreUser(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	// Deleted users can only be updated with soft deletion skipped. Restoring
+	// fails with CONFLICT when another user took the email in the meantime.
+	entUser, err := r.client(ctx).User.UpdateOneID(id).
+		Where(user.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Save(schema.SkipSoftDelete(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("deleted user with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(entUser), nil
+}
+
 // Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
-	entUsers, err := r.Client.User.Query().
-		WithTodos(). // Load todos relationship
+func (r *queryResolver) Users(ctx context.Context, where *model.UserWhereInput, orderBy *model.UserOrder) ([]*model.User, error) {
+	if err := requireUserOrder(ctx, orderBy); err != nil {
+		return nil, err
+	}
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	order := upstreamUserOrderMapper(orderBy)
+
+	entUsers, err := r.client(ctx).User.Query().
+		Where(upstreamUserWhereMapper(ctx, where)...).
+		Order(user.OrderOption(order.apply(false))).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
@@ -82,3 +128,54 @@ func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
 
 	return users, nil
 }
+
+// UsersConnection is the resolver for the usersConnection field.
+func (r *queryResolver) UsersConnection(ctx context.Context, first *int, after *string, last *int, before *string, where *model.UserWhereInput, orderBy *model.UserOrder) (*model.UserConnection, error) {
+	if err := requireUserOrder(ctx, orderBy); err != nil {
+		return nil, err
+	}
+	if where != nil {
+		ctx = withDeleted(ctx, where.IncludeDeleted)
+	}
+
+	// Use upstream mappers to convert filters and ordering
+	page, err := newPageArgs(first, after, last, before, upstreamUserOrderMapper(orderBy))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := paginateUsers(ctx, r.client(ctx).User.Query().Where(upstreamUserWhereMapper(ctx, where)...), page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to a GraphQL connection
+	return downstreamUserConnectionMapper(result), nil
+}
+
+// Todos is the resolver for the todos field.
+func (r *userResolver) Todos(ctx context.Context, obj *model.User) ([]*model.Todo, error) {
+	userID, err := nodeID(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// Batched with the todos of every other user in the response
+	entTodos, err := loader.For(ctx).TodosByUserID.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	todos := make([]*model.Todo, len(entTodos))
+	for i, entTodo := range entTodos {
+		todos[i] = downstreamTodoMapper(entTodo)
+	}
+
+	return todos, nil
+}
+
+// User returns generated.UserResolver implementation.
+func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
+
+type userResolver struct{ *Resolver }
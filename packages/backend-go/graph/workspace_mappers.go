@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+
+	"backend-go/ent"
+	"backend-go/ent/invitation"
+	"backend-go/ent/membership"
+	"backend-go/graph/model"
+)
+
+// Workspace data mappers for converting between database entities and GraphQL models
+//
+// Naming Convention:
+// - downstream: Database → Frontend (Ent entities → GraphQL models)
+// - upstream: Frontend → Database (GraphQL inputs → Ent operations)
+
+// =============================================================================
+// DOWNSTREAM MAPPERS (Database → Frontend)
+// =============================================================================
+
+// downstreamWorkspaceMapper converts an Ent Workspace entity to a GraphQL model
+func downstreamWorkspaceMapper(entWorkspace *ent.Workspace) *model.Workspace {
+	// Members are resolved lazily by the Workspace.members field resolver
+	return &model.Workspace{
+		ID:        entWorkspace.ID.String(),
+		Name:      entWorkspace.Name,
+		CreatedAt: entWorkspace.CreatedAt,
+		UpdatedAt: entWorkspace.UpdatedAt,
+	}
+}
+
+// downstreamInvitationMapper converts an Ent Invitation entity, loaded with its
+// workspace, to a GraphQL model
+func downstreamInvitationMapper(entInvitation *ent.Invitation) *model.Invitation {
+	return &model.Invitation{
+		ID:            entInvitation.ID.String(),
+		WorkspaceID:   entInvitation.WorkspaceID,
+		WorkspaceName: entInvitation.Edges.Workspace.Name,
+		UserID:        entInvitation.UserID,
+		Role:          model.Role(entInvitation.Role),
+		CreatedAt:     entInvitation.CreatedAt,
+	}
+}
+
+// =============================================================================
+// UPSTREAM MAPPERS (Frontend → Database)
+// =============================================================================
+
+// upstreamCreateWorkspaceMapper prepares a workspace creation operation from GraphQL input
+func upstreamCreateWorkspaceMapper(ctx context.Context, client *ent.Client, input model.CreateWorkspaceInput) *ent.WorkspaceCreate {
+	return client.Workspace.
+		Create().
+		SetName(input.Name)
+}
+
+// upstreamRoleMapper converts a GraphQL role to its Ent value
+func upstreamRoleMapper(role model.Role) membership.Role {
+	// Both enums share their values
+	return membership.Role(role)
+}
+
+// upstreamInvitationRoleMapper converts a GraphQL role to the Ent value of
+// invitations
+func upstreamInvitationRoleMapper(role model.Role) invitation.Role {
+	// Both enums share their values
+	return invitation.Role(role)
+}
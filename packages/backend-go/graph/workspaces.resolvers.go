@@ -0,0 +1,323 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"backend-go/apperror"
+	"backend-go/auth"
+	"backend-go/ent"
+	"backend-go/ent/invitation"
+	"backend-go/ent/membership"
+	"backend-go/ent/user"
+	"backend-go/graph/generated"
+	"backend-go/graph/model"
+	"backend-go/tenant"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreateWorkspace is the resolver for the createWorkspace field.
+func (r *mutationResolver) CreateWorkspace(ctx context.Context, input model.CreateWorkspaceInput) (*model.Workspace, error) {
+	// Use upstream mapper to prepare the creation operation
+	createQuery := upstreamCreateWorkspaceMapper(ctx, r.client(ctx), input)
+
+	entWorkspace, err := createQuery.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	// Whoever creates a workspace owns it, @loggedIn made sure there is one
+	viewer, _ := auth.FromContext(ctx)
+	err = r.client(ctx).Membership.Create().
+		SetWorkspaceID(entWorkspace.ID).
+		SetUserID(viewer.ID).
+		SetRole(membership.RoleOWNER).
+		Exec(tenant.System(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add creator to workspace: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamWorkspaceMapper(entWorkspace), nil
+}
+
+// InviteWorkspaceMember is the resolver for the inviteWorkspaceMember field.
+func (r *mutationResolver) InviteWorkspaceMember(ctx context.Context, userID uuid.UUID, role model.Role) (*model.Invitation, error) {
+	if err := requireGrant(ctx, "", upstreamRoleMapper(role)); err != nil {
+		return nil, err
+	}
+
+	// Users outside the workspace are only visible to the system
+	exists, err := r.client(ctx).User.Query().Where(user.ID(userID)).Exist(tenant.System(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	if !exists {
+		return nil, apperror.NotFound("user with id %s not found", userID).
+			WithField("userId", "user does not exist")
+	}
+	member, err := r.client(ctx).Membership.Query().Where(membership.UserID(userID)).Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up workspace member: %w", err)
+	}
+	if member {
+		return nil, apperror.Conflict("user with id %s is already a member of the workspace", userID)
+	}
+
+	// The invitation is to the workspace of the request, the user only joins
+	// it by accepting
+	entInvitation, err := r.client(ctx).Invitation.Create().
+		SetUserID(userID).
+		SetRole(upstreamInvitationRoleMapper(role)).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, apperror.Conflict("user with id %s is already invited to the workspace", userID)
+		}
+		return nil, fmt.Errorf("failed to invite workspace member: %w", err)
+	}
+	entInvitation.Edges.Workspace, err = r.client(ctx).Workspace.Get(ctx, entInvitation.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamInvitationMapper(entInvitation), nil
+}
+
+// CancelInvitation is the resolver for the cancelInvitation field.
+func (r *mutationResolver) CancelInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	entInvitation, err := r.client(ctx).Invitation.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("invitation with id %s not found", id)
+		}
+		return false, fmt.Errorf("failed to look up invitation: %w", err)
+	}
+	if err := requireGrant(ctx, membership.Role(entInvitation.Role), ""); err != nil {
+		return false, err
+	}
+
+	if err := r.client(ctx).Invitation.DeleteOne(entInvitation).Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to cancel invitation: %w", err)
+	}
+
+	return true, nil
+}
+
+// AcceptInvitation is the resolver for the acceptInvitation field.
+func (r *mutationResolver) AcceptInvitation(ctx context.Context, id uuid.UUID) (*model.Workspace, error) {
+	// The invitation is to another workspace than the request acts in, if any,
+	// so only the system sees it. @loggedIn made sure there is a viewer.
+	viewer, _ := auth.FromContext(ctx)
+	system := tenant.System(ctx)
+	entInvitation, err := r.client(ctx).Invitation.Query().
+		Where(invitation.ID(id), invitation.UserID(viewer.ID)).
+		WithWorkspace().
+		Only(system)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("invitation with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to look up invitation: %w", err)
+	}
+
+	err = r.client(ctx).Membership.Create().
+		SetWorkspaceID(entInvitation.WorkspaceID).
+		SetUserID(viewer.ID).
+		SetRole(membership.Role(entInvitation.Role)).
+		Exec(system)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, apperror.Conflict("already a member of workspace %s", entInvitation.WorkspaceID)
+		}
+		return nil, fmt.Errorf("failed to join workspace: %w", err)
+	}
+	if err := r.client(ctx).Invitation.DeleteOne(entInvitation).Exec(system); err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamWorkspaceMapper(entInvitation.Edges.Workspace), nil
+}
+
+// DeclineInvitation is the resolver for the declineInvitation field.
+func (r *mutationResolver) DeclineInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	// Only the system sees invitations to other workspaces, @loggedIn made
+	// sure there is a viewer
+	viewer, _ := auth.FromContext(ctx)
+	n, err := r.client(ctx).Invitation.Delete().
+		Where(invitation.ID(id), invitation.UserID(viewer.ID)).
+		Exec(tenant.System(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to decline invitation: %w", err)
+	}
+	if n == 0 {
+		return false, apperror.NotFound("invitation with id %s not found", id)
+	}
+
+	return true, nil
+}
+
+// RemoveWorkspaceMember is the resolver for the removeWorkspaceMember field.
+func (r *mutationResolver) RemoveWorkspaceMember(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m, err := r.client(ctx).Membership.Query().Where(membership.UserID(userID)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, apperror.NotFound("user with id %s is not a member of the workspace", userID)
+		}
+		return false, fmt.Errorf("failed to look up workspace member: %w", err)
+	}
+	if m.Role == membership.RoleOWNER {
+		if err := requireGrant(ctx, m.Role, ""); err != nil {
+			return false, err
+		}
+		if err := requireOtherOwner(ctx, r.client(ctx), userID); err != nil {
+			return false, err
+		}
+	}
+
+	// Todos stay assigned to the former member
+	if err := r.client(ctx).Membership.DeleteOne(m).Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetMemberRole is the resolver for the setMemberRole field.
+func (r *mutationResolver) SetMemberRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error) {
+	m, err := r.client(ctx).Membership.Query().
+		Where(membership.UserID(userID)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("user with id %s is not a member of the workspace", userID)
+		}
+		return nil, fmt.Errorf("failed to look up workspace member: %w", err)
+	}
+
+	newRole := upstreamRoleMapper(role)
+	if err := requireGrant(ctx, m.Role, newRole); err != nil {
+		return nil, err
+	}
+	if m.Role == membership.RoleOWNER && newRole != membership.RoleOWNER {
+		if err := requireOtherOwner(ctx, r.client(ctx), userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.Update().SetRole(newRole).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to change member role: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamUserMapper(m.Edges.User), nil
+}
+
+// Workspace is the resolver for the workspace field.
+func (r *queryResolver) Workspace(ctx context.Context) (*model.Workspace, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrNoWorkspace
+	}
+
+	entWorkspace, err := r.client(ctx).Workspace.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, apperror.NotFound("workspace with id %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to fetch workspace: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL model
+	return downstreamWorkspaceMapper(entWorkspace), nil
+}
+
+// Invitations is the resolver for the invitations field.
+func (r *queryResolver) Invitations(ctx context.Context) ([]*model.Invitation, error) {
+	// Invitations are to workspaces the user isn't a member of yet, which only
+	// the system sees. @loggedIn made sure there is a viewer.
+	viewer, _ := auth.FromContext(ctx)
+	entInvitations, err := r.client(ctx).Invitation.Query().
+		Where(invitation.UserID(viewer.ID)).
+		WithWorkspace().
+		Order(ent.Desc(invitation.FieldCreatedAt)).
+		All(tenant.System(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invitations: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	invitations := make([]*model.Invitation, len(entInvitations))
+	for i, entInvitation := range entInvitations {
+		invitations[i] = downstreamInvitationMapper(entInvitation)
+	}
+	return invitations, nil
+}
+
+// Members is the resolver for the members field.
+func (r *workspaceResolver) Members(ctx context.Context, obj *model.Workspace) ([]*model.User, error) {
+	workspaceID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+
+	entUsers, err := r.client(ctx).User.Query().
+		Where(user.HasMembershipsWith(membership.WorkspaceID(workspaceID))).
+		Order(ent.Asc(user.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace members: %w", err)
+	}
+
+	// Use downstream mapper to convert to GraphQL models
+	users := make([]*model.User, len(entUsers))
+	for i, entUser := range entUsers {
+		users[i] = downstreamUserMapper(entUser)
+	}
+	return users, nil
+}
+
+// ViewerRole is the resolver for the viewerRole field.
+func (r *workspaceResolver) ViewerRole(ctx context.Context, obj *model.Workspace) (*model.Role, error) {
+	workspaceID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID: %w", err)
+	}
+
+	// Requests acting in the workspace were authenticated with their role
+	if id, ok := tenant.FromContext(ctx); ok && id == workspaceID {
+		if role, ok := auth.RoleFromContext(ctx); ok {
+			viewerRole := model.Role(role)
+			return &viewerRole, nil
+		}
+	}
+
+	viewer, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	// Other workspaces, like newly created ones, are only visible to the system
+	m, err := r.client(ctx).Membership.Query().
+		Where(membership.WorkspaceID(workspaceID), membership.UserID(viewer.ID)).
+		Only(tenant.System(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up membership: %w", err)
+	}
+	viewerRole := model.Role(m.Role)
+	return &viewerRole, nil
+}
+
+// Workspace returns generated.WorkspaceResolver implementation.
+func (r *Resolver) Workspace() generated.WorkspaceResolver { return &workspaceResolver{r} }
+
+type workspaceResolver struct{ *Resolver }
@@ -1,22 +1,28 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/rs/cors"
 
+	"backend-go/auth"
+	"backend-go/changefeed"
 	"backend-go/ent"
+	_ "backend-go/ent/runtime"
 	"backend-go/graph"
-	"backend-go/graph/generated"
+	"backend-go/purge"
+	"backend-go/remind"
+	"backend-go/tenant"
 )
 
 const defaultPort = "8080"
@@ -51,20 +57,108 @@ func main() {
 	}
 	log.Println("✅ Connected to PostgreSQL database")
 
+	// Stream changes from both backends to subscriptions. The trigger is owned
+	// by this backend; Drizzle leaves the change_events table alone.
+	ctx := context.Background()
+	if err := changefeed.Install(ctx, db); err != nil {
+		log.Fatalf("failed to set up change feed: %v", err)
+	}
+	// Each replica reads the feed under its own name, by default its host's
+	feedID := os.Getenv("CHANGE_FEED_ID")
+	if feedID == "" {
+		if feedID, err = os.Hostname(); err != nil {
+			log.Fatalf("failed to name change feed, set CHANGE_FEED_ID: %v", err)
+		}
+	}
+	feed := changefeed.New(db, databaseURL, feedID)
+	go func() {
+		if err := feed.Run(ctx); err != nil {
+			log.Fatalf("change feed stopped: %v", err)
+		}
+	}()
+
+	// Remove deleted rows for good once they can no longer be restored
+	retention := purge.DefaultRetention
+	if value := os.Getenv("SOFT_DELETE_RETENTION"); value != "" {
+		if retention, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("invalid SOFT_DELETE_RETENTION: %v", err)
+		}
+	}
+	go purge.New(client, retention).Run(ctx)
+
+	// Announce todos to todoReminder subscribers as they come due
+	lead := remind.DefaultLead
+	if value := os.Getenv("REMINDER_LEAD"); value != "" {
+		if lead, err = time.ParseDuration(value); err != nil {
+			log.Fatalf("invalid REMINDER_LEAD: %v", err)
+		}
+	}
+	go remind.New(client, lead).Run(ctx)
+
+	// Sign access tokens with keys that rotate every auth.KeyRotationInterval,
+	// stored encrypted with a secret every instance shares
+	keySecret := os.Getenv("SIGNING_KEY_SECRET")
+	if keySecret == "" {
+		log.Fatal("SIGNING_KEY_SECRET environment variable is required")
+	}
+	keys, err := auth.NewKeys(client, keySecret)
+	if err != nil {
+		log.Fatalf("failed to set up signing keys: %v", err)
+	}
+	if err := keys.Rotate(ctx); err != nil {
+		log.Fatalf("failed to set up signing keys: %v", err)
+	}
+	go keys.Run(ctx)
+
+	// Log users in with the company's identity provider instead of passwords
+	// when one is configured
+	var sso *auth.OIDC
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		config := auth.OIDCConfig{
+			IssuerURL:     issuer,
+			ClientID:      os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:   os.Getenv("OIDC_REDIRECT_URL"),
+			PostLoginURL:  os.Getenv("OIDC_POST_LOGIN_URL"),
+			PostLogoutURL: os.Getenv("OIDC_POST_LOGOUT_URL"),
+		}
+		if value := os.Getenv("OIDC_ROLE_MAPPING"); value != "" {
+			if config.Roles, err = auth.ParseRoleRules(value); err != nil {
+				log.Fatalf("invalid OIDC_ROLE_MAPPING: %v", err)
+			}
+		}
+		if sso, err = auth.NewOIDC(ctx, client, keys, config); err != nil {
+			log.Fatalf("failed to set up single sign-on: %v", err)
+		}
+	}
+
 	// Create resolver with Ent client
 	resolver := &graph.Resolver{
-		Client: client,
+		Client:           client,
+		Broker:           feed,
+		Keys:             keys,
+		DisablePasswords: sso != nil,
 	}
 
 	// Create GraphQL server
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+	srv := graph.NewServer(resolver)
 
 	// Create router
 	router := mux.NewRouter()
 
 	// GraphQL endpoints
 	router.Handle("/", playground.Handler("GraphQL playground todos", "/query")).Methods("GET")
-	router.Handle("/query", srv).Methods("POST")
+	router.Handle("/query", srv).Methods("GET", "POST")
+
+	// Public keys verifying access tokens, for other services
+	router.Handle("/.well-known/jwks.json", keys).Methods("GET")
+
+	// Single sign-on
+	if sso != nil {
+		router.HandleFunc("/auth/login", sso.Login).Methods("GET")
+		router.HandleFunc("/auth/callback", sso.Callback).Methods("GET")
+		router.HandleFunc("/auth/logout", sso.Logout).Methods("GET", "POST")
+	}
 
 	// Enable CORS
 	c := cors.New(cors.Options{
@@ -74,9 +168,12 @@ func main() {
 		AllowCredentials: true,
 	})
 
-	handler := c.Handler(router)
+	// Every request acts in the workspace it selects, see tenant, as the
+	// user its access token belongs to, see auth
+	handler := c.Handler(tenant.Middleware(auth.Middleware(client, keys)(router)))
 
 	log.Printf("🚀 GraphQL server ready at http://localhost:%s/", port)
 	log.Printf("🔍 GraphQL playground at http://localhost:%s/", port)
+	log.Printf("📡 GraphQL subscriptions at ws://localhost:%s/query", port)
 	log.Fatal(http.ListenAndServe(":"+port, handler))
 }
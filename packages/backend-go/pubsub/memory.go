@@ -0,0 +1,58 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before
+// further events are dropped for it
+const subscriberBuffer = 64
+
+// MemoryBroker is a Broker delivering events within a single process
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewMemoryBroker creates an in-process broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish delivers event to every subscriber without blocking. A subscriber
+// whose buffer is full misses the event rather than stalling the publisher.
+func (b *MemoryBroker) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done
+func (b *MemoryBroker) Subscribe(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch, nil
+}
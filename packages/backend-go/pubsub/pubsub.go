@@ -0,0 +1,44 @@
+// Package pubsub distributes todo and user change events to GraphQL
+// subscriptions.
+//
+// Events aren't published by resolvers: the database records every write, and
+// the change feed in package changefeed turns those records into an Event for
+// each subscription, which reads from its own channel obtained through
+// Subscribe. The Broker interface hides where events come from, so tests
+// swap the feed for one observing the writes of an ent client.
+package pubsub
+
+import (
+	"context"
+
+	"backend-go/ent"
+)
+
+// Op is the kind of change an event describes
+type Op string
+
+const (
+	OpCreate Op = "CREATE"
+	OpUpdate Op = "UPDATE"
+	OpDelete Op = "DELETE"
+	// OpRemind announces that a todo comes due, see package remind. It
+	// follows the update stamping the todo.
+	OpRemind Op = "REMIND"
+)
+
+// Event describes a change to a todo or a user. Exactly one of Todo and User
+// is set. For deletes, it holds the last state of the row before it was
+// removed.
+type Event struct {
+	Op   Op
+	Todo *ent.Todo
+	User *ent.User
+}
+
+// Broker fans events out to subscribers. Implementations must be safe for
+// concurrent use.
+type Broker interface {
+	// Subscribe returns a channel receiving the events published until ctx
+	// is done, after which the channel is closed
+	Subscribe(ctx context.Context) (<-chan Event, error)
+}
@@ -0,0 +1,91 @@
+// Package purge removes soft-deleted todos and users for good once they have
+// been in the trash for longer than the retention period, along with entries
+// of the session denylist that outlived the tokens they deny.
+package purge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/ent/deniedsession"
+	"backend-go/ent/schema"
+	"backend-go/ent/todo"
+	"backend-go/ent/user"
+	"backend-go/tenant"
+)
+
+const (
+	// DefaultRetention is how long deleted rows can be restored by default
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// interval is how often Run looks for rows to purge
+	interval = time.Hour
+)
+
+// Purger hard-deletes rows soft-deleted before the retention period
+type Purger struct {
+	client    *ent.Client
+	retention time.Duration
+}
+
+// New creates a purger for the rows of client deleted more than retention ago
+func New(client *ent.Client, retention time.Duration) *Purger {
+	return &Purger{client: client, retention: retention}
+}
+
+// Run purges expired rows right away and then every interval until ctx is
+// done. Failures are logged and retried on the next run.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := p.Purge(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("purge: %v", err)
+		case n > 0:
+			log.Printf("purge: removed %d deleted rows", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge hard-deletes the rows deleted before the retention period and returns
+// how many were removed, across every workspace, and drops expired entries of
+// the session denylist. Todos of a purged user are left unassigned.
+func (p *Purger) Purge(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-p.retention)
+	ctx = tenant.System(schema.SkipSoftDelete(ctx))
+
+	todos, err := p.client.Todo.Delete().
+		Where(todo.DeletedAtLT(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge todos: %w", err)
+	}
+
+	users, err := p.client.User.Delete().
+		Where(user.DeletedAtLT(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return todos, fmt.Errorf("failed to purge users: %w", err)
+	}
+
+	denied, err := p.client.DeniedSession.Delete().
+		Where(deniedsession.ExpiresAtLT(time.Now())).
+		Exec(ctx)
+	if err != nil {
+		return todos + users, fmt.Errorf("failed to purge session denylist: %w", err)
+	}
+
+	return todos + users + denied, nil
+}
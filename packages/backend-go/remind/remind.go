@@ -0,0 +1,84 @@
+// Package remind announces todos whose due date is coming up. Each open todo
+// is stamped with reminded_at once it is due within the lead time, which the
+// change feed streams to the todoReminder subscription, so every replica
+// delivers the reminder, whichever one stamped it.
+package remind
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend-go/ent"
+	"backend-go/ent/project"
+	"backend-go/ent/todo"
+	"backend-go/tenant"
+)
+
+const (
+	// DefaultLead is how long before their due date todos are announced by
+	// default
+	DefaultLead = time.Hour
+
+	// interval is how often Run looks for todos to announce
+	interval = time.Minute
+)
+
+// Reminder stamps the todos due within the lead time
+type Reminder struct {
+	client *ent.Client
+	lead   time.Duration
+}
+
+// New creates a reminder for the todos of client due within lead
+func New(client *ent.Client, lead time.Duration) *Reminder {
+	return &Reminder{client: client, lead: lead}
+}
+
+// Run announces due todos right away and then every interval until ctx is
+// done. Failures are logged and retried on the next run.
+func (r *Reminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.Remind(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("remind: %v", err)
+		case n > 0:
+			log.Printf("remind: announced %d due todos", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Remind stamps the open todos due within the lead time that haven't been
+// announced yet and returns how many were, across every workspace. Todos in
+// the trash or in archived projects are left alone. Replicas running at the
+// same time stamp each todo once, as the update skips stamped ones.
+func (r *Reminder) Remind(ctx context.Context) (int, error) {
+	now := time.Now()
+	n, err := r.client.Todo.Update().
+		Where(
+			todo.Completed(false),
+			todo.DueAtLTE(now.Add(r.lead)),
+			todo.RemindedAtIsNil(),
+			todo.Or(
+				todo.ProjectIDIsNil(),
+				todo.HasProjectWith(project.Archived(false)),
+			),
+		).
+		SetRemindedAt(now).
+		Save(tenant.System(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to announce due todos: %w", err)
+	}
+	return n, nil
+}
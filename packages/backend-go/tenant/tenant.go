@@ -0,0 +1,84 @@
+// Package tenant carries the workspace a request acts in.
+//
+// Every workspace-scoped entity is filtered to the workspace of the context
+// by the ent schema (see schema.WorkspaceMixin), and queries or writes made
+// without one fail with ErrNoWorkspace rather than seeing every tenant. Work
+// that deliberately spans workspaces, like the purge job, runs in a System
+// context instead.
+package tenant
+
+import (
+	"context"
+	"net/http"
+
+	"backend-go/apperror"
+
+	"github.com/google/uuid"
+)
+
+// Header is the request header selecting the workspace
+const Header = "X-Workspace-ID"
+
+// queryParam selects the workspace of websocket connections, as browsers
+// can't set headers on them
+const queryParam = "workspaceId"
+
+// ErrNoWorkspace is returned by workspace-scoped queries and writes made
+// without a workspace in their context
+var ErrNoWorkspace = apperror.Unauthenticated("no workspace selected, pass the %s header", Header)
+
+type workspaceKey struct{}
+
+type systemKey struct{}
+
+// NewContext returns a copy of parent acting in the workspace with id
+func NewContext(parent context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(parent, workspaceKey{}, id)
+}
+
+// FromContext returns the workspace ctx acts in
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(workspaceKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// System returns a copy of parent that isn't restricted to any workspace.
+// Only use it for work that has to span workspaces.
+func System(parent context.Context) context.Context {
+	return context.WithValue(parent, systemKey{}, true)
+}
+
+// Detach returns a System copy of parent that acts in no workspace, for work
+// on users as the identities every workspace shares, like signing up
+func Detach(parent context.Context) context.Context {
+	return System(context.WithValue(parent, workspaceKey{}, nil))
+}
+
+// IsSystem reports whether ctx was returned by System
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
+
+// Middleware selects the workspace of each request from the X-Workspace-ID
+// header, or the workspaceId query parameter for websockets. Requests without
+// one go through without a workspace; malformed IDs are rejected.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(Header)
+		if value == "" {
+			value = r.URL.Query().Get(queryParam)
+		}
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, "invalid workspace ID", http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
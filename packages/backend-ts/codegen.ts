@@ -8,6 +8,10 @@ const config: CodegenConfig = {
       config: {
         useIndexSignature: true,
         contextType: "../context#Context",
+        scalars: {
+          UUID: "string",
+          DateTime: "Date",
+        },
       },
     },
   },
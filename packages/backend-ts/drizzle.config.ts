@@ -5,6 +5,8 @@ export default defineConfig({
   out: "./drizzle",
   schema: "./src/db/schema.ts",
   dialect: "postgresql",
+  // Written by the change feed installed by the Go backend
+  tablesFilter: ["!change_events", "!change_feed_consumers"],
   dbCredentials: {
     url: process.env.DATABASE_URL!,
   },
@@ -0,0 +1,192 @@
+import { createPublicKey, verify, type KeyObject } from "crypto";
+import { and, eq, isNull } from "drizzle-orm";
+import { GraphQLError } from "graphql";
+import {
+  membershipsTable,
+  sessionDenylistTable,
+  usersTable,
+} from "./db/schema";
+import type { Context } from "./context";
+
+// Requests authenticate like they do with backend-go: by an access token it
+// signed, sent as "Authorization: Bearer <token>", acting in the workspace
+// selected by the X-Workspace-ID header or else the one of the token.
+
+// The keys backend-go signs access tokens with
+const jwksUrl =
+  process.env.JWKS_URL ?? "http://localhost:8080/.well-known/jwks.json";
+
+// The "iss" claim of the access tokens of backend-go
+const issuer = "backend-go";
+
+// Tokens of an unknown key fetch the keys again at most this often, so
+// forged key IDs can't flood backend-go
+const keysRefreshInterval = 60_000;
+
+const uuidPattern =
+  /^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i;
+
+export type Role = (typeof membershipsTable.role.enumValues)[number];
+
+// Every role may do what the roles below it may
+const roleRank: Record<Role, number> = {
+  VIEWER: 1,
+  MEMBER: 2,
+  ADMIN: 3,
+  OWNER: 4,
+};
+
+// The logged in user of a request, with their role in the workspace it acts
+// in, if any
+export interface Viewer {
+  userId: string;
+  workspaceId?: string;
+  role?: Role;
+}
+
+interface Claims {
+  sub: string;
+  sid: string;
+  wid?: string;
+  iss: string;
+  exp: number;
+  nbf?: number;
+}
+
+let keys = new Map<string, KeyObject>();
+let keysFetchedAt = 0;
+
+function fail(code: string, message: string): never {
+  throw new GraphQLError(message, { extensions: { code } });
+}
+
+function invalidToken(): never {
+  fail("UNAUTHENTICATED", "invalid or expired token");
+}
+
+// findKey returns the public key with kid, fetching the keys again when it's
+// unknown, as backend-go may have rotated them since
+async function findKey(kid: string): Promise<KeyObject | undefined> {
+  if (!keys.has(kid) && Date.now() - keysFetchedAt >= keysRefreshInterval) {
+    keysFetchedAt = Date.now();
+    const response = await fetch(jwksUrl);
+    if (!response.ok) {
+      throw new Error(`failed to fetch signing keys: ${response.status}`);
+    }
+    const set = (await response.json()) as {
+      keys: (JsonWebKey & { kid: string })[];
+    };
+    keys = new Map(
+      set.keys.map((jwk) => [jwk.kid, createPublicKey({ key: jwk, format: "jwk" })]),
+    );
+  }
+  return keys.get(kid);
+}
+
+// verifyToken returns the claims of an access token signed by backend-go
+// that hasn't expired
+async function verifyToken(token: string): Promise<Claims> {
+  const [header, payload, signature] = token.split(".");
+  if (!header || !payload || !signature) invalidToken();
+
+  let claims: Claims;
+  let key: KeyObject | undefined;
+  try {
+    const { alg, kid } = JSON.parse(Buffer.from(header, "base64url").toString());
+    if (alg !== "EdDSA" || typeof kid !== "string") invalidToken();
+    key = await findKey(kid);
+    claims = JSON.parse(Buffer.from(payload, "base64url").toString());
+  } catch (err) {
+    if (err instanceof GraphQLError || !(err instanceof SyntaxError)) throw err;
+    invalidToken();
+  }
+  if (!key) invalidToken();
+
+  const signed = Buffer.from(`${header}.${payload}`);
+  if (!verify(null, signed, key, Buffer.from(signature, "base64url"))) {
+    invalidToken();
+  }
+
+  const now = Date.now() / 1000;
+  if (claims.iss !== issuer || claims.exp <= now || (claims.nbf ?? 0) > now) {
+    invalidToken();
+  }
+  return claims;
+}
+
+// authenticate returns the user the access token of request was issued to,
+// with their role in the workspace it acts in. Requests without a token go
+// through anonymously. Membership is checked on every request, so removed
+// members and changed roles take effect right away.
+export async function authenticate(
+  db: Context["db"],
+  request: Request,
+): Promise<Viewer | null> {
+  const header = request.headers.get("Authorization");
+  if (!header) return null;
+  if (!header.startsWith("Bearer ")) {
+    fail("UNAUTHENTICATED", "unsupported authorization scheme");
+  }
+
+  const claims = await verifyToken(header.slice("Bearer ".length));
+  if (!uuidPattern.test(claims.sub) || !uuidPattern.test(claims.sid)) {
+    invalidToken();
+  }
+
+  // Tokens of logged out sessions are denied until they expire
+  const [denied] = await db
+    .select({ id: sessionDenylistTable.id })
+    .from(sessionDenylistTable)
+    .where(eq(sessionDenylistTable.id, claims.sid));
+  if (denied) invalidToken();
+
+  // Deleted users are logged out
+  const [user] = await db
+    .select({ id: usersTable.id })
+    .from(usersTable)
+    .where(and(eq(usersTable.id, claims.sub), isNull(usersTable.deletedAt)));
+  if (!user) invalidToken();
+
+  const workspaceId = request.headers.get("X-Workspace-ID") || claims.wid;
+  if (!workspaceId) return { userId: user.id };
+  if (!uuidPattern.test(workspaceId)) {
+    fail("INVALID_ARGUMENT", "invalid X-Workspace-ID header");
+  }
+
+  const [membership] = await db
+    .select({ role: membershipsTable.role })
+    .from(membershipsTable)
+    .where(
+      and(
+        eq(membershipsTable.workspaceId, workspaceId),
+        eq(membershipsTable.userId, user.id),
+      ),
+    );
+  if (!membership) fail("FORBIDDEN", "not a member of the workspace");
+
+  return { userId: user.id, workspaceId, role: membership.role };
+}
+
+// requireRole returns the viewer of a request if they have at least role in
+// the workspace it acts in, and fails otherwise
+export function requireRole(
+  viewer: Viewer | null,
+  role: Role,
+): Required<Viewer> {
+  if (!viewer) fail("UNAUTHENTICATED", "not logged in");
+  if (!viewer.workspaceId || !viewer.role) {
+    fail(
+      "UNAUTHENTICATED",
+      "no workspace selected, pass the X-Workspace-ID header",
+    );
+  }
+  if (roleRank[viewer.role] < roleRank[role]) {
+    fail("FORBIDDEN", `needs the ${role} role`);
+  }
+  return viewer as Required<Viewer>;
+}
+
+// hasRole reports whether viewer has at least role
+export function hasRole(viewer: Viewer | null, role: Role): boolean {
+  return !!viewer?.role && roleRank[viewer.role] >= roleRank[role];
+}
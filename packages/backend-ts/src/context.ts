@@ -1,4 +1,5 @@
 import { drizzle } from "drizzle-orm/node-postgres";
+import { authenticate, type Viewer } from "./auth";
 
 // Create database instance
 export const db = drizzle(process.env.DATABASE_URL!);
@@ -6,11 +7,14 @@ export const db = drizzle(process.env.DATABASE_URL!);
 // Define the GraphQL context type
 export interface Context {
   db: typeof db;
+  // The logged in user and their role in the workspace the request acts in
+  viewer: Viewer | null;
 }
 
 // Create context function
-export function createContext(): Context {
+export async function createContext(request: Request): Promise<Context> {
   return {
     db,
+    viewer: await authenticate(db, request),
   };
 }
@@ -0,0 +1,90 @@
+import "dotenv/config";
+import { sql } from "drizzle-orm";
+import { drizzle } from "drizzle-orm/node-postgres";
+
+// Moves the rows of a database from before workspaces into a "Default"
+// workspace, which every existing user joins, the oldest one as its owner.
+// Run it before `drizzle-kit push` adds the NOT NULL workspace_id columns,
+// which would fail on tables with rows. Databases without such rows are left
+// alone, so running it again does nothing.
+
+// The tables scoped to a workspace, see workspaceId in schema.ts
+const scopedTables = [
+  "projects",
+  "todos",
+  "tags",
+  "comments",
+  "comment_revisions",
+];
+
+const db = drizzle(process.env.DATABASE_URL!);
+
+await db.transaction(async (tx) => {
+  // Tables as drizzle-kit push creates them
+  await tx.execute(sql`
+    CREATE TABLE IF NOT EXISTS "workspaces" (
+      "id" uuid PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+      "name" varchar(255) NOT NULL,
+      "created_at" timestamp with time zone DEFAULT now() NOT NULL,
+      "updated_at" timestamp with time zone DEFAULT now() NOT NULL
+    )
+  `);
+  await tx.execute(sql`
+    CREATE TABLE IF NOT EXISTS "memberships" (
+      "id" uuid PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+      "workspace_id" uuid NOT NULL
+        CONSTRAINT "memberships_workspace_id_workspaces_id_fk" REFERENCES "workspaces"("id"),
+      "user_id" uuid NOT NULL
+        CONSTRAINT "memberships_user_id_users_id_fk" REFERENCES "users"("id") ON DELETE cascade,
+      "role" varchar(255) DEFAULT 'MEMBER' NOT NULL,
+      "created_at" timestamp with time zone DEFAULT now() NOT NULL,
+      "updated_at" timestamp with time zone DEFAULT now() NOT NULL
+    )
+  `);
+  await tx.execute(sql`
+    CREATE UNIQUE INDEX IF NOT EXISTS "membership_workspace_id_user_id"
+      ON "memberships" ("workspace_id", "user_id")
+  `);
+
+  // Rows of tables that predate workspaces have nowhere to go yet
+  const unscoped: string[] = [];
+  for (const table of scopedTables) {
+    const { rows } = await tx.execute<{ unscoped: boolean }>(sql`
+      SELECT to_regclass(${table}) IS NOT NULL AND NOT EXISTS (
+        SELECT FROM information_schema.columns
+        WHERE table_name = ${table} AND column_name = 'workspace_id'
+      ) AS unscoped
+    `);
+    if (rows[0].unscoped) unscoped.push(table);
+  }
+  if (unscoped.length === 0) {
+    console.log("Nothing to backfill");
+    return;
+  }
+
+  const { rows: workspaces } = await tx.execute<{ id: string }>(sql`
+    INSERT INTO "workspaces" ("name") VALUES ('Default') RETURNING "id"
+  `);
+  const workspaceId = workspaces[0].id;
+
+  for (const table of unscoped) {
+    await tx.execute(sql`
+      ALTER TABLE ${sql.identifier(table)} ADD COLUMN "workspace_id" uuid
+    `);
+    await tx.execute(sql`
+      UPDATE ${sql.identifier(table)} SET "workspace_id" = ${workspaceId}
+    `);
+  }
+
+  await tx.execute(sql`
+    INSERT INTO "memberships" ("workspace_id", "user_id", "role")
+    SELECT ${workspaceId}, "id",
+      CASE WHEN row_number() OVER (ORDER BY "created_at", "id") = 1
+        THEN 'OWNER' ELSE 'MEMBER' END
+    FROM "users"
+    WHERE "deleted_at" IS NULL
+  `);
+  console.log(`Moved ${unscoped.join(", ")} into workspace ${workspaceId}`);
+});
+
+process.exit(0);
@@ -1,29 +1,380 @@
-import { boolean, pgTable, uuid, varchar } from "drizzle-orm/pg-core";
-import { relations } from "drizzle-orm";
+import {
+  type AnyPgColumn,
+  boolean,
+  customType,
+  integer,
+  pgTable,
+  primaryKey,
+  text,
+  timestamp,
+  uniqueIndex,
+  uuid,
+  varchar,
+} from "drizzle-orm/pg-core";
+import { relations, sql } from "drizzle-orm";
+
+// Raw bytes, which pg-core has no column builder for
+const bytea = customType<{ data: Buffer }>({
+  dataType: () => "bytea",
+});
+
+// Columns shared by every table, mirrored by the ent mixins of backend-go
+const sharedColumns = {
+  createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+  updatedAt: timestamp("updated_at", { withTimezone: true })
+    .notNull()
+    .defaultNow()
+    .$onUpdate(() => new Date()),
+  // Set while the row is in the trash
+  deletedAt: timestamp("deleted_at", { withTimezone: true }),
+  // Bumped on every update for optimistic concurrency control
+  version: integer()
+    .notNull()
+    .default(1)
+    .$onUpdate(() => sql`version + 1`),
+};
+
+// Workspaces table, the tenants every other table but users is scoped to
+export const workspacesTable = pgTable("workspaces", {
+  id: uuid().primaryKey().defaultRandom(),
+  name: varchar({ length: 255 }).notNull(),
+  createdAt: sharedColumns.createdAt,
+  updatedAt: sharedColumns.updatedAt,
+});
+
+// Set on every workspace-scoped row, see WorkspaceMixin in backend-go. Rows
+// from before workspaces get theirs from src/db/backfill-workspaces.ts.
+const workspaceId = () =>
+  uuid("workspace_id")
+    .notNull()
+    .references(() => workspacesTable.id);
 
 // Users table
-export const usersTable = pgTable("users", {
+export const usersTable = pgTable(
+  "users",
+  {
+    id: uuid().primaryKey().defaultRandom(),
+    email: varchar({ length: 255 }).notNull(),
+    name: varchar({ length: 255 }).notNull(),
+    // argon2id hash in PHC string format, never sent to clients
+    passwordHash: varchar("password_hash", { length: 255 }),
+    // Hash of the one-time token users without a password set one up with
+    passwordSetupHash: varchar("password_setup_hash", { length: 255 }),
+    passwordSetupExpiresAt: timestamp("password_setup_expires_at", { withTimezone: true }),
+    ...sharedColumns,
+  },
+  // Deleted users give up their email
+  (table) => [
+    uniqueIndex("user_email")
+      .on(table.email)
+      .where(sql`${table.deletedAt} IS NULL`),
+  ],
+);
+
+// Memberships table, the workspaces a user belongs to and their role in each
+export const membershipsTable = pgTable(
+  "memberships",
+  {
+    id: uuid().primaryKey().defaultRandom(),
+    workspaceId: workspaceId(),
+    userId: uuid("user_id")
+      .notNull()
+      .references(() => usersTable.id, { onDelete: "cascade" }),
+    role: varchar({ length: 255, enum: ["OWNER", "ADMIN", "MEMBER", "VIEWER"] })
+      .notNull()
+      .default("MEMBER"),
+    createdAt: sharedColumns.createdAt,
+    updatedAt: sharedColumns.updatedAt,
+  },
+  (table) => [
+    uniqueIndex("membership_workspace_id_user_id").on(
+      table.workspaceId,
+      table.userId,
+    ),
+  ],
+);
+
+// Sessions table, the logins of a user. Only a hash of the refresh token is
+// stored, access tokens are signed JWTs.
+export const sessionsTable = pgTable("sessions", {
   id: uuid().primaryKey().defaultRandom(),
-  email: varchar({ length: 255 }).notNull().unique(),
+  userId: uuid("user_id")
+    .notNull()
+    .references(() => usersTable.id, { onDelete: "cascade" }),
+  refreshTokenHash: varchar("refresh_token_hash", { length: 255 })
+    .notNull()
+    .unique(),
+  refreshExpiresAt: timestamp("refresh_expires_at", {
+    withTimezone: true,
+  }).notNull(),
+  revokedAt: timestamp("revoked_at", { withTimezone: true }),
+  createdAt: sharedColumns.createdAt,
+  updatedAt: sharedColumns.updatedAt,
+});
+
+// Session denylist table, logged out sessions whose access tokens are
+// rejected until they expire
+export const sessionDenylistTable = pgTable("session_denylist", {
+  id: uuid().primaryKey(),
+  expiresAt: timestamp("expires_at", { withTimezone: true }).notNull(),
+});
+
+// Signing keys table, the Ed25519 keys signing access tokens, newest first in
+// use. Managed by backend-go, which rotates them.
+export const signingKeysTable = pgTable("signing_keys", {
+  id: uuid().primaryKey().defaultRandom(),
+  privateKey: bytea("private_key").notNull(),
+  createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+});
+
+// Projects table, lists grouping todos
+export const projectsTable = pgTable("projects", {
+  id: uuid().primaryKey().defaultRandom(),
+  workspaceId: workspaceId(),
   name: varchar({ length: 255 }).notNull(),
+  description: text(),
+  // Archived projects keep their todos out of the default listings
+  archived: boolean().notNull().default(false),
+  ownerId: uuid("owner_id").references(() => usersTable.id, {
+    onDelete: "set null",
+  }),
+  createdAt: sharedColumns.createdAt,
+  updatedAt: sharedColumns.updatedAt,
 });
 
 // Todos table with user relationship
 export const todosTable = pgTable("todos", {
   id: uuid().primaryKey().defaultRandom(),
+  workspaceId: workspaceId(),
   title: varchar({ length: 255 }).notNull(),
   completed: boolean().notNull().default(false),
+  // The first assignee, see syncFirstAssignee in backend-go
   userId: uuid("user_id").references(() => usersTable.id),
+  dueAt: timestamp("due_at", { withTimezone: true }),
+  priority: varchar({ length: 255, enum: ["LOW", "MEDIUM", "HIGH", "URGENT"] })
+    .notNull()
+    .default("MEDIUM"),
+  // Stamped when the todo is completed, see trackCompletion in backend-go
+  completedAt: timestamp("completed_at", { withTimezone: true }),
+  // Stamped when the todo was announced as due, see package remind in backend-go
+  remindedAt: timestamp("reminded_at", { withTimezone: true }),
+  // Set on subtasks, see upstreamParentMapper in backend-go for cycle checks
+  parentId: uuid("parent_id").references((): AnyPgColumn => todosTable.id, {
+    onDelete: "set null",
+  }),
+  projectId: uuid("project_id").references(() => projectsTable.id, {
+    onDelete: "set null",
+  }),
+  // Who created the todo, see stampCreator in backend-go
+  creatorId: uuid("creator_id").references(() => usersTable.id, {
+    onDelete: "set null",
+  }),
+  ...sharedColumns,
+});
+
+// Assignees of todos, in the order they were assigned
+export const todoAssigneesTable = pgTable(
+  "todo_assignees",
+  {
+    todoId: uuid("todo_id")
+      .notNull()
+      .references(() => todosTable.id, { onDelete: "cascade" }),
+    userId: uuid("user_id")
+      .notNull()
+      .references(() => usersTable.id, { onDelete: "cascade" }),
+    createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
+  },
+  (table) => [primaryKey({ columns: [table.todoId, table.userId] })],
+);
+
+// Watchers of todos
+export const todoWatchersTable = pgTable(
+  "todo_watchers",
+  {
+    todoId: uuid("todo_id")
+      .notNull()
+      .references(() => todosTable.id, { onDelete: "cascade" }),
+    userId: uuid("user_id")
+      .notNull()
+      .references(() => usersTable.id, { onDelete: "cascade" }),
+  },
+  (table) => [primaryKey({ columns: [table.todoId, table.userId] })],
+);
+
+// Tags table, labels shared by any number of todos
+export const tagsTable = pgTable(
+  "tags",
+  {
+    id: uuid().primaryKey().defaultRandom(),
+    workspaceId: workspaceId(),
+    name: varchar({ length: 50 }).notNull(),
+    color: varchar({ length: 255 }).notNull().default("#9E9E9E"),
+    createdAt: sharedColumns.createdAt,
+    updatedAt: sharedColumns.updatedAt,
+  },
+  // Tag names are unique within a workspace
+  (table) => [
+    uniqueIndex("tag_workspace_id_name").on(table.workspaceId, table.name),
+  ],
+);
+
+// Join table between tags and todos
+export const todoTagsTable = pgTable(
+  "todo_tags",
+  {
+    tagId: uuid("tag_id")
+      .notNull()
+      .references(() => tagsTable.id, { onDelete: "cascade" }),
+    todoId: uuid("todo_id")
+      .notNull()
+      .references(() => todosTable.id, { onDelete: "cascade" }),
+  },
+  (table) => [primaryKey({ columns: [table.tagId, table.todoId] })],
+);
+
+// Comments table, the discussion of a todo
+export const commentsTable = pgTable("comments", {
+  id: uuid().primaryKey().defaultRandom(),
+  workspaceId: workspaceId(),
+  body: text().notNull(),
+  // Set once the body has been changed
+  edited: boolean().notNull().default(false),
+  todoId: uuid("todo_id")
+    .notNull()
+    .references(() => todosTable.id, { onDelete: "cascade" }),
+  authorId: uuid("author_id").references(() => usersTable.id, {
+    onDelete: "set null",
+  }),
+  createdAt: sharedColumns.createdAt,
+  updatedAt: sharedColumns.updatedAt,
+});
+
+// Comment revisions table, the body a comment had before each edit
+export const commentRevisionsTable = pgTable("comment_revisions", {
+  id: uuid().primaryKey().defaultRandom(),
+  workspaceId: workspaceId(),
+  body: text().notNull(),
+  commentId: uuid("comment_id")
+    .notNull()
+    .references(() => commentsTable.id, { onDelete: "cascade" }),
+  createdAt: timestamp("created_at", { withTimezone: true }).notNull().defaultNow(),
 });
 
 // Define relationships
+export const workspacesRelations = relations(workspacesTable, ({ many }) => ({
+  memberships: many(membershipsTable),
+  sessions: many(sessionsTable),
+  todos: many(todosTable),
+}));
+
+export const membershipsRelations = relations(membershipsTable, ({ one }) => ({
+  workspace: one(workspacesTable, {
+    fields: [membershipsTable.workspaceId],
+    references: [workspacesTable.id],
+  }),
+  user: one(usersTable, {
+    fields: [membershipsTable.userId],
+    references: [usersTable.id],
+  }),
+}));
+
 export const usersRelations = relations(usersTable, ({ many }) => ({
+  memberships: many(membershipsTable),
   todos: many(todosTable),
+  assignments: many(todoAssigneesTable),
+  watching: many(todoWatchersTable),
+  comments: many(commentsTable),
+  projects: many(projectsTable),
+}));
+
+export const sessionsRelations = relations(sessionsTable, ({ one }) => ({
+  user: one(usersTable, {
+    fields: [sessionsTable.userId],
+    references: [usersTable.id],
+  }),
 }));
 
-export const todosRelations = relations(todosTable, ({ one }) => ({
+export const todosRelations = relations(todosTable, ({ one, many }) => ({
   user: one(usersTable, {
     fields: [todosTable.userId],
     references: [usersTable.id],
   }),
+  project: one(projectsTable, {
+    fields: [todosTable.projectId],
+    references: [projectsTable.id],
+  }),
+  assignments: many(todoAssigneesTable),
+  watchers: many(todoWatchersTable),
+  todoTags: many(todoTagsTable),
+  comments: many(commentsTable),
+}));
+
+export const todoAssigneesRelations = relations(
+  todoAssigneesTable,
+  ({ one }) => ({
+    todo: one(todosTable, {
+      fields: [todoAssigneesTable.todoId],
+      references: [todosTable.id],
+    }),
+    user: one(usersTable, {
+      fields: [todoAssigneesTable.userId],
+      references: [usersTable.id],
+    }),
+  }),
+);
+
+export const todoWatchersRelations = relations(todoWatchersTable, ({ one }) => ({
+  todo: one(todosTable, {
+    fields: [todoWatchersTable.todoId],
+    references: [todosTable.id],
+  }),
+  user: one(usersTable, {
+    fields: [todoWatchersTable.userId],
+    references: [usersTable.id],
+  }),
 }));
+
+export const projectsRelations = relations(projectsTable, ({ one, many }) => ({
+  owner: one(usersTable, {
+    fields: [projectsTable.ownerId],
+    references: [usersTable.id],
+  }),
+  todos: many(todosTable),
+}));
+
+export const tagsRelations = relations(tagsTable, ({ many }) => ({
+  todoTags: many(todoTagsTable),
+}));
+
+export const todoTagsRelations = relations(todoTagsTable, ({ one }) => ({
+  tag: one(tagsTable, {
+    fields: [todoTagsTable.tagId],
+    references: [tagsTable.id],
+  }),
+  todo: one(todosTable, {
+    fields: [todoTagsTable.todoId],
+    references: [todosTable.id],
+  }),
+}));
+
+export const commentsRelations = relations(commentsTable, ({ one, many }) => ({
+  todo: one(todosTable, {
+    fields: [commentsTable.todoId],
+    references: [todosTable.id],
+  }),
+  author: one(usersTable, {
+    fields: [commentsTable.authorId],
+    references: [usersTable.id],
+  }),
+  revisions: many(commentRevisionsTable),
+}));
+
+export const commentRevisionsRelations = relations(
+  commentRevisionsTable,
+  ({ one }) => ({
+    comment: one(commentsTable, {
+      fields: [commentRevisionsTable.commentId],
+      references: [commentsTable.id],
+    }),
+  }),
+);
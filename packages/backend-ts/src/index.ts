@@ -1,12 +1,15 @@
 import { Hono } from "hono";
 import { cors } from "hono/cors";
 import "dotenv/config";
+import { and, eq, isNull } from "drizzle-orm";
+import { GraphQLError } from "graphql";
 import { todosTable } from "./db/schema";
+import { requireRole } from "./auth";
 import { createYoga } from "graphql-yoga";
 import { createSchema } from "graphql-yoga";
 import { resolvers } from "./resolvers";
 import { createContext, Context } from "./context";
-import { readFileSync } from "fs";
+import { readdirSync, readFileSync } from "fs";
 import { join } from "path";
 
 const app = new Hono();
@@ -23,15 +26,10 @@ app.use(
 const rootPath = join(__dirname, "../../..");
 
 // Read the schema files directly from the API directory
-const todosSchema = readFileSync(
-  join(rootPath, "api/schema/todos.graphqls"),
-  "utf8"
-);
-const usersSchema = readFileSync(
-  join(rootPath, "api/schema/users.graphqls"),
-  "utf8"
-);
-const typeDefs = [todosSchema, usersSchema];
+const schemaDir = join(rootPath, "api/schema");
+const typeDefs = readdirSync(schemaDir)
+  .filter((file) => file.endsWith(".graphqls"))
+  .map((file) => readFileSync(join(schemaDir, file), "utf8"));
 
 // Create GraphQL schema
 const schema = createSchema<Context>({
@@ -44,7 +42,7 @@ const yoga = createYoga<Context>({
   schema,
   graphqlEndpoint: "/graphql",
   landingPage: false,
-  context: () => createContext(),
+  context: ({ request }) => createContext(request),
 });
 
 // Add GraphQL endpoint
@@ -57,23 +55,43 @@ app.get("/", (c) => {
   return c.text("TypeScript GraphQL Backend with Shared Schema");
 });
 
-// REST endpoints (keep for comparison)
+// REST endpoints (keep for comparison), authenticated and scoped to the
+// workspace of the request like the GraphQL ones
 app.get("/api/todos", async (c) => {
-  const { db } = createContext();
-  const todos = await db.select().from(todosTable);
+  const { db, viewer } = await createContext(c.req.raw);
+  const { workspaceId } = requireRole(viewer, "VIEWER");
+  const todos = await db
+    .select()
+    .from(todosTable)
+    .where(
+      and(eq(todosTable.workspaceId, workspaceId), isNull(todosTable.deletedAt)),
+    );
   return c.json(todos);
 });
 
 app.post("/api/todos", async (c) => {
-  const { db } = createContext();
+  const { db, viewer } = await createContext(c.req.raw);
+  const { userId, workspaceId } = requireRole(viewer, "MEMBER");
   const { title } = await c.req.json();
   const [todo] = await db
     .insert(todosTable)
-    .values({ title, completed: false })
+    .values({ workspaceId, title, creatorId: userId, completed: false })
     .returning();
   return c.json(todo);
 });
 
+// Authentication failures of REST requests, see requireRole
+app.onError((err, c) => {
+  if (err instanceof GraphQLError) {
+    const code = err.extensions.code;
+    const status =
+      code === "UNAUTHENTICATED" ? 401 : code === "FORBIDDEN" ? 403 : 400;
+    return c.json({ error: err.message, code }, status);
+  }
+  console.error(err);
+  return c.json({ error: "internal server error" }, 500);
+});
+
 export default {
   port: process.env.PORT || 3000,
   fetch: app.fetch,
@@ -1,112 +1,297 @@
-import { eq } from "drizzle-orm";
-import { todosTable, usersTable } from "../db/schema";
+import { and, eq, inArray, isNotNull, isNull, sql } from "drizzle-orm";
+import { GraphQLError } from "graphql";
+import { DateTimeResolver, UUIDResolver } from "graphql-scalars";
+import { hasRole, requireRole } from "../auth";
+import type { Context } from "../context";
+import { membershipsTable, todosTable, usersTable } from "../db/schema";
 import type { Resolvers, User, Todo } from "../generated/types";
 
+// Every resolver acts in the workspace of the request, like the ent
+// interceptors of backend-go. Users are shared by all workspaces and belong
+// to those they are a member of.
+
+// membersOf selects the IDs of the members of a workspace
+const membersOf = (db: Context["db"], workspaceId: string) =>
+  db
+    .select({ id: membershipsTable.userId })
+    .from(membershipsTable)
+    .where(eq(membershipsTable.workspaceId, workspaceId));
+
+// requireMember fails with NOT_FOUND unless the user with userId, passed as
+// field, is a member of the workspace
+async function requireMember(
+  db: Context["db"],
+  workspaceId: string,
+  userId: string,
+  field: string,
+) {
+  const [member] = await db
+    .select({ id: membershipsTable.userId })
+    .from(membershipsTable)
+    .where(
+      and(
+        eq(membershipsTable.workspaceId, workspaceId),
+        eq(membershipsTable.userId, userId),
+      ),
+    );
+  if (!member) {
+    throw new GraphQLError(`user with id ${userId} not found`, {
+      extensions: {
+        code: "NOT_FOUND",
+        fields: [{ field, message: "not found" }],
+      },
+    });
+  }
+}
+
+// requireSelf returns the viewer of a request if they are the user with
+// userId, and fails otherwise
+function requireSelf(viewer: Context["viewer"], userId: string) {
+  const self = requireRole(viewer, "VIEWER");
+  if (self.userId !== userId) {
+    throw new GraphQLError("users can only change themselves", {
+      extensions: { code: "FORBIDDEN" },
+    });
+  }
+  return self;
+}
+
 export const resolvers: Resolvers = {
+  UUID: UUIDResolver,
+  DateTime: DateTimeResolver,
+
   Query: {
-    todos: async (_, __, { db }) => {
-      const todos = await db.select().from(todosTable);
+    todos: async (_, __, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "VIEWER");
+      const todos = await db
+        .select()
+        .from(todosTable)
+        .where(
+          and(
+            eq(todosTable.workspaceId, workspaceId),
+            isNull(todosTable.deletedAt),
+          ),
+        );
       return todos as Todo[];
     },
-    users: async (_, __, { db }) => {
-      const users = await db.select().from(usersTable);
+    users: async (_, __, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "VIEWER");
+      const users = await db
+        .select()
+        .from(usersTable)
+        .where(
+          and(
+            inArray(usersTable.id, membersOf(db, workspaceId)),
+            isNull(usersTable.deletedAt),
+          ),
+        );
       return users as User[];
     },
   },
 
   Mutation: {
-    createTodo: async (_, { input }, { db }) => {
+    createTodo: async (_, { input }, { db, viewer }) => {
+      const { userId, workspaceId } = requireRole(viewer, "MEMBER");
+      if (input.userId) {
+        await requireMember(db, workspaceId, input.userId, "input.userId");
+      }
+
       const [todo] = await db
         .insert(todosTable)
         .values({
+          workspaceId,
           title: input.title,
           userId: input.userId || null,
+          creatorId: userId,
           completed: false,
         })
         .returning();
       return todo as Todo;
     },
 
-    updateTodo: async (_, { input }, { db }) => {
+    updateTodo: async (_, { input }, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "MEMBER");
       const updateData: Partial<typeof todosTable.$inferInsert> = {};
 
       if (input.title !== undefined && input.title !== null)
         updateData.title = input.title;
-      if (input.done !== undefined && input.done !== null)
+      if (input.done !== undefined && input.done !== null) {
         updateData.completed = input.done;
-      if (input.userId !== undefined && input.userId !== null)
+        // Completing an already completed todo keeps its first stamp
+        updateData.completedAt = input.done
+          ? sql`COALESCE(${todosTable.completedAt}, now())`
+          : null;
+      }
+      if (input.userId !== undefined && input.userId !== null) {
+        await requireMember(db, workspaceId, input.userId, "input.userId");
         updateData.userId = input.userId;
+      }
 
       const [todo] = await db
         .update(todosTable)
         .set(updateData)
-        .where(eq(todosTable.id, input.id))
+        .where(
+          and(
+            eq(todosTable.id, input.id),
+            eq(todosTable.workspaceId, workspaceId),
+            isNull(todosTable.deletedAt),
+          ),
+        )
         .returning();
       return todo as Todo;
     },
 
-    deleteTodo: async (_, { id }, { db }) => {
+    // Deleted rows stay in the trash until backend-go purges them
+    deleteTodo: async (_, { id }, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "MEMBER");
       const result = await db
-        .delete(todosTable)
-        .where(eq(todosTable.id, id))
+        .update(todosTable)
+        .set({ deletedAt: new Date() })
+        .where(
+          and(
+            eq(todosTable.id, id),
+            eq(todosTable.workspaceId, workspaceId),
+            isNull(todosTable.deletedAt),
+          ),
+        )
         .returning();
       return result.length > 0;
     },
 
-    createUser: async (_, { input }, { db }) => {
-      const [user] = await db
-        .insert(usersTable)
-        .values({
-          email: input.email,
-          name: input.name,
-        })
+    restoreTodo: async (_, { id }, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "MEMBER");
+      const [todo] = await db
+        .update(todosTable)
+        .set({ deletedAt: null })
+        .where(
+          and(
+            eq(todosTable.id, id),
+            eq(todosTable.workspaceId, workspaceId),
+            isNotNull(todosTable.deletedAt),
+          ),
+        )
         .returning();
+      return todo as Todo;
+    },
+
+    // Users join the workspace they are created in
+    createUser: async (_, { input }, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "ADMIN");
+      const user = await db.transaction(async (tx) => {
+        const [user] = await tx
+          .insert(usersTable)
+          .values({
+            // Emails are saved in lower case like backend-go does, so
+            // logins match one user however the address is typed
+            email: input.email.toLowerCase(),
+            name: input.name,
+          })
+          .returning();
+        await tx
+          .insert(membershipsTable)
+          .values({ workspaceId, userId: user.id });
+        return user;
+      });
       return user as User;
     },
 
-    updateUser: async (_, { input }, { db }) => {
+    // Users are shared by every workspace they belong to, so only they
+    // change themselves, like the User policy of backend-go
+    updateUser: async (_, { input }, { db, viewer }) => {
+      const { workspaceId } = requireSelf(viewer, input.id);
       const updateData: Partial<typeof usersTable.$inferInsert> = {};
 
       if (input.email !== undefined && input.email !== null)
-        updateData.email = input.email;
+        updateData.email = input.email.toLowerCase();
       if (input.name !== undefined && input.name !== null)
         updateData.name = input.name;
 
       const [user] = await db
         .update(usersTable)
         .set(updateData)
-        .where(eq(usersTable.id, input.id))
+        .where(
+          and(
+            eq(usersTable.id, input.id),
+            inArray(usersTable.id, membersOf(db, workspaceId)),
+            isNull(usersTable.deletedAt),
+          ),
+        )
         .returning();
       return user as User;
     },
 
-    deleteUser: async (_, { id }, { db }) => {
+    // Deleted rows stay in the trash until backend-go purges them
+    deleteUser: async (_, { id }, { db, viewer }) => {
+      const { workspaceId } = requireSelf(viewer, id);
       const result = await db
-        .delete(usersTable)
-        .where(eq(usersTable.id, id))
+        .update(usersTable)
+        .set({ deletedAt: new Date() })
+        .where(
+          and(
+            eq(usersTable.id, id),
+            inArray(usersTable.id, membersOf(db, workspaceId)),
+            isNull(usersTable.deletedAt),
+          ),
+        )
         .returning();
       return result.length > 0;
     },
+
+    restoreUser: async (_, { id }, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "ADMIN");
+      const [user] = await db
+        .update(usersTable)
+        .set({ deletedAt: null })
+        .where(
+          and(
+            eq(usersTable.id, id),
+            inArray(usersTable.id, membersOf(db, workspaceId)),
+            isNotNull(usersTable.deletedAt),
+          ),
+        )
+        .returning();
+      return user as User;
+    },
   },
 
   Todo: {
-    user: async (parent, _, { db }) => {
+    user: async (parent, _, { db, viewer }) => {
       if (!parent.userId) return null;
 
+      const { workspaceId } = requireRole(viewer, "VIEWER");
       const [user] = await db
         .select()
         .from(usersTable)
-        .where(eq(usersTable.id, parent.userId));
+        .where(
+          and(
+            eq(usersTable.id, parent.userId),
+            inArray(usersTable.id, membersOf(db, workspaceId)),
+            isNull(usersTable.deletedAt),
+          ),
+        );
       return (user as User) || null;
     },
   },
 
   User: {
-    todos: async (parent, _, { db }) => {
+    // Viewers don't see the email addresses of others
+    email: (parent, _, { viewer }) =>
+      hasRole(viewer, "MEMBER") || viewer?.userId === parent.id
+        ? parent.email
+        : null,
+
+    todos: async (parent, _, { db, viewer }) => {
+      const { workspaceId } = requireRole(viewer, "VIEWER");
       const todos = await db
         .select()
         .from(todosTable)
-        .where(eq(todosTable.userId, parent.id));
+        .where(
+          and(
+            eq(todosTable.userId, parent.id),
+            eq(todosTable.workspaceId, workspaceId),
+            isNull(todosTable.deletedAt),
+          ),
+        );
       return todos as Todo[];
     },
   },
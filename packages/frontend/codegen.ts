@@ -13,15 +13,12 @@ const config: CodegenConfig = {
       config: {
         reactQueryVersion: 5,
         useTypeImports: true,
-        fetcher: {
-          // endpoint is treated as a raw TS expression. Wrap the literal in quotes.
-          endpoint: "'/graphql'",
-          fetchParams: JSON.stringify({
-            headers: {
-              "Content-Type": "application/json",
-            },
-          }),
+        scalars: {
+          UUID: "string",
+          DateTime: "string",
         },
+        // Sends the access token and workspace, see fetcher.ts
+        fetcher: "./fetcher#fetcher",
         dedupeFragments: true,
         exposeFetcher: true,
         exposeQueryKeys: true,
@@ -193,7 +193,7 @@ export function TodosTable() {
                 <TableCell>
                   <FormControl size="small" sx={{ minWidth: 150 }}>
                     <Select
-                      value={todo.userId || ""}
+                      value={todo.user?.id || ""}
                       onChange={(e) =>
                         handleAssignUser(todo.id, e.target.value || null)
                       }
@@ -117,7 +117,7 @@ export function UsersPage() {
 
   const handleEdit = (user: User) => {
     setEditingUser(user);
-    setForm({ name: user.name, email: user.email });
+    setForm({ name: user.name, email: user.email ?? "" });
     setShowDialog(true);
   };
 
@@ -1,13 +1,45 @@
+// The access token of the logged in user and the workspace requests act in,
+// sent along with every request as the backend expects them
+const accessTokenKey = "accessToken";
+const workspaceIdKey = "workspaceId";
+
+export function setSession(accessToken: string, workspaceId?: string) {
+  localStorage.setItem(accessTokenKey, accessToken);
+  if (workspaceId) localStorage.setItem(workspaceIdKey, workspaceId);
+}
+
+export function setWorkspace(workspaceId: string) {
+  localStorage.setItem(workspaceIdKey, workspaceId);
+}
+
+export function clearSession() {
+  localStorage.removeItem(accessTokenKey);
+  localStorage.removeItem(workspaceIdKey);
+}
+
 export const fetcher = <TData, TVariables>(
   query: string,
   variables?: TVariables
 ) => {
   return async (): Promise<TData> => {
+    const headers: Record<string, string> = {
+      "content-type": "application/json",
+    };
+    const accessToken = localStorage.getItem(accessTokenKey);
+    if (accessToken) headers["Authorization"] = `Bearer ${accessToken}`;
+    const workspaceId = localStorage.getItem(workspaceIdKey);
+    if (workspaceId) headers["X-Workspace-ID"] = workspaceId;
+
     const res = await fetch("/graphql", {
       method: "POST",
-      headers: { "content-type": "application/json" },
+      headers,
+      credentials: "include",
       body: JSON.stringify({ query, variables }),
     });
+    // The backend rejects invalid tokens and workspaces before GraphQL runs
+    if (!res.ok && !res.headers.get("content-type")?.includes("json")) {
+      throw new Error((await res.text()) || res.statusText);
+    }
     const json = await res.json();
     if (json.errors?.length) throw new Error(json.errors[0].message);
     return json.data as TData;
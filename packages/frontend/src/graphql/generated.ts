@@ -1,4 +1,5 @@
 import { useQuery, useInfiniteQuery, useMutation, type UseQueryOptions, type UseInfiniteQueryOptions, type InfiniteData, type UseMutationOptions } from '@tanstack/react-query';
+import { fetcher } from './fetcher';
 export type Maybe<T> = T | null;
 export type InputMaybe<T> = Maybe<T>;
 export type Exact<T extends { [key: string]: unknown }> = { [K in keyof T]: T[K] };
@@ -6,26 +7,6 @@ export type MakeOptional<T, K extends keyof T> = Omit<T, K> & { [SubKey in K]?:
 export type MakeMaybe<T, K extends keyof T> = Omit<T, K> & { [SubKey in K]: Maybe<T[SubKey]> };
 export type MakeEmpty<T extends { [key: string]: unknown }, K extends keyof T> = { [_ in K]?: never };
 export type Incremental<T> = T | { [P in keyof T]?: P extends ' $fragmentName' | '__typename' ? T[P] : never };
-
-function fetcher<TData, TVariables>(query: string, variables?: TVariables) {
-  return async (): Promise<TData> => {
-    const res = await fetch('/graphql' as string, {
-    method: "POST",
-    ...({"headers":{"Content-Type":"application/json"}}),
-      body: JSON.stringify({ query, variables }),
-    });
-
-    const json = await res.json();
-
-    if (json.errors) {
-      const { message } = json.errors[0];
-
-      throw new Error(message);
-    }
-
-    return json.data;
-  }
-}
 /** All built-in and custom scalars, mapped to their actual values */
 export type Scalars = {
   ID: { input: string; output: string; }
@@ -33,11 +14,100 @@ export type Scalars = {
   Boolean: { input: boolean; output: boolean; }
   Int: { input: number; output: number; }
   Float: { input: number; output: number; }
+  DateTime: { input: string; output: string; }
+  UUID: { input: string; output: string; }
+};
+
+/** Comments are written by the logged in user. */
+export type AddCommentInput = {
+  body: Scalars['String']['input'];
+  todoId: Scalars['UUID']['input'];
+};
+
+/**
+ * The tokens of a session. Send the access token with every request as
+ * "Authorization: Bearer <token>" (or as authorization in the connection_init
+ * payload of websockets) and trade the refresh token for new tokens with
+ * refreshSession once it expired.
+ */
+export type AuthPayload = {
+  __typename?: 'AuthPayload';
+  /** A JWT, verifiable with the keys published at /.well-known/jwks.json. */
+  accessToken: Scalars['String']['output'];
+  /** When the access token stops working. */
+  expiresAt: Scalars['DateTime']['output'];
+  refreshToken: Scalars['String']['output'];
+  user: User;
+};
+
+export type ChangePasswordInput = {
+  /** Left out by users who don't have a password yet, like those of single sign-on. */
+  currentPassword?: InputMaybe<Scalars['String']['input']>;
+  /** At least 8 characters. */
+  newPassword: Scalars['String']['input'];
+};
+
+/** A comment in the discussion of a todo. */
+export type Comment = Node & {
+  __typename?: 'Comment';
+  /** Null once the author has been deleted. */
+  author?: Maybe<User>;
+  authorId?: Maybe<Scalars['UUID']['output']>;
+  body: Scalars['String']['output'];
+  createdAt: Scalars['DateTime']['output'];
+  /** Set once the body has been changed, see revisions. */
+  edited: Scalars['Boolean']['output'];
+  id: Scalars['ID']['output'];
+  /** The bodies the comment had before each edit, oldest first. */
+  revisions: Array<CommentRevision>;
+  todoId: Scalars['UUID']['output'];
+  updatedAt: Scalars['DateTime']['output'];
+};
+
+export type CommentConnection = {
+  __typename?: 'CommentConnection';
+  edges: Array<CommentEdge>;
+  pageInfo: PageInfo;
+  totalCount: Scalars['Int']['output'];
+};
+
+export type CommentEdge = {
+  __typename?: 'CommentEdge';
+  cursor: Scalars['String']['output'];
+  node: Comment;
+};
+
+/** The body a comment had until it was edited at createdAt. */
+export type CommentRevision = {
+  __typename?: 'CommentRevision';
+  body: Scalars['String']['output'];
+  createdAt: Scalars['DateTime']['output'];
+  id: Scalars['ID']['output'];
+};
+
+export type CreateProjectInput = {
+  description?: InputMaybe<Scalars['String']['input']>;
+  name: Scalars['String']['input'];
+  /** Defaults to the logged in user, only admins make others the owner. */
+  ownerId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+export type CreateTagInput = {
+  /** Defaults to #9E9E9E. */
+  color?: InputMaybe<Scalars['String']['input']>;
+  name: Scalars['String']['input'];
 };
 
 export type CreateTodoInput = {
+  dueAt?: InputMaybe<Scalars['DateTime']['input']>;
+  /** Creates the todo as a subtask of this one. */
+  parentId?: InputMaybe<Scalars['UUID']['input']>;
+  /** Defaults to MEDIUM. */
+  priority?: InputMaybe<TodoPriority>;
+  projectId?: InputMaybe<Scalars['UUID']['input']>;
   title: Scalars['String']['input'];
-  userId?: InputMaybe<Scalars['ID']['input']>;
+  /** @deprecated Use assignTodo. */
+  userId?: InputMaybe<Scalars['UUID']['input']>;
 };
 
 export type CreateUserInput = {
@@ -45,14 +115,210 @@ export type CreateUserInput = {
   name: Scalars['String']['input'];
 };
 
+export type CreateWorkspaceInput = {
+  name: Scalars['String']['input'];
+};
+
+export type EditCommentInput = {
+  body: Scalars['String']['input'];
+  id: Scalars['UUID']['input'];
+};
+
+/**
+ * An offer to join a workspace in a role. Users only become members of a
+ * workspace once they accept an invitation to it.
+ */
+export type Invitation = {
+  __typename?: 'Invitation';
+  createdAt: Scalars['DateTime']['output'];
+  id: Scalars['ID']['output'];
+  /** The role the user gets on accepting. */
+  role: Role;
+  /** The invited user. */
+  userId: Scalars['UUID']['output'];
+  workspaceId: Scalars['UUID']['output'];
+  workspaceName: Scalars['String']['output'];
+};
+
+export type LoginInput = {
+  email: Scalars['String']['input'];
+  password: Scalars['String']['input'];
+};
+
 export type Mutation = {
   __typename?: 'Mutation';
+  /**
+   * Makes the logged in user a member of the workspace of their invitation, in
+   * its role. Doesn't need a workspace to be selected. Fails with NOT_FOUND when
+   * the invitation doesn't exist or is for someone else.
+   */
+  acceptInvitation: Workspace;
+  addComment: Comment;
+  /** Tags the todo, skipping tags it already carries. */
+  addTagsToTodo: Todo;
+  /** Assigns the users after the current assignees, skipping users already assigned. */
+  assignTodo: Todo;
+  /** Withdraws an invitation to the current workspace. */
+  cancelInvitation: Scalars['Boolean']['output'];
+  /**
+   * Fails with UNAUTHENTICATED when the current password is wrong. Ends every
+   * other session of the user.
+   */
+  changePassword: Scalars['Boolean']['output'];
+  /**
+   * Lets a member of the current workspace without a password, like users
+   * created by admins, set up their first one. Fails with CONFLICT when they
+   * already have a password and with FORBIDDEN for members of other workspaces
+   * too, whose admins could take their account otherwise. Replaces earlier
+   * tokens for the user.
+   */
+  createPasswordSetup: PasswordSetup;
+  createProject: Project;
+  createTag: Tag;
   createTodo: Todo;
   createUser: User;
+  /**
+   * Creates a workspace owned by the logged in user. Doesn't need a workspace to
+   * be selected.
+   */
+  createWorkspace: Workspace;
+  /**
+   * Turns down an invitation of the logged in user. Doesn't need a workspace to
+   * be selected.
+   */
+  declineInvitation: Scalars['Boolean']['output'];
+  /** Deletes the comment along with its revisions. Only the author and admins delete a comment. */
+  deleteComment: Scalars['Boolean']['output'];
+  /** Keeps the todos of the project, which no longer belong to any. Only the owner and admins delete a project. */
+  deleteProject: Scalars['Boolean']['output'];
+  /** Removes the tag from every todo carrying it. */
+  deleteTag: Scalars['Boolean']['output'];
+  /**
+   * Moves the todo to the trash, from where restoreTodo brings it back until it
+   * is purged. Fails with NOT_FOUND when the todo doesn't exist.
+   */
   deleteTodo: Scalars['Boolean']['output'];
+  /**
+   * Moves the user to the trash, from where restoreUser brings it back until it
+   * is purged. Their todos stay assigned, but Todo.user is null in the meantime.
+   * Users only delete themselves. Fails with NOT_FOUND when the user doesn't
+   * exist.
+   */
   deleteUser: Scalars['Boolean']['output'];
+  /** Keeps the previous body as a revision. Only the author and admins edit a comment. */
+  editComment: Comment;
+  /**
+   * Invites an existing user to the current workspace. They only become a member
+   * once they accept. Users created through createUser join it as MEMBER on their
+   * own. Only owners can invite owners. Fails with CONFLICT when the user is
+   * already a member or invited.
+   */
+  inviteWorkspaceMember: Invitation;
+  /** Fails with UNAUTHENTICATED when the email or password is wrong. */
+  login: AuthPayload;
+  /** Ends the session of the request. */
+  logout: Scalars['Boolean']['output'];
+  /**
+   * Issues a new access token for the session the refresh token belongs to and
+   * replaces the refresh token. The old refresh token stops working.
+   */
+  refreshSession: AuthPayload;
+  /** Untags the todo, skipping tags it doesn't carry. */
+  removeTagsFromTodo: Todo;
+  /**
+   * Only owners can remove owners. Fails with NOT_FOUND when the user isn't a
+   * member, and with CONFLICT when they are the last owner.
+   */
+  removeWorkspaceMember: Scalars['Boolean']['output'];
+  /** Fails with NOT_FOUND when the todo doesn't exist or has been purged. */
+  restoreTodo: Todo;
+  /**
+   * Brings back a deleted member of the workspace. Fails with NOT_FOUND when the
+   * user doesn't exist or has been purged.
+   */
+  restoreUser: User;
+  /**
+   * Changes the role of a member. Only owners can make members owners or change
+   * the role of owners. Fails with NOT_FOUND when the user isn't a member, and
+   * with CONFLICT when they are the last owner.
+   */
+  setMemberRole: User;
+  /**
+   * Sets the first password of the user the token of a createPasswordSetup was
+   * issued to and logs them in. Fails with UNAUTHENTICATED when the token is
+   * wrong, expired or was used.
+   */
+  setUpPassword: AuthPayload;
+  /**
+   * Creates a user with a password and logs them in. The user doesn't join any
+   * workspace until they are added to one or create their own.
+   */
+  signUp: AuthPayload;
+  /**
+   * Unassigns the users, skipping users that aren't assigned. The next assignee
+   * in line becomes the first.
+   */
+  unassignTodo: Todo;
+  /** Does nothing when the user doesn't watch the todo. */
+  unwatchTodo: Todo;
+  /** Only the owner and admins update a project. */
+  updateProject: Project;
+  updateTag: Tag;
   updateTodo: Todo;
+  /**
+   * Users only change themselves, as every workspace they belong to shares them.
+   * Admins manage memberships instead, see removeWorkspaceMember.
+   */
   updateUser: User;
+  /** Does nothing when the user already watches the todo. */
+  watchTodo: Todo;
+};
+
+
+export type MutationAcceptInvitationArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationAddCommentArgs = {
+  input: AddCommentInput;
+};
+
+
+export type MutationAddTagsToTodoArgs = {
+  tagIds: Array<Scalars['UUID']['input']>;
+  todoId: Scalars['UUID']['input'];
+};
+
+
+export type MutationAssignTodoArgs = {
+  todoId: Scalars['UUID']['input'];
+  userIds: Array<Scalars['UUID']['input']>;
+};
+
+
+export type MutationCancelInvitationArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationChangePasswordArgs = {
+  input: ChangePasswordInput;
+};
+
+
+export type MutationCreatePasswordSetupArgs = {
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type MutationCreateProjectArgs = {
+  input: CreateProjectInput;
+};
+
+
+export type MutationCreateTagArgs = {
+  input: CreateTagInput;
 };
 
 
@@ -66,13 +332,118 @@ export type MutationCreateUserArgs = {
 };
 
 
+export type MutationCreateWorkspaceArgs = {
+  input: CreateWorkspaceInput;
+};
+
+
+export type MutationDeclineInvitationArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationDeleteCommentArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationDeleteProjectArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationDeleteTagArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
 export type MutationDeleteTodoArgs = {
-  id: Scalars['ID']['input'];
+  id: Scalars['UUID']['input'];
 };
 
 
 export type MutationDeleteUserArgs = {
-  id: Scalars['ID']['input'];
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationEditCommentArgs = {
+  input: EditCommentInput;
+};
+
+
+export type MutationInviteWorkspaceMemberArgs = {
+  role?: Role;
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type MutationLoginArgs = {
+  input: LoginInput;
+};
+
+
+export type MutationRefreshSessionArgs = {
+  refreshToken: Scalars['String']['input'];
+};
+
+
+export type MutationRemoveTagsFromTodoArgs = {
+  tagIds: Array<Scalars['UUID']['input']>;
+  todoId: Scalars['UUID']['input'];
+};
+
+
+export type MutationRemoveWorkspaceMemberArgs = {
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type MutationRestoreTodoArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationRestoreUserArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type MutationSetMemberRoleArgs = {
+  role: Role;
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type MutationSetUpPasswordArgs = {
+  input: SetUpPasswordInput;
+};
+
+
+export type MutationSignUpArgs = {
+  input: SignUpInput;
+};
+
+
+export type MutationUnassignTodoArgs = {
+  todoId: Scalars['UUID']['input'];
+  userIds: Array<Scalars['UUID']['input']>;
+};
+
+
+export type MutationUnwatchTodoArgs = {
+  todoId: Scalars['UUID']['input'];
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type MutationUpdateProjectArgs = {
+  input: UpdateProjectInput;
+};
+
+
+export type MutationUpdateTagArgs = {
+  input: UpdateTagInput;
 };
 
 
@@ -85,46 +456,505 @@ export type MutationUpdateUserArgs = {
   input: UpdateUserInput;
 };
 
+
+export type MutationWatchTodoArgs = {
+  todoId: Scalars['UUID']['input'];
+  userId: Scalars['UUID']['input'];
+};
+
+/**
+ * An object with a globally unique ID. The IDs of todos, users, tags, comments
+ * and projects are opaque global IDs naming the type and the UUID of the object,
+ * so any of them can be refetched through the node and nodes fields. They can be
+ * passed wherever a UUID of a node of the same type is expected too.
+ */
+export type Node = {
+  id: Scalars['ID']['output'];
+};
+
+/** Sort direction for list and connection orderings. */
+export enum OrderDirection {
+  Asc = 'ASC',
+  Desc = 'DESC'
+}
+
+/** Information about a page of a Relay-style connection. */
+export type PageInfo = {
+  __typename?: 'PageInfo';
+  endCursor?: Maybe<Scalars['String']['output']>;
+  hasNextPage: Scalars['Boolean']['output'];
+  hasPreviousPage: Scalars['Boolean']['output'];
+  startCursor?: Maybe<Scalars['String']['output']>;
+};
+
+/**
+ * A one-time token a user without a password sets up their first one with,
+ * see setUpPassword. Admins hand it to them, e.g. in a link to the frontend.
+ */
+export type PasswordSetup = {
+  __typename?: 'PasswordSetup';
+  /** When the token stops working. */
+  expiresAt: Scalars['DateTime']['output'];
+  token: Scalars['String']['output'];
+};
+
+/**
+ * A list grouping todos. The todos of archived projects are left out of the
+ * todos, todosConnection, overdueTodos and Tag.todos listings unless
+ * includeArchived is set.
+ */
+export type Project = Node & {
+  __typename?: 'Project';
+  archived: Scalars['Boolean']['output'];
+  createdAt: Scalars['DateTime']['output'];
+  description?: Maybe<Scalars['String']['output']>;
+  id: Scalars['ID']['output'];
+  name: Scalars['String']['output'];
+  /** Null once the owner has been deleted. */
+  owner?: Maybe<User>;
+  ownerId?: Maybe<Scalars['UUID']['output']>;
+  /** Lists the todos of the project, whether it is archived or not. */
+  todos: TodoConnection;
+  updatedAt: Scalars['DateTime']['output'];
+};
+
+
+export type ProjectTodosArgs = {
+  after?: InputMaybe<Scalars['String']['input']>;
+  before?: InputMaybe<Scalars['String']['input']>;
+  first?: InputMaybe<Scalars['Int']['input']>;
+  last?: InputMaybe<Scalars['Int']['input']>;
+  orderBy?: InputMaybe<TodoOrder>;
+  where?: InputMaybe<TodoWhereInput>;
+};
+
 export type Query = {
   __typename?: 'Query';
+  /**
+   * The pending invitations of the logged in user, newest first. Doesn't need a
+   * workspace to be selected.
+   */
+  invitations: Array<Invitation>;
+  node?: Maybe<Node>;
+  nodes: Array<Maybe<Node>>;
+  /** Open todos assigned to the user that are past their due date, most overdue first. */
+  overdueTodos: Array<Todo>;
+  project?: Maybe<Project>;
+  /** Ordered by name. */
+  projects: Array<Project>;
+  /** Every tag, ordered by name. */
+  tags: Array<Tag>;
   todos: Array<Todo>;
+  todosConnection: TodoConnection;
   users: Array<User>;
+  usersConnection: UserConnection;
+  /** The logged in user, null for anonymous requests. */
+  viewer?: Maybe<User>;
+  /** The workspace the request acts in. */
+  workspace: Workspace;
+};
+
+
+export type QueryNodeArgs = {
+  id: Scalars['ID']['input'];
+};
+
+
+export type QueryNodesArgs = {
+  ids: Array<Scalars['ID']['input']>;
+};
+
+
+export type QueryOverdueTodosArgs = {
+  userId: Scalars['UUID']['input'];
+};
+
+
+export type QueryProjectArgs = {
+  id: Scalars['UUID']['input'];
+};
+
+
+export type QueryProjectsArgs = {
+  includeArchived?: Scalars['Boolean']['input'];
+};
+
+
+export type QueryTodosArgs = {
+  orderBy?: InputMaybe<TodoOrder>;
+  where?: InputMaybe<TodoWhereInput>;
+};
+
+
+export type QueryTodosConnectionArgs = {
+  after?: InputMaybe<Scalars['String']['input']>;
+  before?: InputMaybe<Scalars['String']['input']>;
+  first?: InputMaybe<Scalars['Int']['input']>;
+  last?: InputMaybe<Scalars['Int']['input']>;
+  orderBy?: InputMaybe<TodoOrder>;
+  where?: InputMaybe<TodoWhereInput>;
+};
+
+
+export type QueryUsersArgs = {
+  orderBy?: InputMaybe<UserOrder>;
+  where?: InputMaybe<UserWhereInput>;
+};
+
+
+export type QueryUsersConnectionArgs = {
+  after?: InputMaybe<Scalars['String']['input']>;
+  before?: InputMaybe<Scalars['String']['input']>;
+  first?: InputMaybe<Scalars['Int']['input']>;
+  last?: InputMaybe<Scalars['Int']['input']>;
+  orderBy?: InputMaybe<UserOrder>;
+  where?: InputMaybe<UserWhereInput>;
+};
+
+/**
+ * What a member may do in the current workspace. Every role may do what the
+ * roles below it may.
+ */
+export enum Role {
+  /** Manages users and members. */
+  Admin = 'ADMIN',
+  /** Works on todos, tags, projects and comments. */
+  Member = 'MEMBER',
+  /** Manages the workspace, including who else owns it. */
+  Owner = 'OWNER',
+  /** Reads the workspace, except for the email addresses of users. */
+  Viewer = 'VIEWER'
+}
+
+export type SetUpPasswordInput = {
+  /** At least 8 characters. */
+  password: Scalars['String']['input'];
+  token: Scalars['String']['input'];
+};
+
+export type SignUpInput = {
+  email: Scalars['String']['input'];
+  name: Scalars['String']['input'];
+  /** At least 8 characters. */
+  password: Scalars['String']['input'];
 };
 
-export type Todo = {
+/**
+ * Live todo changes. Passing userId only streams todos assigned to that user,
+ * first or not.
+ */
+export type Subscription = {
+  __typename?: 'Subscription';
+  todoCreated: Todo;
+  /** Emits the last state of each deleted todo. */
+  todoDeleted: Todo;
+  /** Emits each open todo once as it comes due, an hour before its due date unless configured otherwise. */
+  todoReminder: Todo;
+  todoUpdated: Todo;
+};
+
+
+export type SubscriptionTodoCreatedArgs = {
+  userId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+
+export type SubscriptionTodoDeletedArgs = {
+  userId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+
+export type SubscriptionTodoReminderArgs = {
+  userId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+
+export type SubscriptionTodoUpdatedArgs = {
+  userId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+/** A label todos can be grouped by. Tag names are unique within a workspace. */
+export type Tag = Node & {
+  __typename?: 'Tag';
+  /** A hex color like #9E9E9E. */
+  color: Scalars['String']['output'];
+  createdAt: Scalars['DateTime']['output'];
+  id: Scalars['ID']['output'];
+  name: Scalars['String']['output'];
+  todos: TodoConnection;
+  updatedAt: Scalars['DateTime']['output'];
+};
+
+
+export type TagTodosArgs = {
+  after?: InputMaybe<Scalars['String']['input']>;
+  before?: InputMaybe<Scalars['String']['input']>;
+  first?: InputMaybe<Scalars['Int']['input']>;
+  last?: InputMaybe<Scalars['Int']['input']>;
+  orderBy?: InputMaybe<TodoOrder>;
+  where?: InputMaybe<TodoWhereInput>;
+};
+
+export type Todo = Node & {
   __typename?: 'Todo';
+  /** Ordered by when they were assigned. The first of them is also user. */
+  assignees: Array<User>;
+  /** Oldest first. */
+  comments: CommentConnection;
   completed: Scalars['Boolean']['output'];
+  /** Set when the todo was last completed, null while it is open. */
+  completedAt?: Maybe<Scalars['DateTime']['output']>;
+  createdAt: Scalars['DateTime']['output'];
+  /** Set while the todo is in the trash, see includeDeleted. */
+  deletedAt?: Maybe<Scalars['DateTime']['output']>;
+  /** When the todo should be done by. Open todos are announced by todoReminder shortly before. */
+  dueAt?: Maybe<Scalars['DateTime']['output']>;
   id: Scalars['ID']['output'];
+  /** The todo this one is a subtask of. */
+  parent?: Maybe<Todo>;
+  parentId?: Maybe<Scalars['UUID']['output']>;
+  priority: TodoPriority;
+  /** The fraction of subtasks that are completed, from 0 to 1. Null without subtasks. */
+  progress?: Maybe<Scalars['Float']['output']>;
+  project?: Maybe<Project>;
+  projectId?: Maybe<Scalars['UUID']['output']>;
+  /** Set when todoReminder announced the todo, cleared when the due date changes. */
+  remindedAt?: Maybe<Scalars['DateTime']['output']>;
+  subtasks: Array<Todo>;
+  /** Ordered by name. */
+  tags: Array<Tag>;
   title: Scalars['String']['output'];
+  updatedAt: Scalars['DateTime']['output'];
+  /** @deprecated Use assignees, this is the first of them. */
   user?: Maybe<User>;
-  userId?: Maybe<Scalars['ID']['output']>;
+  /** @deprecated Use assignees, this is the first of them. */
+  userId?: Maybe<Scalars['UUID']['output']>;
+  /** Bumped on every change. Pass it back as expectedVersion to detect concurrent edits. */
+  version: Scalars['Int']['output'];
+  /** Ordered by name. */
+  watchers: Array<User>;
+};
+
+
+export type TodoCommentsArgs = {
+  after?: InputMaybe<Scalars['String']['input']>;
+  before?: InputMaybe<Scalars['String']['input']>;
+  first?: InputMaybe<Scalars['Int']['input']>;
+  last?: InputMaybe<Scalars['Int']['input']>;
+};
+
+export type TodoConnection = {
+  __typename?: 'TodoConnection';
+  edges: Array<TodoEdge>;
+  pageInfo: PageInfo;
+  totalCount: Scalars['Int']['output'];
+};
+
+export type TodoEdge = {
+  __typename?: 'TodoEdge';
+  cursor: Scalars['String']['output'];
+  node: Todo;
+};
+
+export type TodoOrder = {
+  direction?: OrderDirection;
+  field: TodoOrderField;
+};
+
+/**
+ * Todos without a due or completion date sort after all others in ascending
+ * order. PRIORITY sorts from LOW to URGENT.
+ */
+export enum TodoOrderField {
+  Completed = 'COMPLETED',
+  CompletedAt = 'COMPLETED_AT',
+  CreatedAt = 'CREATED_AT',
+  DueAt = 'DUE_AT',
+  Priority = 'PRIORITY',
+  Title = 'TITLE',
+  UpdatedAt = 'UPDATED_AT'
+}
+
+export enum TodoPriority {
+  High = 'HIGH',
+  Low = 'LOW',
+  Medium = 'MEDIUM',
+  Urgent = 'URGENT'
+}
+
+/** Filters for todo lists. Every field that is set must match. */
+export type TodoWhereInput = {
+  /** True lists the todos the logged in user is assigned to, false the others. */
+  assignedToMe?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Only todos this user is assigned to, first or not. */
+  assigneeId?: InputMaybe<Scalars['UUID']['input']>;
+  completed?: InputMaybe<Scalars['Boolean']['input']>;
+  completedAfter?: InputMaybe<Scalars['DateTime']['input']>;
+  completedBefore?: InputMaybe<Scalars['DateTime']['input']>;
+  dueAfter?: InputMaybe<Scalars['DateTime']['input']>;
+  dueBefore?: InputMaybe<Scalars['DateTime']['input']>;
+  /** Only todos carrying every one of these tags. */
+  hasAllTags?: InputMaybe<Array<Scalars['UUID']['input']>>;
+  /** Only todos carrying at least one of these tags. */
+  hasAnyTag?: InputMaybe<Array<Scalars['UUID']['input']>>;
+  /** False lists todos nobody is assigned to, true todos with assignees. */
+  hasAssignees?: InputMaybe<Scalars['Boolean']['input']>;
+  hasDueDate?: InputMaybe<Scalars['Boolean']['input']>;
+  /** False lists top-level todos only, true subtasks only. */
+  hasParent?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Also return the todos of archived projects. */
+  includeArchived?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Also return deleted todos that haven't been purged yet. Meant for admin tools. */
+  includeDeleted?: InputMaybe<Scalars['Boolean']['input']>;
+  priorityIn?: InputMaybe<Array<TodoPriority>>;
+  projectIdIn?: InputMaybe<Array<Scalars['UUID']['input']>>;
+  titleContains?: InputMaybe<Scalars['String']['input']>;
+  /** Only todos changed after this point in time, for incremental sync. */
+  updatedAfter?: InputMaybe<Scalars['DateTime']['input']>;
+  /** @deprecated Matches the first assignee only, use assigneeId. */
+  userIdIn?: InputMaybe<Array<Scalars['UUID']['input']>>;
+  /** @deprecated Use hasAssignees. */
+  userIdIsNull?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Only todos this user watches. */
+  watcherId?: InputMaybe<Scalars['UUID']['input']>;
+};
+
+export type UpdateProjectInput = {
+  archived?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Leave out to keep the description, pass null to clear it. */
+  description?: InputMaybe<Scalars['String']['input']>;
+  id: Scalars['UUID']['input'];
+  name?: InputMaybe<Scalars['String']['input']>;
+};
+
+export type UpdateTagInput = {
+  color?: InputMaybe<Scalars['String']['input']>;
+  id: Scalars['UUID']['input'];
+  name?: InputMaybe<Scalars['String']['input']>;
 };
 
 export type UpdateTodoInput = {
+  /** Along with done: true, also completes every subtask below the todo. */
+  completeSubtasks?: InputMaybe<Scalars['Boolean']['input']>;
   done?: InputMaybe<Scalars['Boolean']['input']>;
-  id: Scalars['ID']['input'];
+  /** Leave out to keep the due date, pass null to clear it. */
+  dueAt?: InputMaybe<Scalars['DateTime']['input']>;
+  /**
+   * Fails the update with CONFLICT when the todo is at another version, i.e.
+   * someone else changed it since it was read. The error carries the current
+   * todo in extensions.current.
+   */
+  expectedVersion?: InputMaybe<Scalars['Int']['input']>;
+  id: Scalars['UUID']['input'];
+  /**
+   * Leave out to keep the parent, pass null to make the todo top-level. Fails
+   * with INVALID_ARGUMENT when the todo would end up among its own subtasks.
+   */
+  parentId?: InputMaybe<Scalars['UUID']['input']>;
+  priority?: InputMaybe<TodoPriority>;
+  /** Leave out to keep the project, pass null to take the todo out of it. */
+  projectId?: InputMaybe<Scalars['UUID']['input']>;
   title?: InputMaybe<Scalars['String']['input']>;
-  userId?: InputMaybe<Scalars['ID']['input']>;
+  /**
+   * Leave out to keep the current assignees, pass a user to make them the only
+   * assignee or null to unassign everyone.
+   * @deprecated Use assignTodo and unassignTodo.
+   */
+  userId?: InputMaybe<Scalars['UUID']['input']>;
 };
 
 export type UpdateUserInput = {
   email?: InputMaybe<Scalars['String']['input']>;
-  id: Scalars['ID']['input'];
+  /**
+   * Fails the update with CONFLICT when the user is at another version, i.e.
+   * someone else changed it since it was read. The error carries the current
+   * user in extensions.current.
+   */
+  expectedVersion?: InputMaybe<Scalars['Int']['input']>;
+  id: Scalars['UUID']['input'];
   name?: InputMaybe<Scalars['String']['input']>;
 };
 
-export type User = {
+export type User = Node & {
   __typename?: 'User';
-  email: Scalars['String']['output'];
+  createdAt: Scalars['DateTime']['output'];
+  /** Set while the user is in the trash, see includeDeleted. */
+  deletedAt?: Maybe<Scalars['DateTime']['output']>;
+  /** Null for viewers, see Role. */
+  email?: Maybe<Scalars['String']['output']>;
   id: Scalars['ID']['output'];
   name: Scalars['String']['output'];
+  /**
+   * The todos the user is the first assignee of, see Todo.user. Filter todos by
+   * assigneeId for every todo they are assigned to.
+   */
   todos: Array<Todo>;
+  updatedAt: Scalars['DateTime']['output'];
+  /** Bumped on every change. Pass it back as expectedVersion to detect concurrent edits. */
+  version: Scalars['Int']['output'];
+};
+
+export type UserConnection = {
+  __typename?: 'UserConnection';
+  edges: Array<UserEdge>;
+  pageInfo: PageInfo;
+  totalCount: Scalars['Int']['output'];
+};
+
+export type UserEdge = {
+  __typename?: 'UserEdge';
+  cursor: Scalars['String']['output'];
+  node: User;
+};
+
+export type UserOrder = {
+  direction?: OrderDirection;
+  field: UserOrderField;
+};
+
+export enum UserOrderField {
+  CreatedAt = 'CREATED_AT',
+  /** Needs the MEMBER role. */
+  Email = 'EMAIL',
+  Name = 'NAME',
+  UpdatedAt = 'UPDATED_AT'
+}
+
+/** Filters for user lists. Every field that is set must match. */
+export type UserWhereInput = {
+  email?: InputMaybe<Scalars['String']['input']>;
+  emailContains?: InputMaybe<Scalars['String']['input']>;
+  /** Whether the user is the first assignee of any todo. */
+  hasTodos?: InputMaybe<Scalars['Boolean']['input']>;
+  /** Also return deleted users that haven't been purged yet. Meant for admin tools. */
+  includeDeleted?: InputMaybe<Scalars['Boolean']['input']>;
+  nameContains?: InputMaybe<Scalars['String']['input']>;
+  /** Only users changed after this point in time, for incremental sync. */
+  updatedAfter?: InputMaybe<Scalars['DateTime']['input']>;
+};
+
+/**
+ * A tenant of the API. Requests act in the workspace selected by the
+ * X-Workspace-ID header (or the workspaceId query parameter for websockets) and
+ * only see and change its todos, tags, projects and comments, and the users that
+ * are members of it.
+ */
+export type Workspace = {
+  __typename?: 'Workspace';
+  createdAt: Scalars['DateTime']['output'];
+  id: Scalars['ID']['output'];
+  /** Ordered by name. */
+  members: Array<User>;
+  name: Scalars['String']['output'];
+  updatedAt: Scalars['DateTime']['output'];
+  /** The role of the logged in user, null for anonymous requests. */
+  viewerRole?: Maybe<Role>;
 };
 
 export type GetTodosQueryVariables = Exact<{ [key: string]: never; }>;
 
 
-export type GetTodosQuery = { __typename?: 'Query', todos: Array<{ __typename?: 'Todo', id: string, title: string, completed: boolean, userId?: string | null, user?: { __typename?: 'User', id: string, name: string, email: string } | null }> };
+export type GetTodosQuery = { __typename?: 'Query', todos: Array<{ __typename?: 'Todo', id: string, title: string, completed: boolean, userId?: string | null, user?: { __typename?: 'User', id: string, name: string, email?: string | null } | null }> };
 
 export type CreateTodoMutationVariables = Exact<{
   input: CreateTodoInput;
@@ -141,7 +971,7 @@ export type UpdateTodoMutationVariables = Exact<{
 export type UpdateTodoMutation = { __typename?: 'Mutation', updateTodo: { __typename?: 'Todo', id: string, title: string, completed: boolean, userId?: string | null } };
 
 export type DeleteTodoMutationVariables = Exact<{
-  id: Scalars['ID']['input'];
+  id: Scalars['UUID']['input'];
 }>;
 
 
@@ -150,24 +980,24 @@ export type DeleteTodoMutation = { __typename?: 'Mutation', deleteTodo: boolean
 export type GetUsersQueryVariables = Exact<{ [key: string]: never; }>;
 
 
-export type GetUsersQuery = { __typename?: 'Query', users: Array<{ __typename?: 'User', id: string, name: string, email: string }> };
+export type GetUsersQuery = { __typename?: 'Query', users: Array<{ __typename?: 'User', id: string, name: string, email?: string | null }> };
 
 export type CreateUserMutationVariables = Exact<{
   input: CreateUserInput;
 }>;
 
 
-export type CreateUserMutation = { __typename?: 'Mutation', createUser: { __typename?: 'User', id: string, name: string, email: string } };
+export type CreateUserMutation = { __typename?: 'Mutation', createUser: { __typename?: 'User', id: string, name: string, email?: string | null } };
 
 export type UpdateUserMutationVariables = Exact<{
   input: UpdateUserInput;
 }>;
 
 
-export type UpdateUserMutation = { __typename?: 'Mutation', updateUser: { __typename?: 'User', id: string, name: string, email: string } };
+export type UpdateUserMutation = { __typename?: 'Mutation', updateUser: { __typename?: 'User', id: string, name: string, email?: string | null } };
 
 export type DeleteUserMutationVariables = Exact<{
-  id: Scalars['ID']['input'];
+  id: Scalars['UUID']['input'];
 }>;
 
 
@@ -292,7 +1122,7 @@ useUpdateTodoMutation.getKey = () => ['UpdateTodo'];
 useUpdateTodoMutation.fetcher = (variables: UpdateTodoMutationVariables) => fetcher<UpdateTodoMutation, UpdateTodoMutationVariables>(UpdateTodoDocument, variables);
 
 export const DeleteTodoDocument = `
-    mutation DeleteTodo($id: ID!) {
+    mutation DeleteTodo($id: UUID!) {
   deleteTodo(id: $id)
 }
     `;
@@ -424,7 +1254,7 @@ useUpdateUserMutation.getKey = () => ['UpdateUser'];
 useUpdateUserMutation.fetcher = (variables: UpdateUserMutationVariables) => fetcher<UpdateUserMutation, UpdateUserMutationVariables>(UpdateUserDocument, variables);
 
 export const DeleteUserDocument = `
-    mutation DeleteUser($id: ID!) {
+    mutation DeleteUser($id: UUID!) {
   deleteUser(id: $id)
 }
     `;